@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/user/domain"
+	"github.com/dukk308/beetool.dev-go-starter/internal/server"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/utils"
+	"github.com/spf13/cobra"
+)
+
+// adminPasswordEnv lets scripts pass the password without it showing up in the
+// process list or shell history.
+const adminPasswordEnv = "ADMIN_PASSWORD"
+
+var (
+	createAdminEmail    string
+	createAdminUsername string
+	createAdminPassword string
+)
+
+var createAdminCmd = &cobra.Command{
+	Use:   "create-admin",
+	Short: "Create an admin account",
+	Long:  "Create an admin account is a command that bootstraps an admin with a verified email, using the server's database configuration",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := os.Setenv("TZ", "UTC"); err != nil {
+			panic(err)
+		}
+
+		utils.ParseFlags()
+
+		password := createAdminPassword
+		if password == "" {
+			password = os.Getenv(adminPasswordEnv)
+		}
+		if createAdminEmail == "" || password == "" {
+			return fmt.Errorf("--email and --password (or %s) are required", adminPasswordEnv)
+		}
+
+		username := createAdminUsername
+		if username == "" {
+			username = createAdminEmail
+		}
+
+		admin, err := server.CreateAdmin(cmd.Context(), &domain.DTOCreateUser{
+			Username: username,
+			Email:    createAdminEmail,
+			Password: password,
+		})
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "admin %s created with id %s\n", admin.Email.Value, admin.ID)
+		return nil
+	},
+}
+
+func init() {
+	createAdminCmd.Flags().StringVar(&createAdminEmail, "email", "", "Admin email address")
+	createAdminCmd.Flags().StringVar(&createAdminUsername, "username", "", "Admin username, defaults to the email")
+	createAdminCmd.Flags().StringVar(&createAdminPassword, "password", "", "Admin password, read from "+adminPasswordEnv+" when empty")
+	rootCmd.AddCommand(createAdminCmd)
+}
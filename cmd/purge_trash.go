@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/server"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/utils"
+	"github.com/spf13/cobra"
+)
+
+var purgeTrashCmd = &cobra.Command{
+	Use:   "purge-trash",
+	Short: "Purge soft-deleted records",
+	Long:  "Purge soft-deleted records is a command that permanently deletes records kept in the trash for longer than -trash-retention",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := os.Setenv("TZ", "UTC"); err != nil {
+			panic(err)
+		}
+
+		utils.ParseFlags()
+		return server.PurgeTrash(cmd.Context())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(purgeTrashCmd)
+}
@@ -1,17 +1,25 @@
 package cmd
 
 import (
-	"fmt"
+	"os"
 
+	"github.com/dukk308/beetool.dev-go-starter/internal/server"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/utils"
 	"github.com/spf13/cobra"
 )
 
 var workerCmd = &cobra.Command{
 	Use:   "worker",
 	Short: "Start the worker",
-	Long:  "Start the worker is a command that starts the worker",
+	Long:  "Start the worker is a command that starts the worker, which runs the RabbitMQ consumers and publishes domain events from the outbox",
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Worker started")
+		if err := os.Setenv("TZ", "UTC"); err != nil {
+			panic(err)
+		}
+
+		utils.ParseFlags()
+		app := server.BootstrapWorker(cmd.Context())
+		app.Run()
 	},
 }
 
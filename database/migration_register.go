@@ -1,13 +1,20 @@
 package database
 
 import (
+	auth_persistence "github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/infrastructure/persistence"
 	blog_persistence "github.com/dukk308/beetool.dev-go-starter/internal/modules/blog/infrastructure/persistence"
 	note_persistence "github.com/dukk308/beetool.dev-go-starter/internal/modules/note/infrastructure/persistence"
 	user_persistence "github.com/dukk308/beetool.dev-go-starter/internal/modules/user/infrastructure/persistence"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gorm_comp"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/outbox_comp"
 )
 
 var Models = []interface{}{
 	user_persistence.SQLUser{},
 	note_persistence.SQLNote{},
 	blog_persistence.SQLBlog{},
+	auth_persistence.SQLUserMFA{},
+	auth_persistence.SQLAPIKey{},
+	gorm_comp.AuditLog{},
+	outbox_comp.OutboxMessage{},
 }
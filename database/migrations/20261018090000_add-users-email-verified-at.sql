@@ -0,0 +1,7 @@
+-- +goose Up
+-- modify "users" table
+ALTER TABLE "public"."users" ADD COLUMN "email_verified_at" timestamp NULL;
+
+-- +goose Down
+-- reverse: modify "users" table
+ALTER TABLE "public"."users" DROP COLUMN "email_verified_at";
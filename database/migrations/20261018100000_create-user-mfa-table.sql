@@ -0,0 +1,25 @@
+-- +goose Up
+-- create "user_mfa" table
+CREATE TABLE "public"."user_mfa" (
+  "id" text NOT NULL,
+  "created_at" timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  "updated_at" timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  "deleted_at" timestamp NULL,
+  "created_by" text NULL,
+  "updated_by" text NULL,
+  "deleted_by" text NULL,
+  "user_id" text NOT NULL,
+  "secret" character varying(255) NOT NULL,
+  "recovery_codes" text NULL,
+  "last_used_step" bigint NOT NULL DEFAULT 0,
+  "enabled_at" timestamp NULL,
+  PRIMARY KEY ("id")
+);
+-- create index "uni_user_mfa_user_id" to table: "user_mfa"
+CREATE UNIQUE INDEX "uni_user_mfa_user_id" ON "public"."user_mfa" ("user_id");
+
+-- +goose Down
+-- reverse: create index "uni_user_mfa_user_id" to table: "user_mfa"
+DROP INDEX "public"."uni_user_mfa_user_id";
+-- reverse: create "user_mfa" table
+DROP TABLE "public"."user_mfa";
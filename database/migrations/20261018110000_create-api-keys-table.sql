@@ -0,0 +1,31 @@
+-- +goose Up
+-- create "api_keys" table
+CREATE TABLE "public"."api_keys" (
+  "id" text NOT NULL,
+  "created_at" timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  "updated_at" timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  "deleted_at" timestamp NULL,
+  "created_by" text NULL,
+  "updated_by" text NULL,
+  "deleted_by" text NULL,
+  "user_id" text NOT NULL,
+  "name" character varying(100) NOT NULL,
+  "prefix" character varying(32) NOT NULL,
+  "key_hash" character varying(64) NOT NULL,
+  "scopes" text NULL,
+  "expires_at" timestamp NULL,
+  "last_used_at" timestamp NULL,
+  PRIMARY KEY ("id")
+);
+-- create index "idx_api_keys_user_id" to table: "api_keys"
+CREATE INDEX "idx_api_keys_user_id" ON "public"."api_keys" ("user_id");
+-- create index "uni_api_keys_prefix" to table: "api_keys"
+CREATE UNIQUE INDEX "uni_api_keys_prefix" ON "public"."api_keys" ("prefix");
+
+-- +goose Down
+-- reverse: create index "uni_api_keys_prefix" to table: "api_keys"
+DROP INDEX "public"."uni_api_keys_prefix";
+-- reverse: create index "idx_api_keys_user_id" to table: "api_keys"
+DROP INDEX "public"."idx_api_keys_user_id";
+-- reverse: create "api_keys" table
+DROP TABLE "public"."api_keys";
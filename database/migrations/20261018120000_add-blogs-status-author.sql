@@ -0,0 +1,17 @@
+-- +goose Up
+-- modify "blogs" table
+ALTER TABLE "public"."blogs" ADD COLUMN "status" character varying(20) NOT NULL DEFAULT 'draft', ADD COLUMN "author_id" text NULL, ADD COLUMN "published_at" timestamp NULL;
+-- posts created before drafts existed were public
+UPDATE "public"."blogs" SET "status" = 'published', "published_at" = "created_at";
+-- create index "idx_blogs_status" to table: "blogs"
+CREATE INDEX "idx_blogs_status" ON "public"."blogs" ("status");
+-- create index "idx_blogs_author_id" to table: "blogs"
+CREATE INDEX "idx_blogs_author_id" ON "public"."blogs" ("author_id");
+
+-- +goose Down
+-- reverse: create index "idx_blogs_author_id" to table: "blogs"
+DROP INDEX "public"."idx_blogs_author_id";
+-- reverse: create index "idx_blogs_status" to table: "blogs"
+DROP INDEX "public"."idx_blogs_status";
+-- reverse: modify "blogs" table
+ALTER TABLE "public"."blogs" DROP COLUMN "published_at", DROP COLUMN "author_id", DROP COLUMN "status";
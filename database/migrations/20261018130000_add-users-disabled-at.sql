@@ -0,0 +1,7 @@
+-- +goose Up
+-- modify "users" table
+ALTER TABLE "public"."users" ADD COLUMN "disabled_at" timestamp NULL;
+
+-- +goose Down
+-- reverse: modify "users" table
+ALTER TABLE "public"."users" DROP COLUMN "disabled_at";
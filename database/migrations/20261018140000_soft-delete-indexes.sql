@@ -0,0 +1,27 @@
+-- +goose Up
+-- create index "idx_users_deleted_at" to table: "users"
+CREATE INDEX "idx_users_deleted_at" ON "public"."users" ("deleted_at");
+-- create index "idx_blogs_deleted_at" to table: "blogs"
+CREATE INDEX "idx_blogs_deleted_at" ON "public"."blogs" ("deleted_at");
+-- create index "idx_user_mfa_deleted_at" to table: "user_mfa"
+CREATE INDEX "idx_user_mfa_deleted_at" ON "public"."user_mfa" ("deleted_at");
+-- create index "idx_api_keys_deleted_at" to table: "api_keys"
+CREATE INDEX "idx_api_keys_deleted_at" ON "public"."api_keys" ("deleted_at");
+-- drop index "uni_blogs_slug" from table: "blogs"
+DROP INDEX "public"."uni_blogs_slug";
+-- create index "uni_blogs_slug" to table: "blogs", deleted posts keep their slug without blocking it
+CREATE UNIQUE INDEX "uni_blogs_slug" ON "public"."blogs" ("slug") WHERE (deleted_at IS NULL);
+
+-- +goose Down
+-- reverse: create index "uni_blogs_slug" to table: "blogs"
+DROP INDEX "public"."uni_blogs_slug";
+-- reverse: drop index "uni_blogs_slug" from table: "blogs"
+CREATE UNIQUE INDEX "uni_blogs_slug" ON "public"."blogs" ("slug");
+-- reverse: create index "idx_api_keys_deleted_at" to table: "api_keys"
+DROP INDEX "public"."idx_api_keys_deleted_at";
+-- reverse: create index "idx_user_mfa_deleted_at" to table: "user_mfa"
+DROP INDEX "public"."idx_user_mfa_deleted_at";
+-- reverse: create index "idx_blogs_deleted_at" to table: "blogs"
+DROP INDEX "public"."idx_blogs_deleted_at";
+-- reverse: create index "idx_users_deleted_at" to table: "users"
+DROP INDEX "public"."idx_users_deleted_at";
@@ -0,0 +1,25 @@
+-- +goose Up
+-- create "audit_logs" table
+CREATE TABLE "public"."audit_logs" (
+  "id" text NOT NULL,
+  "actor_id" text NULL,
+  "entity" text NOT NULL,
+  "entity_id" text NOT NULL,
+  "action" text NOT NULL,
+  "changes" jsonb NOT NULL,
+  "request_id" text NULL,
+  "created_at" timestamp NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- create index "idx_audit_logs_actor_id" to table: "audit_logs"
+CREATE INDEX "idx_audit_logs_actor_id" ON "public"."audit_logs" ("actor_id", "created_at");
+-- create index "idx_audit_logs_entity" to table: "audit_logs"
+CREATE INDEX "idx_audit_logs_entity" ON "public"."audit_logs" ("entity", "entity_id", "created_at");
+
+-- +goose Down
+-- reverse: create index "idx_audit_logs_entity" to table: "audit_logs"
+DROP INDEX "public"."idx_audit_logs_entity";
+-- reverse: create index "idx_audit_logs_actor_id" to table: "audit_logs"
+DROP INDEX "public"."idx_audit_logs_actor_id";
+-- reverse: create "audit_logs" table
+DROP TABLE "public"."audit_logs";
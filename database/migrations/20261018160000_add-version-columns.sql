@@ -0,0 +1,19 @@
+-- +goose Up
+-- modify "users" table
+ALTER TABLE "public"."users" ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
+-- modify "blogs" table
+ALTER TABLE "public"."blogs" ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
+-- modify "user_mfa" table
+ALTER TABLE "public"."user_mfa" ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
+-- modify "api_keys" table
+ALTER TABLE "public"."api_keys" ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
+
+-- +goose Down
+-- reverse: modify "api_keys" table
+ALTER TABLE "public"."api_keys" DROP COLUMN "version";
+-- reverse: modify "user_mfa" table
+ALTER TABLE "public"."user_mfa" DROP COLUMN "version";
+-- reverse: modify "blogs" table
+ALTER TABLE "public"."blogs" DROP COLUMN "version";
+-- reverse: modify "users" table
+ALTER TABLE "public"."users" DROP COLUMN "version";
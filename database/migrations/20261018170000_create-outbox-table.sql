@@ -0,0 +1,34 @@
+-- +goose Up
+-- create "outbox" table
+CREATE TABLE "public"."outbox" (
+  "id" text NOT NULL,
+  "seq" bigserial NOT NULL,
+  "aggregate_type" character varying(100) NOT NULL,
+  "aggregate_id" text NOT NULL,
+  "event_type" character varying(255) NOT NULL,
+  "payload" jsonb NOT NULL,
+  "request_id" text NULL,
+  "status" character varying(20) NOT NULL DEFAULT 'pending',
+  "attempts" bigint NOT NULL DEFAULT 0,
+  "next_attempt_at" timestamp NOT NULL,
+  "last_error" text NULL,
+  "occurred_at" timestamp NOT NULL,
+  "published_at" timestamp NULL,
+  PRIMARY KEY ("id")
+);
+-- create index "uni_outbox_seq" to table: "outbox"
+CREATE UNIQUE INDEX "uni_outbox_seq" ON "public"."outbox" ("seq");
+-- create index "idx_outbox_aggregate" to table: "outbox"
+CREATE INDEX "idx_outbox_aggregate" ON "public"."outbox" ("aggregate_type", "aggregate_id", "seq");
+-- create index "idx_outbox_status_next_attempt" to table: "outbox"
+CREATE INDEX "idx_outbox_status_next_attempt" ON "public"."outbox" ("status", "next_attempt_at");
+
+-- +goose Down
+-- reverse: create index "idx_outbox_status_next_attempt" to table: "outbox"
+DROP INDEX "public"."idx_outbox_status_next_attempt";
+-- reverse: create index "idx_outbox_aggregate" to table: "outbox"
+DROP INDEX "public"."idx_outbox_aggregate";
+-- reverse: create index "uni_outbox_seq" to table: "outbox"
+DROP INDEX "public"."uni_outbox_seq";
+-- reverse: create "outbox" table
+DROP TABLE "public"."outbox";
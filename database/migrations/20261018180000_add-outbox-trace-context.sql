@@ -0,0 +1,7 @@
+-- +goose Up
+-- modify "outbox" table
+ALTER TABLE "public"."outbox" ADD COLUMN "trace_context" jsonb NULL;
+
+-- +goose Down
+-- reverse: modify "outbox" table
+ALTER TABLE "public"."outbox" DROP COLUMN "trace_context";
@@ -1,10 +1,102 @@
 package config
 
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
 type AuthConfig struct {
 	AccessTokenSecret  string `mapstructure:"access_token_secret"`
 	RefreshTokenSecret string `mapstructure:"refresh_token_secret"`
+	// SigningKeyFile is a PEM encoded RSA or Ed25519 private key used to sign
+	// access tokens. When empty, access tokens are signed with HS256 and
+	// AccessTokenSecret.
+	SigningKeyFile string `mapstructure:"signing_key_file"`
+	// VerificationKeyFiles are extra PEM encoded keys still accepted for
+	// access tokens, e.g. the previous signing key during a rotation.
+	VerificationKeyFiles []string      `mapstructure:"verification_key_files"`
+	AccessTokenExpiry    time.Duration `mapstructure:"access_token_expiry"`
+	RefreshTokenExpiry   time.Duration `mapstructure:"refresh_token_expiry"`
+	// Issuer and Audience are stamped on every token and required when
+	// validating, so tokens minted by another deployment are rejected.
+	Issuer    string             `mapstructure:"issuer"`
+	Audience  string             `mapstructure:"audience"`
+	ClockSkew time.Duration      `mapstructure:"clock_skew"`
+	Google    OIDCProviderConfig `mapstructure:"google"`
+	// VerifyEmailURL and ResetPasswordURL are the frontend pages emailed links
+	// point to; the token is appended as a query parameter.
+	VerifyEmailURL       string        `mapstructure:"verify_email_url"`
+	ResetPasswordURL     string        `mapstructure:"reset_password_url"`
+	RequireVerifiedEmail bool          `mapstructure:"require_verified_email"`
+	Lockout              LockoutConfig `mapstructure:"lockout"`
+	MFA                  MFAConfig     `mapstructure:"mfa"`
+}
+
+// LockoutConfig throttles failed sign-ins per email and per client IP.
+type LockoutConfig struct {
+	MaxAttempts      int64         `mapstructure:"max_attempts"`
+	MaxAttemptsPerIP int64         `mapstructure:"max_attempts_per_ip"`
+	BaseLockout      time.Duration `mapstructure:"base_lockout"`
+	MaxLockout       time.Duration `mapstructure:"max_lockout"`
+	FailureWindow    time.Duration `mapstructure:"failure_window"`
+}
+
+// MFAConfig configures TOTP two-factor authentication. Users with one of the
+// RequiredRoles must enroll before they can finish signing in.
+type MFAConfig struct {
+	Issuer        string   `mapstructure:"issuer"`
+	RequiredRoles []string `mapstructure:"required_roles"`
+}
+
+// OIDCProviderConfig configures an OpenID Connect sign-in provider. The
+// provider is disabled while ClientID is empty.
+type OIDCProviderConfig struct {
+	IssuerURL    string `mapstructure:"issuer_url"`
+	ClientID     string `mapstructure:"client_id"`
+	ClientSecret string `mapstructure:"client_secret"`
+	RedirectURL  string `mapstructure:"redirect_url"`
+}
+
+// TrashConfig sets how long soft-deleted rows can be restored before the
+// purge job deletes them for good.
+type TrashConfig struct {
+	Retention time.Duration `mapstructure:"retention"`
 }
 
 type Config struct {
-	Auth     AuthConfig
+	Auth  AuthConfig
+	Trash TrashConfig
+}
+
+// ValidateSecrets rejects HMAC secrets that are the published defaults, too
+// short to resist brute force, or shared between access and refresh tokens.
+// The access token secret is not used when a signing key file is configured.
+func (c AuthConfig) ValidateSecrets() error {
+	if err := validateSecret("refresh-token-secret", c.RefreshTokenSecret); err != nil {
+		return err
+	}
+
+	if c.SigningKeyFile != "" {
+		return nil
+	}
+
+	if err := validateSecret("access-token-secret", c.AccessTokenSecret); err != nil {
+		return err
+	}
+	if c.AccessTokenSecret == c.RefreshTokenSecret {
+		return errors.New("access-token-secret and refresh-token-secret must be different")
+	}
+
+	return nil
+}
+
+func validateSecret(name, secret string) error {
+	if secret == defaultAccessTokenSecret || secret == defaultRefreshTokenSecret {
+		return fmt.Errorf("%s is set to its default value, configure a secret for this environment", name)
+	}
+	if len(secret) < minSecretLength {
+		return fmt.Errorf("%s must be at least %d characters long", name, minSecretLength)
+	}
+	return nil
 }
@@ -2,32 +2,202 @@ package config
 
 import (
 	"flag"
+	"strings"
+	"time"
+
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/global_config"
+)
+
+const (
+	defaultAccessTokenSecret  = "your-access-token-secret-change-in-production"
+	defaultRefreshTokenSecret = "your-refresh-token-secret-change-in-production"
+	minSecretLength           = 32
 )
 
 var (
-	accessTokenSecretVal string
-	refreshTokenSecretVal string
+	accessTokenSecretVal       string
+	refreshTokenSecretVal      string
+	jwtSigningKeyFileVal       string
+	jwtVerificationKeyFilesVal string
+	accessTokenExpiryVal       time.Duration
+	refreshTokenExpiryVal      time.Duration
+	jwtIssuerVal               string
+	jwtAudienceVal             string
+	jwtClockSkewVal            time.Duration
+	googleIssuerURLVal         string
+	googleClientIDVal          string
+	googleClientSecretVal      string
+	googleRedirectURLVal       string
+	verifyEmailURLVal          string
+	resetPasswordURLVal        string
+	requireVerifiedEmailVal    bool
+	signinMaxAttemptsVal       int64
+	signinMaxAttemptsPerIPVal  int64
+	signinBaseLockoutVal       time.Duration
+	signinMaxLockoutVal        time.Duration
+	signinFailureWindowVal     time.Duration
+	mfaIssuerVal               string
+	mfaRequiredRolesVal        string
+	trashRetentionVal          time.Duration
 )
 
 var (
-	AccessTokenSecret = &accessTokenSecretVal
-	RefreshTokenSecret = &refreshTokenSecretVal
+	AccessTokenSecret       = &accessTokenSecretVal
+	RefreshTokenSecret      = &refreshTokenSecretVal
+	JWTSigningKeyFile       = &jwtSigningKeyFileVal
+	JWTVerificationKeyFiles = &jwtVerificationKeyFilesVal
+	AccessTokenExpiry       = &accessTokenExpiryVal
+	RefreshTokenExpiry      = &refreshTokenExpiryVal
+	JWTIssuer               = &jwtIssuerVal
+	JWTAudience             = &jwtAudienceVal
+	JWTClockSkew            = &jwtClockSkewVal
+	GoogleIssuerURL         = &googleIssuerURLVal
+	GoogleClientID          = &googleClientIDVal
+	GoogleClientSecret      = &googleClientSecretVal
+	GoogleRedirectURL       = &googleRedirectURLVal
+	VerifyEmailURL          = &verifyEmailURLVal
+	ResetPasswordURL        = &resetPasswordURLVal
+	RequireVerifiedEmail    = &requireVerifiedEmailVal
+	SigninMaxAttempts       = &signinMaxAttemptsVal
+	SigninMaxAttemptsPerIP  = &signinMaxAttemptsPerIPVal
+	SigninBaseLockout       = &signinBaseLockoutVal
+	SigninMaxLockout        = &signinMaxLockoutVal
+	SigninFailureWindow     = &signinFailureWindowVal
+	MFAIssuer               = &mfaIssuerVal
+	MFARequiredRoles        = &mfaRequiredRolesVal
+	TrashRetention          = &trashRetentionVal
 )
 
 func init() {
 	if flag.Lookup("access-token-secret") == nil {
-		flag.StringVar(&accessTokenSecretVal, "access-token-secret", "your-access-token-secret-change-in-production", "Access token secret")
+		flag.StringVar(&accessTokenSecretVal, "access-token-secret", defaultAccessTokenSecret, "Access token secret")
 	}
 	if flag.Lookup("refresh-token-secret") == nil {
-		flag.StringVar(&refreshTokenSecretVal, "refresh-token-secret", "your-refresh-token-secret-change-in-production", "Refresh token secret")
+		flag.StringVar(&refreshTokenSecretVal, "refresh-token-secret", defaultRefreshTokenSecret, "Refresh token secret")
+	}
+	if flag.Lookup("jwt-signing-key-file") == nil {
+		flag.StringVar(&jwtSigningKeyFileVal, "jwt-signing-key-file", "", "PEM private key (RSA or Ed25519) used to sign access tokens, HS256 is used when empty")
+	}
+	if flag.Lookup("jwt-verification-key-files") == nil {
+		flag.StringVar(&jwtVerificationKeyFilesVal, "jwt-verification-key-files", "", "Comma separated PEM keys still accepted when verifying access tokens")
+	}
+	if flag.Lookup("access-token-expiry") == nil {
+		flag.DurationVar(&accessTokenExpiryVal, "access-token-expiry", 15*time.Minute, "Access token lifetime")
+	}
+	if flag.Lookup("refresh-token-expiry") == nil {
+		flag.DurationVar(&refreshTokenExpiryVal, "refresh-token-expiry", 7*24*time.Hour, "Refresh token lifetime")
+	}
+	if flag.Lookup("jwt-issuer") == nil {
+		flag.StringVar(&jwtIssuerVal, "jwt-issuer", "", "Issuer (iss) set on and required from tokens, not checked when empty")
+	}
+	if flag.Lookup("jwt-audience") == nil {
+		flag.StringVar(&jwtAudienceVal, "jwt-audience", "", "Audience (aud) set on and required from tokens, not checked when empty")
+	}
+	if flag.Lookup("jwt-clock-skew") == nil {
+		flag.DurationVar(&jwtClockSkewVal, "jwt-clock-skew", 30*time.Second, "Allowed clock skew when validating token times")
+	}
+	if flag.Lookup("google-issuer-url") == nil {
+		flag.StringVar(&googleIssuerURLVal, "google-issuer-url", "https://accounts.google.com", "Google OIDC issuer URL")
+	}
+	if flag.Lookup("google-client-id") == nil {
+		flag.StringVar(&googleClientIDVal, "google-client-id", "", "Google OAuth client ID, Google sign-in is disabled when empty")
+	}
+	if flag.Lookup("google-client-secret") == nil {
+		flag.StringVar(&googleClientSecretVal, "google-client-secret", "", "Google OAuth client secret")
+	}
+	if flag.Lookup("google-redirect-url") == nil {
+		flag.StringVar(&googleRedirectURLVal, "google-redirect-url", "http://localhost:8080/v1/auth/google/callback", "Google OAuth redirect URL")
+	}
+	if flag.Lookup("verify-email-url") == nil {
+		flag.StringVar(&verifyEmailURLVal, "verify-email-url", "http://localhost:3000/verify-email", "Frontend page email verification links point to")
+	}
+	if flag.Lookup("reset-password-url") == nil {
+		flag.StringVar(&resetPasswordURLVal, "reset-password-url", "http://localhost:3000/reset-password", "Frontend page password reset links point to")
+	}
+	if flag.Lookup("require-verified-email") == nil {
+		flag.BoolVar(&requireVerifiedEmailVal, "require-verified-email", false, "Reject sign-in until the email address is verified")
+	}
+	if flag.Lookup("signin-max-attempts") == nil {
+		flag.Int64Var(&signinMaxAttemptsVal, "signin-max-attempts", 5, "Failed sign-ins per email before it is locked")
+	}
+	if flag.Lookup("signin-max-attempts-per-ip") == nil {
+		flag.Int64Var(&signinMaxAttemptsPerIPVal, "signin-max-attempts-per-ip", 20, "Failed sign-ins per client IP before it is locked")
+	}
+	if flag.Lookup("signin-base-lockout") == nil {
+		flag.DurationVar(&signinBaseLockoutVal, "signin-base-lockout", time.Minute, "First sign-in lockout, doubled on every further failure")
+	}
+	if flag.Lookup("signin-max-lockout") == nil {
+		flag.DurationVar(&signinMaxLockoutVal, "signin-max-lockout", time.Hour, "Longest sign-in lockout")
+	}
+	if flag.Lookup("signin-failure-window") == nil {
+		flag.DurationVar(&signinFailureWindowVal, "signin-failure-window", 24*time.Hour, "How long failed sign-ins are remembered after the last one")
+	}
+	if flag.Lookup("mfa-issuer") == nil {
+		flag.StringVar(&mfaIssuerVal, "mfa-issuer", "golang-clean-arc", "Issuer shown by authenticator apps for TOTP enrollments")
+	}
+	if flag.Lookup("mfa-required-roles") == nil {
+		flag.StringVar(&mfaRequiredRolesVal, "mfa-required-roles", "", "Comma-separated roles that must use two-factor authentication, e.g. admin,editor")
+	}
+	if flag.Lookup("trash-retention") == nil {
+		flag.DurationVar(&trashRetentionVal, "trash-retention", 30*24*time.Hour, "How long soft-deleted records can be restored before they are purged")
 	}
 }
 
-func LoadConfig() *Config {
-	return &Config{
+// LoadConfig reads the parsed flags, which already include their env vars, and
+// refuses to start outside of local with secrets that are not safe to sign with.
+func LoadConfig(globalConfig *global_config.GlobalConfig) (*Config, error) {
+	cfg := &Config{
 		Auth: AuthConfig{
-			AccessTokenSecret:  "your-access-token-secret-change-in-production",
-			RefreshTokenSecret: "your-refresh-token-secret-change-in-production",
+			AccessTokenSecret:    accessTokenSecretVal,
+			RefreshTokenSecret:   refreshTokenSecretVal,
+			SigningKeyFile:       jwtSigningKeyFileVal,
+			VerificationKeyFiles: splitList(jwtVerificationKeyFilesVal),
+			AccessTokenExpiry:    accessTokenExpiryVal,
+			RefreshTokenExpiry:   refreshTokenExpiryVal,
+			Issuer:               jwtIssuerVal,
+			Audience:             jwtAudienceVal,
+			ClockSkew:            jwtClockSkewVal,
+			Google: OIDCProviderConfig{
+				IssuerURL:    googleIssuerURLVal,
+				ClientID:     googleClientIDVal,
+				ClientSecret: googleClientSecretVal,
+				RedirectURL:  googleRedirectURLVal,
+			},
+			VerifyEmailURL:       verifyEmailURLVal,
+			ResetPasswordURL:     resetPasswordURLVal,
+			RequireVerifiedEmail: requireVerifiedEmailVal,
+			Lockout: LockoutConfig{
+				MaxAttempts:      signinMaxAttemptsVal,
+				MaxAttemptsPerIP: signinMaxAttemptsPerIPVal,
+				BaseLockout:      signinBaseLockoutVal,
+				MaxLockout:       signinMaxLockoutVal,
+				FailureWindow:    signinFailureWindowVal,
+			},
+			MFA: MFAConfig{
+				Issuer:        mfaIssuerVal,
+				RequiredRoles: splitList(mfaRequiredRolesVal),
+			},
 		},
+		Trash: TrashConfig{
+			Retention: trashRetentionVal,
+		},
+	}
+
+	if globalConfig.Environment != "local" {
+		if err := cfg.Auth.ValidateSecrets(); err != nil {
+			return nil, err
+		}
+	}
+
+	return cfg, nil
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
 	}
-}
\ No newline at end of file
+	return items
+}
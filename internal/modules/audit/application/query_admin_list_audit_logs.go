@@ -0,0 +1,59 @@
+package application
+
+import (
+	"context"
+	"strings"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/audit/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+)
+
+type AdminListAuditLogsQuery struct {
+	repository domain.IAuditLogRepository
+}
+
+func NewAdminListAuditLogsQuery(repository domain.IAuditLogRepository) *AdminListAuditLogsQuery {
+	return &AdminListAuditLogsQuery{
+		repository: repository,
+	}
+}
+
+// Execute lists audit log entries, newest first.
+func (q *AdminListAuditLogsQuery) Execute(ctx context.Context, dto *domain.DTOAdminListAuditLogs) (*domain.DTOAuditLogListResponse, error) {
+	page, limit := dto.Page, dto.Limit
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	if dto.From != nil && dto.To != nil && dto.To.Before(*dto.From) {
+		return nil, base.NewValidationError("to must not be before from")
+	}
+
+	filter := domain.AuditLogFilter{
+		Entity:   strings.TrimSpace(dto.Entity),
+		EntityID: strings.TrimSpace(dto.EntityID),
+		ActorID:  strings.TrimSpace(dto.ActorID),
+		Action:   strings.TrimSpace(dto.Action),
+		From:     dto.From,
+		To:       dto.To,
+	}
+
+	logs, total, err := q.repository.List(ctx, filter, (page-1)*limit, limit)
+	if err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	items := make([]*domain.DTOAuditLogResponse, len(logs))
+	for i, log := range logs {
+		items[i] = domain.NewDTOAuditLogResponse(log)
+	}
+
+	return &domain.DTOAuditLogListResponse{
+		Items: items,
+		Total: total,
+		Page:  page,
+		Limit: limit,
+	}, nil
+}
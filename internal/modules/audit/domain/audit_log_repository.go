@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// AuditLogFilter narrows List; empty fields match everything. From and To
+// bound the time of the change.
+type AuditLogFilter struct {
+	Entity   string
+	EntityID string
+	ActorID  string
+	Action   string
+	From     *time.Time
+	To       *time.Time
+}
+
+type IAuditLogRepository interface {
+	List(ctx context.Context, filter AuditLogFilter, offset, limit int) ([]*AuditLog, int64, error)
+}
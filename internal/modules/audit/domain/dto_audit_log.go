@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type DTOAdminListAuditLogs struct {
+	Page     int        `form:"page"`
+	Limit    int        `form:"limit"`
+	Entity   string     `form:"entity"`
+	EntityID string     `form:"entity_id"`
+	ActorID  string     `form:"actor_id"`
+	Action   string     `form:"action"`
+	From     *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To       *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+type DTOAuditLogResponse struct {
+	ID        string          `json:"id"`
+	ActorID   *string         `json:"actor_id"`
+	Entity    string          `json:"entity"`
+	EntityID  string          `json:"entity_id"`
+	Action    string          `json:"action"`
+	Changes   json.RawMessage `json:"changes"`
+	RequestID *string         `json:"request_id,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+func NewDTOAuditLogResponse(log *AuditLog) *DTOAuditLogResponse {
+	return &DTOAuditLogResponse{
+		ID:        log.ID,
+		ActorID:   log.ActorID,
+		Entity:    log.Entity,
+		EntityID:  log.EntityID,
+		Action:    log.Action,
+		Changes:   log.Changes,
+		RequestID: log.RequestID,
+		CreatedAt: log.CreatedAt,
+	}
+}
+
+type DTOAuditLogListResponse struct {
+	Items []*DTOAuditLogResponse `json:"items"`
+	Total int64                  `json:"total"`
+	Page  int                    `json:"page"`
+	Limit int                    `json:"limit"`
+}
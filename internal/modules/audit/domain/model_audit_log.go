@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditLog is one recorded change of an entity. Changes maps each changed
+// column to its before and after value.
+type AuditLog struct {
+	ID        string
+	ActorID   *string
+	Entity    string
+	EntityID  string
+	Action    string
+	Changes   json.RawMessage
+	RequestID *string
+	CreatedAt time.Time
+}
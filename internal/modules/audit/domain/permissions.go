@@ -0,0 +1,6 @@
+package domain
+
+// Permissions checked by the audit module, granted to roles by the authz policy.
+const (
+	PermissionRead = "audit:read"
+)
@@ -0,0 +1,24 @@
+package audit
+
+import (
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/audit/application"
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/audit/domain"
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/audit/infrastructure/persistence"
+	audit_http "github.com/dukk308/beetool.dev-go-starter/internal/modules/audit/presentation/http"
+	"go.uber.org/fx"
+)
+
+var Module = fx.Module("audit",
+	fx.Provide(
+		fx.Annotate(
+			persistence.NewAuditLogRepository,
+			fx.As(new(domain.IAuditLogRepository)),
+		),
+	),
+	fx.Provide(
+		application.NewAdminListAuditLogsQuery,
+	),
+	fx.Provide(
+		audit_http.NewHttp,
+	),
+)
@@ -0,0 +1,69 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/audit/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gorm_comp"
+	"gorm.io/gorm"
+)
+
+// AuditLogRepository reads the audit_logs table written by gorm_comp.
+type AuditLogRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditLogRepository(db *gorm.DB) *AuditLogRepository {
+	return &AuditLogRepository{
+		db: db,
+	}
+}
+
+func (r *AuditLogRepository) List(ctx context.Context, filter domain.AuditLogFilter, offset, limit int) ([]*domain.AuditLog, int64, error) {
+	query := r.db.WithContext(ctx).Model(&gorm_comp.AuditLog{})
+	if filter.Entity != "" {
+		query = query.Where("entity = ?", filter.Entity)
+	}
+	if filter.EntityID != "" {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.ActorID != "" {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var sqlLogs []gorm_comp.AuditLog
+	if err := query.Order("created_at DESC").Offset(offset).Limit(limit).Find(&sqlLogs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	logs := make([]*domain.AuditLog, len(sqlLogs))
+	for i, sqlLog := range sqlLogs {
+		logs[i] = &domain.AuditLog{
+			ID:        sqlLog.ID,
+			ActorID:   sqlLog.ActorID,
+			Entity:    sqlLog.Entity,
+			EntityID:  sqlLog.EntityID,
+			Action:    sqlLog.Action,
+			Changes:   json.RawMessage(sqlLog.Changes),
+			RequestID: sqlLog.RequestID,
+			CreatedAt: sqlLog.CreatedAt,
+		}
+	}
+
+	return logs, total, nil
+}
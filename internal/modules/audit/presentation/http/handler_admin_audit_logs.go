@@ -0,0 +1,25 @@
+package http
+
+import (
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/audit/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gin_comp"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Http) HandlerAdminListAuditLogs() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var dto domain.DTOAdminListAuditLogs
+		if err := c.ShouldBindQuery(&dto); err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		response, err := h.adminListAuditLogsQuery.Execute(c.Request.Context(), &dto)
+		if err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		gin_comp.ResponseSuccess(c, response)
+	}
+}
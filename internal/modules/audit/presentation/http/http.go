@@ -0,0 +1,36 @@
+package http
+
+import (
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/audit/application"
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/audit/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/authz"
+	middleware "github.com/dukk308/beetool.dev-go-starter/pkgs/middlewares/gin"
+	"github.com/gin-gonic/gin"
+)
+
+type Http struct {
+	adminListAuditLogsQuery *application.AdminListAuditLogsQuery
+	authorizer              authz.IAuthorizer
+	routeGroups             *middleware.RouteGroups
+}
+
+func NewHttp(
+	adminListAuditLogsQuery *application.AdminListAuditLogsQuery,
+	authorizer authz.IAuthorizer,
+	routeGroups *middleware.RouteGroups,
+) *Http {
+	return &Http{
+		adminListAuditLogsQuery: adminListAuditLogsQuery,
+		authorizer:              authorizer,
+		routeGroups:             routeGroups,
+	}
+}
+
+func (h *Http) RegisterRoutes(router *gin.RouterGroup) {
+	adminGroup := h.routeGroups.Authenticated(router, "/admin/v1/audit-logs")
+	{
+		adminGroup.GET("",
+			middleware.RequirePermission(h.authorizer, domain.PermissionRead),
+			h.HandlerAdminListAuditLogs())
+	}
+}
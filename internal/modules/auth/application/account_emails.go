@@ -0,0 +1,117 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	user_domain "github.com/dukk308/beetool.dev-go-starter/internal/modules/user/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/mailer_comp"
+)
+
+// VerificationEmailSender lets the user module send verification links when
+// an email address changes.
+type VerificationEmailSender struct {
+	tokenService domain.ITokenService
+	mailer       mailer_comp.IMailer
+	links        domain.EmailLinks
+}
+
+func NewVerificationEmailSender(
+	tokenService domain.ITokenService,
+	mailer mailer_comp.IMailer,
+	links domain.EmailLinks,
+) user_domain.IEmailVerificationSender {
+	return &VerificationEmailSender{
+		tokenService: tokenService,
+		mailer:       mailer,
+		links:        links,
+	}
+}
+
+func (s *VerificationEmailSender) SendVerificationEmail(ctx context.Context, viewer *user_domain.Viewer) error {
+	return sendVerificationEmail(ctx, s.tokenService, s.mailer, s.links, viewer)
+}
+
+func sendVerificationEmail(
+	ctx context.Context,
+	tokenService domain.ITokenService,
+	mailer mailer_comp.IMailer,
+	links domain.EmailLinks,
+	viewer *user_domain.Viewer,
+) error {
+	token, err := tokenService.GenerateActionToken(domain.TokenPurposeVerifyEmail, viewer.ID.String(), viewer.Email.Value, "")
+	if err != nil {
+		return err
+	}
+
+	return mailer.Send(ctx, &mailer_comp.Message{
+		To:      viewer.Email.Value,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nConfirm your email address by opening the link below:\n\n%s\n\nThe link expires in %s.\n",
+			viewer.Username, emailLink(links.VerifyEmailURL, token), domain.TokenPurposeVerifyEmail.Expiry(),
+		),
+	})
+}
+
+func sendPasswordResetEmail(
+	ctx context.Context,
+	tokenService domain.ITokenService,
+	mailer mailer_comp.IMailer,
+	links domain.EmailLinks,
+	viewer *user_domain.Viewer,
+) error {
+	token, err := tokenService.GenerateActionToken(
+		domain.TokenPurposeResetPassword, viewer.ID.String(), viewer.Email.Value, domain.PasswordStamp(viewer.Password),
+	)
+	if err != nil {
+		return err
+	}
+
+	return mailer.Send(ctx, &mailer_comp.Message{
+		To:      viewer.Email.Value,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nChoose a new password by opening the link below:\n\n%s\n\nThe link expires in %s. If you did not ask for it, ignore this email.\n",
+			viewer.Username, emailLink(links.ResetPasswordURL, token), domain.TokenPurposeResetPassword.Expiry(),
+		),
+	})
+}
+
+func emailLink(baseURL, token string) string {
+	link, err := url.Parse(baseURL)
+	if err != nil {
+		return baseURL + "?token=" + url.QueryEscape(token)
+	}
+
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+	return link.String()
+}
+
+// consumeActionToken validates an emailed token and redeems it.
+func consumeActionToken(
+	ctx context.Context,
+	tokenService domain.ITokenService,
+	tokenStorage domain.ITokenStorage,
+	purpose domain.TokenPurpose,
+	token string,
+) (*domain.ActionTokenClaims, error) {
+	claims, err := tokenService.ValidateActionToken(purpose, token)
+	if err != nil {
+		return nil, err
+	}
+
+	first, err := tokenStorage.ConsumeActionToken(ctx, claims.ID, claims.TTL())
+	if err != nil {
+		return nil, err
+	}
+	if !first {
+		return nil, domain.ErrInvalidActionToken
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,81 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/logger"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/types"
+)
+
+// APIKeyAuthenticator verifies API keys for the auth middlewares. The role is
+// read from the owner on every request, so role changes apply to keys at once.
+type APIKeyAuthenticator struct {
+	repository     domain.IAPIKeyRepository
+	userRepository domain.IUserRepository
+	log            logger.Logger
+}
+
+func NewAPIKeyAuthenticator(
+	repository domain.IAPIKeyRepository,
+	userRepository domain.IUserRepository,
+	log logger.Logger,
+) *APIKeyAuthenticator {
+	return &APIKeyAuthenticator{
+		repository:     repository,
+		userRepository: userRepository,
+		log:            log,
+	}
+}
+
+func (a *APIKeyAuthenticator) Authenticate(ctx context.Context, rawKey string) (*types.UserAuthenticated, error) {
+	prefix, ok := domain.ParseAPIKeyPrefix(rawKey)
+	if !ok {
+		return nil, domain.ErrInvalidAPIKey
+	}
+
+	key, err := a.repository.GetByPrefix(ctx, prefix)
+	if errors.Is(err, domain.ErrAPIKeyNotFound) {
+		return nil, domain.ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	if !key.Matches(rawKey) {
+		return nil, domain.ErrInvalidAPIKey
+	}
+
+	now := time.Now()
+	if key.IsExpired(now) {
+		return nil, domain.ErrExpiredAPIKey
+	}
+
+	user, err := a.userRepository.GetByID(ctx, key.UserID)
+	if err != nil {
+		return nil, domain.ErrInvalidAPIKey
+	}
+	if user.Disabled {
+		return nil, domain.ErrAccountDisabled
+	}
+
+	if key.NeedsTouch(now) {
+		if err := a.repository.TouchLastUsed(ctx, key.ID.String(), now); err != nil {
+			a.log.Errorw("failed to record api key usage", logger.Fields{
+				"api_key_id": key.ID.String(),
+				"error":      err.Error(),
+			})
+		}
+	}
+
+	return &types.UserAuthenticated{
+		ID:       user.ID,
+		Email:    user.Email,
+		Role:     user.Role,
+		APIKeyID: key.ID.String(),
+		Scopes:   key.Scopes,
+	}, nil
+}
@@ -0,0 +1,65 @@
+package application
+
+import (
+	"context"
+	"strings"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/types"
+)
+
+type Authenticator struct {
+	tokenService domain.ITokenService
+	denylist     domain.IAccessTokenDenylist
+	apiKeys      domain.IAPIKeyAuthenticator
+}
+
+func NewAuthenticator(
+	tokenService domain.ITokenService,
+	denylist domain.IAccessTokenDenylist,
+	apiKeys domain.IAPIKeyAuthenticator,
+) *Authenticator {
+	return &Authenticator{
+		tokenService: tokenService,
+		denylist:     denylist,
+		apiKeys:      apiKeys,
+	}
+}
+
+func (a *Authenticator) Authenticate(ctx context.Context, authorization string) (*types.UserAuthenticated, error) {
+	scheme, credential, ok := strings.Cut(authorization, " ")
+	if !ok || credential == "" {
+		return nil, domain.ErrInvalidToken
+	}
+
+	switch scheme {
+	case "Bearer":
+		return a.authenticateAccessToken(ctx, credential)
+	case domain.APIKeyScheme:
+		return a.apiKeys.Authenticate(ctx, credential)
+	default:
+		return nil, domain.ErrInvalidToken
+	}
+}
+
+func (a *Authenticator) authenticateAccessToken(ctx context.Context, token string) (*types.UserAuthenticated, error) {
+	claims, err := a.tokenService.ValidateToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	denied, err := a.denylist.IsDenied(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+	if denied {
+		return nil, domain.ErrRevokedToken
+	}
+
+	return &types.UserAuthenticated{
+		ID:        claims.UserID,
+		Email:     claims.Email,
+		Role:      claims.Role,
+		SessionID: claims.SessionID,
+	}, nil
+}
@@ -0,0 +1,23 @@
+package application
+
+import (
+	"context"
+
+	user_domain "github.com/dukk308/beetool.dev-go-starter/internal/modules/user/domain"
+)
+
+type AdminRevokeUserTokensCommand struct {
+	revoker user_domain.ISessionRevoker
+}
+
+func NewAdminRevokeUserTokensCommand(revoker user_domain.ISessionRevoker) *AdminRevokeUserTokensCommand {
+	return &AdminRevokeUserTokensCommand{
+		revoker: revoker,
+	}
+}
+
+// Execute signs the user out of every device and denies all access tokens
+// issued to them so far.
+func (c *AdminRevokeUserTokensCommand) Execute(ctx context.Context, userID string) error {
+	return c.revoker.RevokeUserSessions(ctx, userID)
+}
@@ -0,0 +1,38 @@
+package application
+
+import (
+	"context"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+)
+
+type AdminUnlockUserSigninCommand struct {
+	repository domain.IUserRepository
+	throttle   domain.ISigninThrottle
+}
+
+func NewAdminUnlockUserSigninCommand(
+	repository domain.IUserRepository,
+	throttle domain.ISigninThrottle,
+) *AdminUnlockUserSigninCommand {
+	return &AdminUnlockUserSigninCommand{
+		repository: repository,
+		throttle:   throttle,
+	}
+}
+
+// Execute lifts the lockout of the user's email and forgets its failures.
+// Lockouts of client IPs are left to expire.
+func (c *AdminUnlockUserSigninCommand) Execute(ctx context.Context, userID string) error {
+	user, err := c.repository.GetByID(ctx, userID)
+	if err != nil {
+		return base.ToDomainError(err)
+	}
+
+	if err := c.throttle.Reset(ctx, domain.NormalizeEmail(user.Email)); err != nil {
+		return base.ToDomainError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package application
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+)
+
+type PublicEnrollMFAChallengeCommand struct {
+	repository    domain.IUserRepository
+	mfaRepository domain.IMFARepository
+	tokenService  domain.ITokenService
+	mfaOptions    domain.MFAOptions
+}
+
+func NewPublicEnrollMFAChallengeCommand(
+	repository domain.IUserRepository,
+	mfaRepository domain.IMFARepository,
+	tokenService domain.ITokenService,
+	mfaOptions domain.MFAOptions,
+) *PublicEnrollMFAChallengeCommand {
+	return &PublicEnrollMFAChallengeCommand{
+		repository:    repository,
+		mfaRepository: mfaRepository,
+		tokenService:  tokenService,
+		mfaOptions:    mfaOptions,
+	}
+}
+
+// Execute sets up TOTP during sign-in for users whose role requires it but who
+// have not enrolled yet. The challenge is then verified with a first code.
+func (c *PublicEnrollMFAChallengeCommand) Execute(ctx context.Context, dto *domain.DTOMFAChallenge) (*domain.DTOTOTPEnrollmentResponse, error) {
+	claims, err := c.tokenService.ValidateActionToken(domain.TokenPurposeMFAChallenge, dto.MFAToken)
+	if err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	user, err := c.repository.GetByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, base.ToDomainError(domain.ErrInvalidActionToken)
+	}
+
+	if !c.mfaOptions.IsRequiredFor(user.Role) {
+		return nil, domain.ErrInvalidActionToken
+	}
+
+	existing, err := c.mfaRepository.GetByUserID(ctx, user.ID)
+	if err != nil && !errors.Is(err, domain.ErrMFANotFound) {
+		return nil, base.ToDomainError(err)
+	}
+	if existing != nil && existing.IsEnabled() {
+		return nil, domain.ErrMFAAlreadyEnabled
+	}
+
+	mfa, err := domain.NewUserMFA(user.ID)
+	if err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	if err := c.mfaRepository.Save(ctx, mfa); err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	return domain.NewDTOTOTPEnrollmentResponse(c.mfaOptions, user.Email, mfa), nil
+}
@@ -0,0 +1,54 @@
+package application
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	user_domain "github.com/dukk308/beetool.dev-go-starter/internal/modules/user/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/mailer_comp"
+)
+
+type PublicForgotPasswordCommand struct {
+	userRepository user_domain.IViewerRepository
+	tokenService   domain.ITokenService
+	mailer         mailer_comp.IMailer
+	links          domain.EmailLinks
+}
+
+func NewPublicForgotPasswordCommand(
+	userRepository user_domain.IViewerRepository,
+	tokenService domain.ITokenService,
+	mailer mailer_comp.IMailer,
+	links domain.EmailLinks,
+) *PublicForgotPasswordCommand {
+	return &PublicForgotPasswordCommand{
+		userRepository: userRepository,
+		tokenService:   tokenService,
+		mailer:         mailer,
+		links:          links,
+	}
+}
+
+// Execute emails a password reset link. Unknown addresses and accounts that
+// sign in through a provider succeed silently so accounts are not revealed.
+func (c *PublicForgotPasswordCommand) Execute(ctx context.Context, dto *domain.DTOEmail) error {
+	viewer, err := c.userRepository.GetByEmail(ctx, dto.Email)
+	if errors.Is(err, user_domain.ErrUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return base.ToDomainError(err)
+	}
+
+	if viewer.AuthProvider != user_domain.AuthProviderLocal {
+		return nil
+	}
+
+	if err := sendPasswordResetEmail(ctx, c.tokenService, c.mailer, c.links, viewer); err != nil {
+		return base.ToDomainError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	user_domain "github.com/dukk308/beetool.dev-go-starter/internal/modules/user/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+)
+
+type PublicGoogleSigninCallbackCommand struct {
+	provider       domain.IOIDCProvider
+	stateStorage   domain.IOIDCStateStorage
+	userRepository user_domain.IViewerRepository
+	finalizer      *SigninFinalizer
+}
+
+func NewPublicGoogleSigninCallbackCommand(
+	provider domain.IOIDCProvider,
+	stateStorage domain.IOIDCStateStorage,
+	userRepository user_domain.IViewerRepository,
+	finalizer *SigninFinalizer,
+) *PublicGoogleSigninCallbackCommand {
+	return &PublicGoogleSigninCallbackCommand{
+		provider:       provider,
+		stateStorage:   stateStorage,
+		userRepository: userRepository,
+		finalizer:      finalizer,
+	}
+}
+
+func (c *PublicGoogleSigninCallbackCommand) Execute(ctx context.Context, dto *domain.DTOOIDCCallback, device *domain.DeviceInfo) (*domain.DTOSigninResponse, error) {
+	// A state from another browser is rejected before it is consumed, so
+	// that browser can still complete its own attempt.
+	if !domain.MatchesOIDCBinding(dto.State, dto.StateBinding) {
+		return nil, domain.ErrInvalidOIDCState
+	}
+
+	// The state is consumed even when the provider reports an error, so a
+	// failed attempt cannot be replayed.
+	request, err := c.stateStorage.ConsumeAuthRequest(ctx, dto.State)
+	if err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	if dto.Error != "" || dto.Code == "" {
+		return nil, base.NewUnauthorizedError("sign-in was cancelled or denied by the provider")
+	}
+
+	identity, err := c.provider.Exchange(ctx, dto.Code, request)
+	if err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	viewer, err := c.findOrCreateViewer(ctx, identity)
+	if err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	user := &domain.UserInfo{
+		ID:       viewer.ID.String(),
+		Email:    viewer.Email.Value,
+		Role:     viewer.Role.String(),
+		Disabled: viewer.IsDisabled(),
+	}
+
+	return c.finalizer.Finalize(ctx, user, device)
+}
+
+func (c *PublicGoogleSigninCallbackCommand) findOrCreateViewer(ctx context.Context, identity *domain.OIDCIdentity) (*user_domain.Viewer, error) {
+	viewer, err := c.userRepository.GetByProvider(ctx, user_domain.AuthProviderGoogle, identity.Subject)
+	if err == nil {
+		return viewer, nil
+	}
+	if !errors.Is(err, user_domain.ErrUserNotFound) {
+		return nil, err
+	}
+
+	if !identity.EmailVerified {
+		return nil, domain.ErrOIDCEmailNotVerified
+	}
+
+	// Accounts are never linked by email: whoever controls the provider account
+	// would otherwise take over the local account.
+	_, err = c.userRepository.GetByEmail(ctx, identity.Email)
+	if err == nil {
+		return nil, domain.ErrOIDCEmailInUse
+	}
+	if !errors.Is(err, user_domain.ErrUserNotFound) {
+		return nil, err
+	}
+
+	viewer, err = user_domain.CreateViewer(&user_domain.DTOCreateUser{
+		Username:   usernameFromEmail(identity.Email),
+		Email:      identity.Email,
+		Provider:   user_domain.AuthProviderGoogle,
+		ProviderID: identity.Subject,
+	})
+	if err != nil {
+		return nil, err
+	}
+	viewer.MarkEmailVerified()
+
+	if err := c.userRepository.Create(ctx, viewer); err != nil {
+		return nil, err
+	}
+
+	return viewer, nil
+}
+
+func usernameFromEmail(email string) string {
+	username, _, _ := strings.Cut(email, "@")
+	return username
+}
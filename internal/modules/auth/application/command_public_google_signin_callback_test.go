@@ -0,0 +1,288 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/config"
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/infrastructure/oidc"
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/infrastructure/storage"
+	user_domain "github.com/dukk308/beetool.dev-go-starter/internal/modules/user/domain"
+	user_persistence "github.com/dukk308/beetool.dev-go-starter/internal/modules/user/infrastructure/persistence"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const testClientID = "test-client"
+
+// fakeIssuer is an OpenID Connect issuer that signs in one account without
+// asking: authorize hands out the code the browser would be redirected with.
+type fakeIssuer struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	mu            sync.Mutex
+	codes         map[string]url.Values
+	subject       string
+	email         string
+	emailVerified bool
+	// nonce overrides the nonce of issued ID tokens when set.
+	nonce string
+}
+
+func newFakeIssuer(t *testing.T) *fakeIssuer {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuer := &fakeIssuer{
+		key:           key,
+		codes:         map[string]url.Values{},
+		subject:       "google-subject",
+		email:         "jane@example.com",
+		emailVerified: true,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", issuer.discovery)
+	mux.HandleFunc("/jwks", issuer.jwks)
+	mux.HandleFunc("/token", issuer.token)
+	issuer.server = httptest.NewServer(mux)
+	t.Cleanup(issuer.server.Close)
+	return issuer
+}
+
+func (i *fakeIssuer) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                                i.server.URL,
+		"authorization_endpoint":                i.server.URL + "/authorize",
+		"token_endpoint":                        i.server.URL + "/token",
+		"jwks_uri":                              i.server.URL + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+	})
+}
+
+func (i *fakeIssuer) jwks(w http.ResponseWriter, r *http.Request) {
+	encode := base64.RawURLEncoding.EncodeToString
+	writeJSON(w, http.StatusOK, map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "test",
+			"alg": "RS256",
+			"use": "sig",
+			"n":   encode(i.key.N.Bytes()),
+			"e":   encode(big.NewInt(int64(i.key.E)).Bytes()),
+		}},
+	})
+}
+
+// authorize returns the code the issuer redirects back with for authURL.
+func (i *fakeIssuer) authorize(t *testing.T, authURL string) string {
+	t.Helper()
+
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := parsed.Query()
+	if query.Get("client_id") != testClientID || query.Get("code_challenge_method") != "S256" {
+		t.Fatalf("authorization URL %s lacks the client ID or PKCE", authURL)
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	code := uuid.NewString()
+	i.codes[code] = query
+	return code
+}
+
+func (i *fakeIssuer) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	authorization, ok := i.codes[r.PostForm.Get("code")]
+	delete(i.codes, r.PostForm.Get("code"))
+	challenge := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if !ok || base64.RawURLEncoding.EncodeToString(challenge[:]) != authorization.Get("code_challenge") {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	nonce := authorization.Get("nonce")
+	if i.nonce != "" {
+		nonce = i.nonce
+	}
+	now := time.Now()
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":            i.server.URL,
+		"sub":            i.subject,
+		"aud":            testClientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+		"nonce":          nonce,
+		"email":          i.email,
+		"email_verified": i.emailVerified,
+	})
+	idToken.Header["kid"] = "test"
+	signed, err := idToken.SignedString(i.key)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": "access-token",
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     signed,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+type googleSignin struct {
+	issuer   *fakeIssuer
+	start    *PublicStartGoogleSigninCommand
+	callback *PublicGoogleSigninCallbackCommand
+	viewers  *user_persistence.ViewerRepository
+}
+
+func newGoogleSignin(t *testing.T) *googleSignin {
+	t.Helper()
+
+	env := newTestEnv(t)
+	issuer := newFakeIssuer(t)
+	provider := oidc.NewProvider(config.OIDCProviderConfig{
+		IssuerURL:   issuer.server.URL,
+		ClientID:    testClientID,
+		RedirectURL: "http://localhost/v1/auth/google/callback",
+	})
+	stateStorage := storage.NewRedisOIDCStateStorage(env.cache)
+	viewers := env.viewerRepository()
+
+	return &googleSignin{
+		issuer:   issuer,
+		start:    NewPublicStartGoogleSigninCommand(provider, stateStorage),
+		callback: NewPublicGoogleSigninCallbackCommand(provider, stateStorage, viewers, env.finalizer),
+		viewers:  viewers,
+	}
+}
+
+// begin starts an attempt and returns the callback the browser that started
+// it sends after signing in at the issuer.
+func (g *googleSignin) begin(t *testing.T) *domain.DTOOIDCCallback {
+	t.Helper()
+
+	authURL, binding, err := g.start.Execute(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &domain.DTOOIDCCallback{
+		Code:         g.issuer.authorize(t, authURL),
+		State:        parsed.Query().Get("state"),
+		StateBinding: binding,
+	}
+}
+
+func TestGoogleSigninCreatesTheViewerOnce(t *testing.T) {
+	g := newGoogleSignin(t)
+	ctx := context.Background()
+
+	for attempt := 1; attempt <= 2; attempt++ {
+		result, err := g.callback.Execute(ctx, g.begin(t), &domain.DeviceInfo{})
+		if err != nil {
+			t.Fatalf("sign-in %d: %v", attempt, err)
+		}
+		if result.AccessToken == "" || result.RefreshToken == "" {
+			t.Fatalf("sign-in %d returned no token pair", attempt)
+		}
+	}
+
+	viewer, err := g.viewers.GetByProvider(ctx, user_domain.AuthProviderGoogle, "google-subject")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if viewer.Email.Value != "jane@example.com" || !viewer.IsEmailVerified() {
+		t.Fatalf("viewer %s, verified %v", viewer.Email.Value, viewer.IsEmailVerified())
+	}
+}
+
+func TestGoogleSigninRejectsACallbackFromAnotherBrowser(t *testing.T) {
+	g := newGoogleSignin(t)
+	ctx := context.Background()
+
+	victim := g.begin(t)
+	attacker := g.begin(t)
+	// The attacker's callback URL, opened in the victim's browser.
+	forged := *attacker
+	forged.StateBinding = victim.StateBinding
+	_, err := g.callback.Execute(ctx, &forged, &domain.DeviceInfo{})
+	assertDomainError(t, err, domain.ErrInvalidOIDCState)
+
+	missing := *victim
+	missing.StateBinding = ""
+	_, err = g.callback.Execute(ctx, &missing, &domain.DeviceInfo{})
+	assertDomainError(t, err, domain.ErrInvalidOIDCState)
+
+	// A rejected callback does not spend the attempt.
+	if _, err := g.callback.Execute(ctx, victim, &domain.DeviceInfo{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGoogleSigninRedeemsAStateOnce(t *testing.T) {
+	g := newGoogleSignin(t)
+	ctx := context.Background()
+
+	callback := g.begin(t)
+	if _, err := g.callback.Execute(ctx, callback, &domain.DeviceInfo{}); err != nil {
+		t.Fatal(err)
+	}
+	_, err := g.callback.Execute(ctx, callback, &domain.DeviceInfo{})
+	assertDomainError(t, err, domain.ErrInvalidOIDCState)
+}
+
+func TestGoogleSigninRejectsAnIDTokenForAnotherAttempt(t *testing.T) {
+	g := newGoogleSignin(t)
+	g.issuer.nonce = "another-nonce"
+
+	_, err := g.callback.Execute(context.Background(), g.begin(t), &domain.DeviceInfo{})
+	assertDomainError(t, err, domain.ErrInvalidOIDCState)
+}
+
+func TestGoogleSigninRejectsUnverifiedEmails(t *testing.T) {
+	g := newGoogleSignin(t)
+	g.issuer.emailVerified = false
+
+	_, err := g.callback.Execute(context.Background(), g.begin(t), &domain.DeviceInfo{})
+	assertDomainError(t, err, domain.ErrOIDCEmailNotVerified)
+}
@@ -0,0 +1,60 @@
+package application
+
+import (
+	"context"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	user_domain "github.com/dukk308/beetool.dev-go-starter/internal/modules/user/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+)
+
+type PublicResetPasswordCommand struct {
+	userRepository user_domain.IViewerRepository
+	tokenService   domain.ITokenService
+	tokenStorage   domain.ITokenStorage
+	revoker        user_domain.ISessionRevoker
+}
+
+func NewPublicResetPasswordCommand(
+	userRepository user_domain.IViewerRepository,
+	tokenService domain.ITokenService,
+	tokenStorage domain.ITokenStorage,
+	revoker user_domain.ISessionRevoker,
+) *PublicResetPasswordCommand {
+	return &PublicResetPasswordCommand{
+		userRepository: userRepository,
+		tokenService:   tokenService,
+		tokenStorage:   tokenStorage,
+		revoker:        revoker,
+	}
+}
+
+// Execute sets a new password and signs the user out everywhere, since the
+// reset usually means the old password can no longer be trusted. A link is
+// rejected once the password has changed since it was sent, so redeeming one
+// link or changing the password invalidates the others.
+func (c *PublicResetPasswordCommand) Execute(ctx context.Context, dto *domain.DTOResetPassword) error {
+	claims, err := consumeActionToken(ctx, c.tokenService, c.tokenStorage, domain.TokenPurposeResetPassword, dto.Token)
+	if err != nil {
+		return base.ToDomainError(err)
+	}
+
+	viewer, err := c.userRepository.GetByID(ctx, claims.UserID)
+	if err != nil || viewer.Email.Value != claims.Email || claims.PasswordStamp != domain.PasswordStamp(viewer.Password) {
+		return base.ToDomainError(domain.ErrInvalidActionToken)
+	}
+
+	hashedPassword, err := c.tokenService.HashPassword(dto.Password)
+	if err != nil {
+		return base.ToDomainError(err)
+	}
+
+	viewer.Password = hashedPassword
+	// Receiving the link proves the address belongs to the user.
+	viewer.MarkEmailVerified()
+	if err := c.userRepository.Update(ctx, viewer); err != nil {
+		return base.ToDomainError(err)
+	}
+
+	return c.revoker.RevokeUserSessions(ctx, viewer.ID.String())
+}
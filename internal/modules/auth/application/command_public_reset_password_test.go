@@ -0,0 +1,92 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+)
+
+func newResetPasswordCommand(env *testEnv) *PublicResetPasswordCommand {
+	revoker := NewUserSessionRevoker(env.tokenStorage, env.tokenService, env.denylist)
+	return NewPublicResetPasswordCommand(env.viewerRepository(), env.tokenService, env.tokenStorage, revoker)
+}
+
+// resetLink issues a password reset token for the viewer's current password,
+// as the reset email does.
+func resetLink(t *testing.T, env *testEnv, userID string) string {
+	t.Helper()
+
+	viewer, err := env.viewerRepository().GetByID(context.Background(), userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := env.tokenService.GenerateActionToken(
+		domain.TokenPurposeResetPassword, userID, viewer.Email.Value, domain.PasswordStamp(viewer.Password),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func TestResetPasswordSignsTheUserOutEverywhere(t *testing.T) {
+	env := newTestEnv(t)
+	userID := env.createViewer(t, "jane@example.com")
+	tokens := env.signIn(t, userID)
+
+	err := newResetPasswordCommand(env).Execute(context.Background(), &domain.DTOResetPassword{
+		Token:    resetLink(t, env, userID),
+		Password: "a brand new password",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if env.authenticate(t, tokens.AccessToken) {
+		t.Error("access token still accepted after a password reset")
+	}
+	sessions, err := env.tokenStorage.ListSessions(context.Background(), userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 0 {
+		t.Fatalf("sessions left = %d, want none", len(sessions))
+	}
+}
+
+func TestResetPasswordRejectsOtherLinksOnceOneIsRedeemed(t *testing.T) {
+	env := newTestEnv(t)
+	userID := env.createViewer(t, "jane@example.com")
+	first := resetLink(t, env, userID)
+	second := resetLink(t, env, userID)
+	command := newResetPasswordCommand(env)
+
+	if err := command.Execute(context.Background(), &domain.DTOResetPassword{Token: first, Password: "a brand new password"}); err != nil {
+		t.Fatal(err)
+	}
+
+	err := command.Execute(context.Background(), &domain.DTOResetPassword{Token: second, Password: "another new password"})
+	assertDomainError(t, err, domain.ErrInvalidActionToken)
+}
+
+func TestResetPasswordRejectsLinksSentBeforeAPasswordChange(t *testing.T) {
+	env := newTestEnv(t)
+	userID := env.createViewer(t, "jane@example.com")
+	link := resetLink(t, env, userID)
+
+	repository := env.viewerRepository()
+	viewer, err := repository.GetByID(context.Background(), userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if viewer.Password, err = env.tokenService.HashPassword(testPassword); err != nil {
+		t.Fatal(err)
+	}
+	if err := repository.Update(context.Background(), viewer); err != nil {
+		t.Fatal(err)
+	}
+
+	err = newResetPasswordCommand(env).Execute(context.Background(), &domain.DTOResetPassword{Token: link, Password: "a brand new password"})
+	assertDomainError(t, err, domain.ErrInvalidActionToken)
+}
@@ -0,0 +1,54 @@
+package application
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	user_domain "github.com/dukk308/beetool.dev-go-starter/internal/modules/user/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/mailer_comp"
+)
+
+type PublicSendVerificationEmailCommand struct {
+	userRepository user_domain.IViewerRepository
+	tokenService   domain.ITokenService
+	mailer         mailer_comp.IMailer
+	links          domain.EmailLinks
+}
+
+func NewPublicSendVerificationEmailCommand(
+	userRepository user_domain.IViewerRepository,
+	tokenService domain.ITokenService,
+	mailer mailer_comp.IMailer,
+	links domain.EmailLinks,
+) *PublicSendVerificationEmailCommand {
+	return &PublicSendVerificationEmailCommand{
+		userRepository: userRepository,
+		tokenService:   tokenService,
+		mailer:         mailer,
+		links:          links,
+	}
+}
+
+// Execute sends a new verification link. Unknown and already verified
+// addresses succeed silently so the endpoint does not reveal accounts.
+func (c *PublicSendVerificationEmailCommand) Execute(ctx context.Context, dto *domain.DTOEmail) error {
+	viewer, err := c.userRepository.GetByEmail(ctx, dto.Email)
+	if errors.Is(err, user_domain.ErrUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return base.ToDomainError(err)
+	}
+
+	if viewer.IsEmailVerified() {
+		return nil
+	}
+
+	if err := sendVerificationEmail(ctx, c.tokenService, c.mailer, c.links, viewer); err != nil {
+		return base.ToDomainError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package application
+
+import (
+	"context"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+)
+
+type PublicStartGoogleSigninCommand struct {
+	provider     domain.IOIDCProvider
+	stateStorage domain.IOIDCStateStorage
+}
+
+func NewPublicStartGoogleSigninCommand(
+	provider domain.IOIDCProvider,
+	stateStorage domain.IOIDCStateStorage,
+) *PublicStartGoogleSigninCommand {
+	return &PublicStartGoogleSigninCommand{
+		provider:     provider,
+		stateStorage: stateStorage,
+	}
+}
+
+// Execute starts a sign-in attempt and returns the provider URL to redirect
+// the browser to, and the binding to set as its OIDCStateCookie.
+func (c *PublicStartGoogleSigninCommand) Execute(ctx context.Context) (string, string, error) {
+	request, err := domain.NewOIDCAuthRequest()
+	if err != nil {
+		return "", "", base.ToDomainError(err)
+	}
+
+	authURL, err := c.provider.AuthCodeURL(ctx, request)
+	if err != nil {
+		return "", "", base.ToDomainError(err)
+	}
+
+	if err := c.stateStorage.StoreAuthRequest(ctx, request); err != nil {
+		return "", "", base.ToDomainError(err)
+	}
+
+	return authURL, request.Binding(), nil
+}
@@ -0,0 +1,51 @@
+package application
+
+import (
+	"context"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	user_domain "github.com/dukk308/beetool.dev-go-starter/internal/modules/user/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+)
+
+type PublicVerifyEmailCommand struct {
+	userRepository user_domain.IViewerRepository
+	tokenService   domain.ITokenService
+	tokenStorage   domain.ITokenStorage
+}
+
+func NewPublicVerifyEmailCommand(
+	userRepository user_domain.IViewerRepository,
+	tokenService domain.ITokenService,
+	tokenStorage domain.ITokenStorage,
+) *PublicVerifyEmailCommand {
+	return &PublicVerifyEmailCommand{
+		userRepository: userRepository,
+		tokenService:   tokenService,
+		tokenStorage:   tokenStorage,
+	}
+}
+
+func (c *PublicVerifyEmailCommand) Execute(ctx context.Context, dto *domain.DTOVerifyEmail) error {
+	claims, err := consumeActionToken(ctx, c.tokenService, c.tokenStorage, domain.TokenPurposeVerifyEmail, dto.Token)
+	if err != nil {
+		return base.ToDomainError(err)
+	}
+
+	viewer, err := c.userRepository.GetByID(ctx, claims.UserID)
+	if err != nil {
+		return base.ToDomainError(domain.ErrInvalidActionToken)
+	}
+
+	// The link only verifies the address it was sent to.
+	if viewer.Email.Value != claims.Email {
+		return base.ToDomainError(domain.ErrInvalidActionToken)
+	}
+
+	viewer.MarkEmailVerified()
+	if err := c.userRepository.Update(ctx, viewer); err != nil {
+		return base.ToDomainError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/logger"
+)
+
+type PublicVerifyMFAChallengeCommand struct {
+	repository    domain.IUserRepository
+	mfaRepository domain.IMFARepository
+	tokenService  domain.ITokenService
+	tokenStorage  domain.ITokenStorage
+	throttle      domain.ISigninThrottle
+	mfaOptions    domain.MFAOptions
+	log           logger.Logger
+}
+
+func NewPublicVerifyMFAChallengeCommand(
+	repository domain.IUserRepository,
+	mfaRepository domain.IMFARepository,
+	tokenService domain.ITokenService,
+	tokenStorage domain.ITokenStorage,
+	throttle domain.ISigninThrottle,
+	mfaOptions domain.MFAOptions,
+	log logger.Logger,
+) *PublicVerifyMFAChallengeCommand {
+	return &PublicVerifyMFAChallengeCommand{
+		repository:    repository,
+		mfaRepository: mfaRepository,
+		tokenService:  tokenService,
+		tokenStorage:  tokenStorage,
+		throttle:      throttle,
+		mfaOptions:    mfaOptions,
+		log:           log,
+	}
+}
+
+// Execute completes a sign-in with a TOTP or recovery code. For a forced
+// enrollment, the first valid code also activates the factor and the response
+// carries the new recovery codes.
+func (c *PublicVerifyMFAChallengeCommand) Execute(ctx context.Context, dto *domain.DTOMFAChallenge, device *domain.DeviceInfo) (*domain.DTOSigninResponse, error) {
+	claims, err := c.tokenService.ValidateActionToken(domain.TokenPurposeMFAChallenge, dto.MFAToken)
+	if err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	email := domain.NormalizeEmail(claims.Email)
+	ip := ""
+	if device != nil {
+		ip = device.IPAddress
+	}
+
+	lockedFor, err := c.throttle.LockedFor(ctx, email, ip)
+	if err != nil {
+		return nil, base.ToDomainError(err)
+	}
+	if lockedFor > 0 {
+		return nil, domain.NewErrSigninLocked(lockedFor)
+	}
+
+	user, err := c.repository.GetByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, base.ToDomainError(domain.ErrInvalidActionToken)
+	}
+
+	mfa, err := c.mfaRepository.GetByUserID(ctx, user.ID)
+	if errors.Is(err, domain.ErrMFANotFound) {
+		return nil, domain.ErrMFAEnrollmentNeeded
+	}
+	if err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	var recoveryCodes []string
+	now := time.Now()
+	switch {
+	case mfa.IsEnabled():
+		if !mfa.Verify(dto.Code, now) {
+			return nil, c.failChallenge(ctx, claims, email, ip)
+		}
+	case c.mfaOptions.IsRequiredFor(user.Role):
+		if !mfa.VerifyTOTP(dto.Code, now) {
+			return nil, c.failChallenge(ctx, claims, email, ip)
+		}
+		if recoveryCodes, err = mfa.Activate(now); err != nil {
+			return nil, base.ToDomainError(err)
+		}
+	default:
+		return nil, domain.ErrMFANotEnabled
+	}
+
+	first, err := c.tokenStorage.ConsumeActionToken(ctx, claims.ID, claims.TTL())
+	if err != nil {
+		return nil, base.ToDomainError(err)
+	}
+	if !first {
+		return nil, domain.ErrInvalidActionToken
+	}
+
+	if err := c.mfaRepository.Save(ctx, mfa); err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	// Wrong codes count against the email, like wrong passwords.
+	if err := c.throttle.Reset(ctx, email); err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	tokens, err := issueTokens(ctx, c.tokenService, c.tokenStorage, user, device)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.DTOSigninResponse{
+		DTOTokenResponse: tokens,
+		RecoveryCodes:    recoveryCodes,
+	}, nil
+}
+
+// failChallenge counts wrong codes against the same lockout as passwords, and
+// spends the MFA token once it took MaxMFAChallengeFailures wrong codes. A
+// spent token rejects even the right code, since consuming it fails.
+func (c *PublicVerifyMFAChallengeCommand) failChallenge(ctx context.Context, claims *domain.ActionTokenClaims, email, ip string) error {
+	lockout, err := c.throttle.RecordFailure(ctx, email, ip)
+	if err != nil {
+		return base.ToDomainError(err)
+	}
+
+	failures, err := c.tokenStorage.RecordActionTokenFailure(ctx, claims.ID, claims.TTL())
+	if err != nil {
+		return base.ToDomainError(err)
+	}
+	if failures >= domain.MaxMFAChallengeFailures {
+		if _, err := c.tokenStorage.ConsumeActionToken(ctx, claims.ID, claims.TTL()); err != nil {
+			return base.ToDomainError(err)
+		}
+		if failures == domain.MaxMFAChallengeFailures {
+			c.log.Infow("[SECURITY] MFA token spent after repeated wrong codes", logger.Fields{
+				"user_id":    claims.UserID,
+				"ip_address": ip,
+			})
+		}
+	}
+
+	if lockout > 0 {
+		return domain.NewErrSigninLocked(lockout)
+	}
+	if failures >= domain.MaxMFAChallengeFailures {
+		return domain.ErrInvalidActionToken
+	}
+	return domain.ErrInvalidMFACode
+}
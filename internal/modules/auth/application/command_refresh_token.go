@@ -2,56 +2,85 @@ package application
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
 	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/logger"
 )
 
 type RefreshTokenCommand struct {
 	repository   domain.IUserRepository
 	tokenService domain.ITokenService
 	tokenStorage domain.ITokenStorage
+	denylist     domain.IAccessTokenDenylist
+	log          logger.Logger
 }
 
 func NewRefreshTokenCommand(
 	repository domain.IUserRepository,
 	tokenService domain.ITokenService,
 	tokenStorage domain.ITokenStorage,
+	denylist domain.IAccessTokenDenylist,
+	log logger.Logger,
 ) *RefreshTokenCommand {
 	return &RefreshTokenCommand{
 		repository:   repository,
 		tokenService: tokenService,
 		tokenStorage: tokenStorage,
+		denylist:     denylist,
+		log:          log,
 	}
 }
 
-func (c *RefreshTokenCommand) Execute(ctx context.Context, dto *domain.DTORefreshToken) (*domain.DTOTokenResponse, error) {
+func (c *RefreshTokenCommand) Execute(ctx context.Context, dto *domain.DTORefreshToken, device *domain.DeviceInfo) (*domain.DTOTokenResponse, error) {
 	claims, err := c.tokenService.ValidateRefreshToken(dto.RefreshToken)
 	if err != nil {
 		return nil, base.ToDomainError(err)
 	}
 
-	isValid, err := c.tokenStorage.IsRefreshTokenValid(ctx, claims.UserID, dto.RefreshToken)
-	if err != nil || !isValid {
+	session, err := c.tokenStorage.GetSession(ctx, claims.UserID, claims.SessionID)
+	if errors.Is(err, domain.ErrSessionNotFound) {
 		return nil, base.ToDomainError(domain.ErrInvalidToken)
 	}
+	if err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	if !session.IsCurrentToken(claims.ID) {
+		return nil, c.revokeFamily(ctx, claims, device)
+	}
+
+	firstUse, err := c.tokenStorage.ConsumeRefreshToken(ctx, claims.ID, time.Until(claims.ExpiresAt.Time))
+	if err != nil {
+		return nil, base.ToDomainError(err)
+	}
+	if !firstUse {
+		return nil, c.revokeFamily(ctx, claims, device)
+	}
 
 	user, err := c.repository.GetByID(ctx, claims.UserID)
 	if err != nil {
 		return nil, base.ToDomainError(domain.ErrInvalidToken)
 	}
+	if user.Disabled {
+		return nil, domain.ErrAccountDisabled
+	}
 
-	accessToken, err := c.tokenService.GenerateAccessToken(user.ID, user.Email, user.Role)
+	session.Rotate(device, c.tokenService.RefreshTokenExpiry())
+
+	accessToken, err := c.tokenService.GenerateAccessToken(user.ID, user.Email, user.Role, session.ID)
 	if err != nil {
 		return nil, base.ToDomainError(err)
 	}
 
-	refreshToken, err := c.tokenService.GenerateRefreshToken(user.ID, user.Email, user.Role)
+	refreshToken, err := c.tokenService.GenerateRefreshToken(user.ID, user.Email, user.Role, session.ID, session.TokenID)
 	if err != nil {
 		return nil, base.ToDomainError(err)
 	}
 
-	if err := c.tokenStorage.StoreRefreshToken(ctx, user.ID, refreshToken); err != nil {
+	if err := c.tokenStorage.StoreSession(ctx, session); err != nil {
 		return nil, base.ToDomainError(err)
 	}
 
@@ -60,3 +89,31 @@ func (c *RefreshTokenCommand) Execute(ctx context.Context, dto *domain.DTORefres
 		RefreshToken: refreshToken,
 	}, nil
 }
+
+// revokeFamily handles a refresh token that was already rotated being presented
+// again. Either the legitimate client or an attacker holds a stolen copy, so the
+// whole family is revoked, with the access tokens already issued to it, and
+// both have to sign in again.
+func (c *RefreshTokenCommand) revokeFamily(ctx context.Context, claims *domain.TokenClaims, device *domain.DeviceInfo) error {
+	fields := logger.Fields{
+		"event":     "refresh_token_reuse",
+		"user_id":   claims.UserID,
+		"family_id": claims.SessionID,
+		"token_id":  claims.ID,
+	}
+	if device != nil {
+		fields["ip_address"] = device.IPAddress
+		fields["user_agent"] = device.UserAgent
+	}
+	c.log.Errorw("[SECURITY] refresh token reuse detected, revoking token family", fields)
+
+	if err := c.tokenStorage.DeleteSession(ctx, claims.UserID, claims.SessionID); err != nil {
+		return base.ToDomainError(err)
+	}
+
+	if err := c.denylist.DenySession(ctx, claims.SessionID, c.tokenService.AccessTokenExpiry()); err != nil {
+		return base.ToDomainError(err)
+	}
+
+	return base.ToDomainError(domain.ErrRefreshTokenReused)
+}
@@ -0,0 +1,96 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/global_config"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/logger"
+	log_cfg "github.com/dukk308/beetool.dev-go-starter/pkgs/logger/config"
+)
+
+func newRefreshTokenCommand(env *testEnv) *RefreshTokenCommand {
+	log := logger.NewZapLogger(&log_cfg.LogOptions{}, &global_config.GlobalConfig{LogLevel: "fatal"})
+	return NewRefreshTokenCommand(env.userRepository(), env.tokenService, env.tokenStorage, env.denylist, log)
+}
+
+func refresh(command *RefreshTokenCommand, refreshToken string) (*domain.DTOTokenResponse, error) {
+	return command.Execute(context.Background(), &domain.DTORefreshToken{RefreshToken: refreshToken}, &domain.DeviceInfo{UserAgent: "test"})
+}
+
+func TestRefreshTokenRotatesTheSessionToken(t *testing.T) {
+	env := newTestEnv(t)
+	command := newRefreshTokenCommand(env)
+	tokens := env.signIn(t, env.createViewer(t, "jane@example.com"))
+
+	rotated, err := refresh(command, tokens.RefreshToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rotated.RefreshToken == tokens.RefreshToken {
+		t.Fatal("refresh token was not rotated")
+	}
+	if env.sessionID(t, rotated.AccessToken) != env.sessionID(t, tokens.AccessToken) {
+		t.Fatal("rotation moved the tokens to another session")
+	}
+	if _, err := refresh(command, rotated.RefreshToken); err != nil {
+		t.Fatalf("refresh with the rotated token: %v", err)
+	}
+}
+
+func TestRefreshTokenReuseRevokesTheFamily(t *testing.T) {
+	env := newTestEnv(t)
+	command := newRefreshTokenCommand(env)
+	userID := env.createViewer(t, "jane@example.com")
+	stolen := env.signIn(t, userID)
+	other := env.signIn(t, userID)
+
+	rotated, err := refresh(command, stolen.RefreshToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The rotated-out token is presented again, by the thief or the client.
+	_, err = refresh(command, stolen.RefreshToken)
+	assertDomainError(t, err, domain.ErrRefreshTokenReused)
+
+	// Neither holder can go on refreshing the family or use the access
+	// tokens issued to it.
+	_, err = refresh(command, rotated.RefreshToken)
+	assertDomainError(t, err, base.ToDomainError(domain.ErrInvalidToken))
+	if env.authenticate(t, stolen.AccessToken) || env.authenticate(t, rotated.AccessToken) {
+		t.Fatal("access token of the revoked family still accepted")
+	}
+
+	// Other sessions of the user are left alone.
+	if !env.authenticate(t, other.AccessToken) {
+		t.Fatal("access token of another session was revoked")
+	}
+	if _, err := refresh(command, other.RefreshToken); err != nil {
+		t.Fatalf("refresh of another session: %v", err)
+	}
+}
+
+func TestRefreshTokenReuseOfTheCurrentTokenRevokesTheFamily(t *testing.T) {
+	env := newTestEnv(t)
+	command := newRefreshTokenCommand(env)
+	tokens := env.signIn(t, env.createViewer(t, "jane@example.com"))
+
+	// The current token was consumed by a refresh that did not finish
+	// rotating, e.g. two clients racing with the same copy.
+	claims, err := env.tokenService.ValidateRefreshToken(tokens.RefreshToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := env.tokenStorage.ConsumeRefreshToken(context.Background(), claims.ID, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = refresh(command, tokens.RefreshToken)
+	assertDomainError(t, err, domain.ErrRefreshTokenReused)
+	if _, err := env.tokenStorage.GetSession(context.Background(), claims.UserID, claims.SessionID); err == nil {
+		t.Fatal("session still stored after reuse")
+	}
+}
@@ -5,52 +5,96 @@ import (
 
 	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
 	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/logger"
 )
 
 type SigninCommand struct {
-	repository   domain.IUserRepository
-	tokenService domain.ITokenService
-	tokenStorage domain.ITokenStorage
+	repository           domain.IUserRepository
+	tokenService         domain.ITokenService
+	finalizer            *SigninFinalizer
+	throttle             domain.ISigninThrottle
+	log                  logger.Logger
+	requireVerifiedEmail bool
 }
 
 func NewSigninCommand(
 	repository domain.IUserRepository,
 	tokenService domain.ITokenService,
-	tokenStorage domain.ITokenStorage,
+	finalizer *SigninFinalizer,
+	throttle domain.ISigninThrottle,
+	log logger.Logger,
+	requireVerifiedEmail bool,
 ) *SigninCommand {
 	return &SigninCommand{
-		repository:   repository,
-		tokenService: tokenService,
-		tokenStorage: tokenStorage,
+		repository:           repository,
+		tokenService:         tokenService,
+		finalizer:            finalizer,
+		throttle:             throttle,
+		log:                  log,
+		requireVerifiedEmail: requireVerifiedEmail,
 	}
 }
 
-func (c *SigninCommand) Execute(ctx context.Context, dto *domain.DTOSignin) (*domain.DTOTokenResponse, error) {
+func (c *SigninCommand) Execute(ctx context.Context, dto *domain.DTOSignin, device *domain.DeviceInfo) (*domain.DTOSigninResponse, error) {
+	email := domain.NormalizeEmail(dto.Email)
+	ip := ""
+	if device != nil {
+		ip = device.IPAddress
+	}
+
+	lockedFor, err := c.throttle.LockedFor(ctx, email, ip)
+	if err != nil {
+		return nil, base.ToDomainError(err)
+	}
+	if lockedFor > 0 {
+		return nil, domain.NewErrSigninLocked(lockedFor)
+	}
+
 	user, err := c.repository.GetByEmail(ctx, dto.Email)
 	if err != nil {
-		return nil, base.ToDomainError(domain.ErrInvalidCredentials)
+		return nil, c.failSignin(ctx, email, ip)
 	}
 
 	if err := c.tokenService.ComparePassword(user.Password, dto.Password); err != nil {
-		return nil, base.ToDomainError(domain.ErrInvalidCredentials)
+		return nil, c.failSignin(ctx, email, ip)
+	}
+
+	if c.requireVerifiedEmail && !user.EmailVerified {
+		return nil, domain.ErrEmailNotVerified
 	}
 
-	accessToken, err := c.tokenService.GenerateAccessToken(user.ID, user.Email, user.Role)
+	response, err := c.finalizer.Finalize(ctx, user, device)
 	if err != nil {
-		return nil, base.ToDomainError(err)
+		return nil, err
+	}
+
+	// A pending MFA challenge keeps the failures, so signing in again does not
+	// clear the wrong codes counted against the email.
+	if !response.MFARequired {
+		if err := c.throttle.Reset(ctx, email); err != nil {
+			return nil, base.ToDomainError(err)
+		}
 	}
 
-	refreshToken, err := c.tokenService.GenerateRefreshToken(user.ID, user.Email, user.Role)
+	return response, nil
+}
+
+// failSignin counts the failed attempt and reports the lockout it triggered,
+// if any. Unknown emails are counted too, so probing them is throttled.
+func (c *SigninCommand) failSignin(ctx context.Context, email, ip string) error {
+	lockout, err := c.throttle.RecordFailure(ctx, email, ip)
 	if err != nil {
-		return nil, base.ToDomainError(err)
+		return base.ToDomainError(err)
 	}
 
-	if err := c.tokenStorage.StoreRefreshToken(ctx, user.ID, refreshToken); err != nil {
-		return nil, base.ToDomainError(err)
+	if lockout > 0 {
+		c.log.Infow("[SECURITY] sign-in locked after repeated failures", logger.Fields{
+			"email":      email,
+			"ip_address": ip,
+			"lockout":    lockout.String(),
+		})
+		return domain.NewErrSigninLocked(lockout)
 	}
 
-	return &domain.DTOTokenResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-	}, nil
+	return base.ToDomainError(domain.ErrInvalidCredentials)
 }
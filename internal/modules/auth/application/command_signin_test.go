@@ -0,0 +1,116 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	auth_persistence "github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/infrastructure/persistence"
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/infrastructure/storage"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/global_config"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/logger"
+	log_cfg "github.com/dukk308/beetool.dev-go-starter/pkgs/logger/config"
+)
+
+// wrongCode never matches: it is no TOTP code and no recovery code.
+const wrongCode = "wrong!"
+
+type mfaSignin struct {
+	signin   *SigninCommand
+	verify   *PublicVerifyMFAChallengeCommand
+	recovery []string
+}
+
+// newMFASignin stores jane@example.com with TOTP enabled and returns the
+// commands of a sign-in with a second factor.
+func newMFASignin(t *testing.T, policy domain.LockoutPolicy) *mfaSignin {
+	t.Helper()
+
+	env := newTestEnv(t)
+	userID := env.createViewer(t, "jane@example.com")
+	mfaRepository := auth_persistence.NewMFARepository(env.db)
+	mfa, err := domain.NewUserMFA(userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recovery, err := mfa.Activate(time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := mfaRepository.Save(context.Background(), mfa); err != nil {
+		t.Fatal(err)
+	}
+
+	log := logger.NewZapLogger(&log_cfg.LogOptions{}, &global_config.GlobalConfig{LogLevel: "fatal"})
+	throttle := storage.NewRedisSigninThrottle(env.cache, policy)
+	return &mfaSignin{
+		signin:   NewSigninCommand(env.userRepository(), env.tokenService, env.finalizer, throttle, log, false),
+		verify:   NewPublicVerifyMFAChallengeCommand(env.userRepository(), mfaRepository, env.tokenService, env.tokenStorage, throttle, domain.MFAOptions{}, log),
+		recovery: recovery,
+	}
+}
+
+// password signs in with the right password and returns the MFA token.
+func (s *mfaSignin) password(t *testing.T) string {
+	t.Helper()
+
+	response, err := s.signin.Execute(context.Background(), &domain.DTOSignin{Email: "jane@example.com", Password: testPassword}, &domain.DeviceInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !response.MFARequired || response.MFAToken == "" {
+		t.Fatal("sign-in did not ask for a second factor")
+	}
+	return response.MFAToken
+}
+
+func (s *mfaSignin) code(mfaToken, code string) (*domain.DTOSigninResponse, error) {
+	return s.verify.Execute(context.Background(), &domain.DTOMFAChallenge{MFAToken: mfaToken, Code: code}, &domain.DeviceInfo{})
+}
+
+func TestSigninAgainDoesNotResetWrongMFACodes(t *testing.T) {
+	s := newMFASignin(t, domain.LockoutPolicy{
+		MaxAttempts:   3,
+		BaseLockout:   time.Minute,
+		MaxLockout:    time.Minute,
+		FailureWindow: time.Hour,
+	})
+
+	for i := 0; i < 2; i++ {
+		_, err := s.code(s.password(t), wrongCode)
+		assertDomainError(t, err, domain.ErrInvalidMFACode)
+	}
+	// The third wrong code reaches the lockout, although the password was
+	// entered again after each of the first two.
+	_, err := s.code(s.password(t), wrongCode)
+	assertDomainError(t, err, base.ToDomainError(domain.NewErrSigninLocked(time.Minute)))
+
+	_, err = s.signin.Execute(context.Background(), &domain.DTOSignin{Email: "jane@example.com", Password: testPassword}, &domain.DeviceInfo{})
+	assertDomainError(t, err, base.ToDomainError(domain.NewErrSigninLocked(time.Minute)))
+}
+
+func TestMFATokenIsSpentAfterTooManyWrongCodes(t *testing.T) {
+	s := newMFASignin(t, domain.LockoutPolicy{})
+	mfaToken := s.password(t)
+
+	for i := 1; i < domain.MaxMFAChallengeFailures; i++ {
+		_, err := s.code(mfaToken, wrongCode)
+		assertDomainError(t, err, domain.ErrInvalidMFACode)
+	}
+	_, err := s.code(mfaToken, wrongCode)
+	assertDomainError(t, err, domain.ErrInvalidActionToken)
+
+	// Not even the right code gets through with the spent token.
+	_, err = s.code(mfaToken, s.recovery[0])
+	assertDomainError(t, err, domain.ErrInvalidActionToken)
+
+	response, err := s.code(s.password(t), s.recovery[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.DTOTokenResponse == nil || response.AccessToken == "" {
+		t.Fatal("right code with a new MFA token issued no tokens")
+	}
+}
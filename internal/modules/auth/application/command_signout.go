@@ -10,25 +10,34 @@ import (
 type SignoutCommand struct {
 	tokenStorage domain.ITokenStorage
 	tokenService domain.ITokenService
+	denylist     domain.IAccessTokenDenylist
 }
 
 func NewSignoutCommand(
 	tokenStorage domain.ITokenStorage,
 	tokenService domain.ITokenService,
+	denylist domain.IAccessTokenDenylist,
 ) *SignoutCommand {
 	return &SignoutCommand{
 		tokenStorage: tokenStorage,
 		tokenService: tokenService,
+		denylist:     denylist,
 	}
 }
 
+// Execute ends the session of refreshToken and denies the access tokens issued
+// to it, so signing out takes effect right away on every one of them.
 func (c *SignoutCommand) Execute(ctx context.Context, refreshToken string) error {
 	claims, err := c.tokenService.ValidateRefreshToken(refreshToken)
 	if err != nil {
 		return base.ToDomainError(err)
 	}
 
-	if err := c.tokenStorage.DeleteRefreshToken(ctx, claims.UserID); err != nil {
+	if err := c.tokenStorage.DeleteSession(ctx, claims.UserID, claims.SessionID); err != nil {
+		return base.ToDomainError(err)
+	}
+
+	if err := c.denylist.DenySession(ctx, claims.SessionID, c.tokenService.AccessTokenExpiry()); err != nil {
 		return base.ToDomainError(err)
 	}
 
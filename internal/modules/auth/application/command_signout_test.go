@@ -0,0 +1,32 @@
+package application
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSignoutDeniesEveryAccessTokenOfTheSession(t *testing.T) {
+	env := newTestEnv(t)
+	userID := env.createViewer(t, "jane@example.com")
+	first := env.signIn(t, userID)
+	other := env.signIn(t, userID)
+	rotated, err := refresh(newRefreshTokenCommand(env), first.RefreshToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewSignoutCommand(env.tokenStorage, env.tokenService, env.denylist).Execute(context.Background(), rotated.RefreshToken); err != nil {
+		t.Fatal(err)
+	}
+
+	if env.authenticate(t, first.AccessToken) || env.authenticate(t, rotated.AccessToken) {
+		t.Error("access token of the signed out session still accepted")
+	}
+	if !env.authenticate(t, other.AccessToken) {
+		t.Error("access token of another session was revoked")
+	}
+	_, err = refresh(newRefreshTokenCommand(env), rotated.RefreshToken)
+	if err == nil {
+		t.Error("refresh token of the signed out session still accepted")
+	}
+}
@@ -6,20 +6,31 @@ import (
 	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
 	user_domain "github.com/dukk308/beetool.dev-go-starter/internal/modules/user/domain"
 	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/mailer_comp"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/logger"
 )
 
 type SignupCommand struct {
 	repository   user_domain.IViewerRepository
 	tokenService domain.ITokenService
+	mailer       mailer_comp.IMailer
+	links        domain.EmailLinks
+	log          logger.Logger
 }
 
 func NewSignupCommand(
 	repository user_domain.IViewerRepository,
 	tokenService domain.ITokenService,
+	mailer mailer_comp.IMailer,
+	links domain.EmailLinks,
+	log logger.Logger,
 ) *SignupCommand {
 	return &SignupCommand{
 		repository:   repository,
 		tokenService: tokenService,
+		mailer:       mailer,
+		links:        links,
+		log:          log,
 	}
 }
 
@@ -45,5 +56,13 @@ func (c *SignupCommand) Execute(ctx context.Context, dto *domain.DTOSignup) (*ba
 		return nil, base.ToDomainError(err)
 	}
 
+	// The account exists at this point; the user can ask for a new link.
+	if err := sendVerificationEmail(ctx, c.tokenService, c.mailer, c.links, viewer); err != nil {
+		c.log.Errorw("failed to send verification email", logger.Fields{
+			"user_id": viewer.ID.String(),
+			"error":   err.Error(),
+		})
+	}
+
 	return &viewer.BaseModel, nil
 }
@@ -0,0 +1,46 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+)
+
+type ViewerActivateTOTPCommand struct {
+	mfaRepository domain.IMFARepository
+}
+
+func NewViewerActivateTOTPCommand(mfaRepository domain.IMFARepository) *ViewerActivateTOTPCommand {
+	return &ViewerActivateTOTPCommand{
+		mfaRepository: mfaRepository,
+	}
+}
+
+func (c *ViewerActivateTOTPCommand) Execute(ctx context.Context, userID string, dto *domain.DTOMFACode) (*domain.DTORecoveryCodesResponse, error) {
+	mfa, err := c.mfaRepository.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	if mfa.IsEnabled() {
+		return nil, domain.ErrMFAAlreadyEnabled
+	}
+
+	now := time.Now()
+	if !mfa.VerifyTOTP(dto.Code, now) {
+		return nil, domain.ErrInvalidMFACode
+	}
+
+	recoveryCodes, err := mfa.Activate(now)
+	if err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	if err := c.mfaRepository.Save(ctx, mfa); err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	return &domain.DTORecoveryCodesResponse{RecoveryCodes: recoveryCodes}, nil
+}
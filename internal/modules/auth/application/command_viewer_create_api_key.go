@@ -0,0 +1,34 @@
+package application
+
+import (
+	"context"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+)
+
+type ViewerCreateAPIKeyCommand struct {
+	repository domain.IAPIKeyRepository
+}
+
+func NewViewerCreateAPIKeyCommand(repository domain.IAPIKeyRepository) *ViewerCreateAPIKeyCommand {
+	return &ViewerCreateAPIKeyCommand{
+		repository: repository,
+	}
+}
+
+func (c *ViewerCreateAPIKeyCommand) Execute(ctx context.Context, userID string, dto *domain.DTOCreateAPIKey) (*domain.DTOCreatedAPIKeyResponse, error) {
+	key, rawKey, err := domain.NewAPIKey(userID, dto.Name, dto.Scopes, dto.ExpiresAt)
+	if err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	if err := c.repository.Create(ctx, key); err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	return &domain.DTOCreatedAPIKeyResponse{
+		DTOAPIKeyResponse: domain.NewDTOAPIKeyResponse(key),
+		Key:               rawKey,
+	}, nil
+}
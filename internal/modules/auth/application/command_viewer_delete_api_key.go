@@ -0,0 +1,26 @@
+package application
+
+import (
+	"context"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+)
+
+type ViewerDeleteAPIKeyCommand struct {
+	repository domain.IAPIKeyRepository
+}
+
+func NewViewerDeleteAPIKeyCommand(repository domain.IAPIKeyRepository) *ViewerDeleteAPIKeyCommand {
+	return &ViewerDeleteAPIKeyCommand{
+		repository: repository,
+	}
+}
+
+func (c *ViewerDeleteAPIKeyCommand) Execute(ctx context.Context, userID, keyID string) error {
+	if err := c.repository.Delete(ctx, userID, keyID); err != nil {
+		return base.ToDomainError(err)
+	}
+
+	return nil
+}
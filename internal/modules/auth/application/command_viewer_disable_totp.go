@@ -0,0 +1,49 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+)
+
+type ViewerDisableTOTPCommand struct {
+	mfaRepository domain.IMFARepository
+	mfaOptions    domain.MFAOptions
+}
+
+func NewViewerDisableTOTPCommand(
+	mfaRepository domain.IMFARepository,
+	mfaOptions domain.MFAOptions,
+) *ViewerDisableTOTPCommand {
+	return &ViewerDisableTOTPCommand{
+		mfaRepository: mfaRepository,
+		mfaOptions:    mfaOptions,
+	}
+}
+
+func (c *ViewerDisableTOTPCommand) Execute(ctx context.Context, userID, role string, dto *domain.DTOMFACode) error {
+	if c.mfaOptions.IsRequiredFor(role) {
+		return domain.ErrMFARequiredForRole
+	}
+
+	mfa, err := c.mfaRepository.GetByUserID(ctx, userID)
+	if err != nil {
+		return base.ToDomainError(err)
+	}
+
+	if !mfa.IsEnabled() {
+		return domain.ErrMFANotEnabled
+	}
+
+	if !mfa.Verify(dto.Code, time.Now()) {
+		return domain.ErrInvalidMFACode
+	}
+
+	if err := c.mfaRepository.Delete(ctx, userID); err != nil {
+		return base.ToDomainError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package application
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+)
+
+type ViewerEnrollTOTPCommand struct {
+	mfaRepository domain.IMFARepository
+	mfaOptions    domain.MFAOptions
+}
+
+func NewViewerEnrollTOTPCommand(
+	mfaRepository domain.IMFARepository,
+	mfaOptions domain.MFAOptions,
+) *ViewerEnrollTOTPCommand {
+	return &ViewerEnrollTOTPCommand{
+		mfaRepository: mfaRepository,
+		mfaOptions:    mfaOptions,
+	}
+}
+
+// Execute creates a pending TOTP secret, replacing an earlier pending one.
+func (c *ViewerEnrollTOTPCommand) Execute(ctx context.Context, userID, email string) (*domain.DTOTOTPEnrollmentResponse, error) {
+	existing, err := c.mfaRepository.GetByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, domain.ErrMFANotFound) {
+		return nil, base.ToDomainError(err)
+	}
+	if existing != nil && existing.IsEnabled() {
+		return nil, domain.ErrMFAAlreadyEnabled
+	}
+
+	mfa, err := domain.NewUserMFA(userID)
+	if err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	if err := c.mfaRepository.Save(ctx, mfa); err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	return domain.NewDTOTOTPEnrollmentResponse(c.mfaOptions, email, mfa), nil
+}
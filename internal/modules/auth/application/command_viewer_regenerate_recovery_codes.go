@@ -0,0 +1,46 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+)
+
+type ViewerRegenerateRecoveryCodesCommand struct {
+	mfaRepository domain.IMFARepository
+}
+
+func NewViewerRegenerateRecoveryCodesCommand(mfaRepository domain.IMFARepository) *ViewerRegenerateRecoveryCodesCommand {
+	return &ViewerRegenerateRecoveryCodesCommand{
+		mfaRepository: mfaRepository,
+	}
+}
+
+// Execute replaces all recovery codes; the previous ones stop working.
+func (c *ViewerRegenerateRecoveryCodesCommand) Execute(ctx context.Context, userID string, dto *domain.DTOMFACode) (*domain.DTORecoveryCodesResponse, error) {
+	mfa, err := c.mfaRepository.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	if !mfa.IsEnabled() {
+		return nil, domain.ErrMFANotEnabled
+	}
+
+	if !mfa.VerifyTOTP(dto.Code, time.Now()) {
+		return nil, domain.ErrInvalidMFACode
+	}
+
+	recoveryCodes, err := mfa.RegenerateRecoveryCodes()
+	if err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	if err := c.mfaRepository.Save(ctx, mfa); err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	return &domain.DTORecoveryCodesResponse{RecoveryCodes: recoveryCodes}, nil
+}
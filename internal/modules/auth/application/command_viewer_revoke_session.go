@@ -0,0 +1,44 @@
+package application
+
+import (
+	"context"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+)
+
+type ViewerRevokeSessionCommand struct {
+	tokenStorage domain.ITokenStorage
+	tokenService domain.ITokenService
+	denylist     domain.IAccessTokenDenylist
+}
+
+func NewViewerRevokeSessionCommand(
+	tokenStorage domain.ITokenStorage,
+	tokenService domain.ITokenService,
+	denylist domain.IAccessTokenDenylist,
+) *ViewerRevokeSessionCommand {
+	return &ViewerRevokeSessionCommand{
+		tokenStorage: tokenStorage,
+		tokenService: tokenService,
+		denylist:     denylist,
+	}
+}
+
+// Execute signs a device out: its refresh token stops working and its access
+// tokens are denied.
+func (c *ViewerRevokeSessionCommand) Execute(ctx context.Context, userID, sessionID string) error {
+	if _, err := c.tokenStorage.GetSession(ctx, userID, sessionID); err != nil {
+		return base.ToDomainError(err)
+	}
+
+	if err := c.tokenStorage.DeleteSession(ctx, userID, sessionID); err != nil {
+		return base.ToDomainError(err)
+	}
+
+	if err := c.denylist.DenySession(ctx, sessionID, c.tokenService.AccessTokenExpiry()); err != nil {
+		return base.ToDomainError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package application
+
+import (
+	"context"
+	"testing"
+)
+
+func TestViewerRevokeSessionDeniesItsAccessTokens(t *testing.T) {
+	env := newTestEnv(t)
+	revoked := env.signIn(t, "user-1")
+	kept := env.signIn(t, "user-1")
+
+	command := NewViewerRevokeSessionCommand(env.tokenStorage, env.tokenService, env.denylist)
+	if err := command.Execute(context.Background(), "user-1", env.sessionID(t, revoked.AccessToken)); err != nil {
+		t.Fatal(err)
+	}
+
+	if env.authenticate(t, revoked.AccessToken) {
+		t.Error("access token of the revoked session still accepted")
+	}
+	if !env.authenticate(t, kept.AccessToken) {
+		t.Error("access token of another session was revoked")
+	}
+}
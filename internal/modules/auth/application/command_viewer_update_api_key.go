@@ -0,0 +1,38 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+)
+
+type ViewerUpdateAPIKeyCommand struct {
+	repository domain.IAPIKeyRepository
+}
+
+func NewViewerUpdateAPIKeyCommand(repository domain.IAPIKeyRepository) *ViewerUpdateAPIKeyCommand {
+	return &ViewerUpdateAPIKeyCommand{
+		repository: repository,
+	}
+}
+
+// Execute renames a key. Scopes and expiry are fixed at creation; create a new
+// key to change them.
+func (c *ViewerUpdateAPIKeyCommand) Execute(ctx context.Context, userID, keyID string, dto *domain.DTOUpdateAPIKey) (*domain.DTOAPIKeyResponse, error) {
+	key, err := c.repository.GetByID(ctx, userID, keyID)
+	if err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	now := time.Now()
+	key.Name = dto.Name
+	key.UpdatedAt = &now
+
+	if err := c.repository.Update(ctx, key); err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	return domain.NewDTOAPIKeyResponse(key), nil
+}
@@ -0,0 +1,145 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	auth_persistence "github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/infrastructure/persistence"
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/infrastructure/repository"
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/infrastructure/storage"
+	user_domain "github.com/dukk308/beetool.dev-go-starter/internal/modules/user/domain"
+	user_persistence "github.com/dukk308/beetool.dev-go-starter/internal/modules/user/infrastructure/persistence"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+	redis_component "github.com/dukk308/beetool.dev-go-starter/pkgs/components/cache_comp"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/cache_comp/cachetest"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gorm_comp"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gorm_comp/gormtest"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/outbox_comp"
+	"gorm.io/gorm"
+)
+
+// testEnv wires the auth commands like the app does, on SQLite and an
+// in-memory cache.
+type testEnv struct {
+	db           *gorm.DB
+	cache        redis_component.ICacheService
+	tokenService domain.ITokenService
+	tokenStorage domain.ITokenStorage
+	denylist     domain.IAccessTokenDenylist
+	finalizer    *SigninFinalizer
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+
+	db := gormtest.Open(t,
+		&user_persistence.SQLUser{},
+		&auth_persistence.SQLUserMFA{},
+		&gorm_comp.AuditLog{},
+		&outbox_comp.OutboxMessage{},
+	)
+	cache := cachetest.NewMemoryCache()
+	tokenService := domain.NewTokenService(
+		domain.NewKeySet(domain.NewHMACSigningKey("test-access-token-secret")),
+		"test-refresh-token-secret",
+		domain.TokenOptions{AccessTokenExpiry: 15 * time.Minute, RefreshTokenExpiry: 24 * time.Hour},
+	)
+	tokenStorage := storage.NewRedisTokenStorage(cache)
+
+	return &testEnv{
+		db:           db,
+		cache:        cache,
+		tokenService: tokenService,
+		tokenStorage: tokenStorage,
+		denylist:     storage.NewRedisAccessTokenDenylist(cache, 0),
+		finalizer:    NewSigninFinalizer(tokenService, tokenStorage, auth_persistence.NewMFARepository(db), domain.MFAOptions{}),
+	}
+}
+
+func (e *testEnv) viewerRepository() *user_persistence.ViewerRepository {
+	return user_persistence.NewViewerRepository(e.db, gorm_comp.NewTxManager(e.db), outbox_comp.NewOutbox(e.db)).(*user_persistence.ViewerRepository)
+}
+
+// testPassword is the password of the viewers createViewer stores.
+const testPassword = "correct horse battery staple"
+
+// createViewer stores a local viewer with email and returns its ID.
+func (e *testEnv) createViewer(t *testing.T, email string) string {
+	t.Helper()
+
+	hashed, err := e.tokenService.HashPassword(testPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	viewer, err := user_domain.CreateViewer(&user_domain.DTOCreateUser{
+		Username: "jane",
+		Email:    email,
+		Password: hashed,
+		Provider: user_domain.AuthProviderLocal,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := e.viewerRepository().Create(context.Background(), viewer); err != nil {
+		t.Fatal(err)
+	}
+	return viewer.ID.String()
+}
+
+func (e *testEnv) userRepository() domain.IUserRepository {
+	return repository.NewUserRepositoryAdapter(e.viewerRepository())
+}
+
+// signIn issues a token pair for userID on a new session, as a completed
+// sign-in does.
+func (e *testEnv) signIn(t *testing.T, userID string) *domain.DTOTokenResponse {
+	t.Helper()
+
+	user := &domain.UserInfo{ID: userID, Email: userID + "@example.com", Role: "viewer"}
+	tokens, err := issueTokens(context.Background(), e.tokenService, e.tokenStorage, user, &domain.DeviceInfo{UserAgent: "test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tokens
+}
+
+// authenticate reports whether accessToken is accepted, and fails the test on
+// any error but a revoked token.
+func (e *testEnv) authenticate(t *testing.T, accessToken string) bool {
+	t.Helper()
+
+	_, err := NewAuthenticator(e.tokenService, e.denylist, nil).Authenticate(context.Background(), "Bearer "+accessToken)
+	if errors.Is(err, domain.ErrRevokedToken) {
+		return false
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return true
+}
+
+// sessionID returns the session an access token belongs to.
+func (e *testEnv) sessionID(t *testing.T, accessToken string) string {
+	t.Helper()
+
+	claims, err := e.tokenService.ValidateToken(accessToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return claims.SessionID
+}
+
+// assertDomainError fails unless err is want, possibly wrapped.
+func assertDomainError(t *testing.T, err error, want *base.DomainError) {
+	t.Helper()
+
+	if err == nil {
+		t.Fatalf("err = nil, want %q", want.Message)
+	}
+	if got := base.ToDomainError(err); got.Message != want.Message {
+		t.Fatalf("err = %v, want %q", err, want.Message)
+	}
+}
@@ -0,0 +1,43 @@
+package application
+
+import (
+	"context"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+)
+
+// issueTokens opens a new session for user on device and returns its first
+// token pair. Disabled users are refused.
+func issueTokens(
+	ctx context.Context,
+	tokenService domain.ITokenService,
+	tokenStorage domain.ITokenStorage,
+	user *domain.UserInfo,
+	device *domain.DeviceInfo,
+) (*domain.DTOTokenResponse, error) {
+	if user.Disabled {
+		return nil, domain.ErrAccountDisabled
+	}
+
+	session := domain.NewSession(user.ID, device, tokenService.RefreshTokenExpiry())
+
+	accessToken, err := tokenService.GenerateAccessToken(user.ID, user.Email, user.Role, session.ID)
+	if err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	refreshToken, err := tokenService.GenerateRefreshToken(user.ID, user.Email, user.Role, session.ID, session.TokenID)
+	if err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	if err := tokenStorage.StoreSession(ctx, session); err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	return &domain.DTOTokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
@@ -0,0 +1,21 @@
+package application
+
+import (
+	"context"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+)
+
+type PublicGetJWKSQuery struct {
+	keys *domain.KeySet
+}
+
+func NewPublicGetJWKSQuery(keys *domain.KeySet) *PublicGetJWKSQuery {
+	return &PublicGetJWKSQuery{
+		keys: keys,
+	}
+}
+
+func (q *PublicGetJWKSQuery) Execute(ctx context.Context) *domain.DTOJWKSResponse {
+	return domain.NewDTOJWKSResponse(q.keys.PublicKeys())
+}
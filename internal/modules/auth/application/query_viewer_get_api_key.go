@@ -0,0 +1,27 @@
+package application
+
+import (
+	"context"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+)
+
+type ViewerGetAPIKeyQuery struct {
+	repository domain.IAPIKeyRepository
+}
+
+func NewViewerGetAPIKeyQuery(repository domain.IAPIKeyRepository) *ViewerGetAPIKeyQuery {
+	return &ViewerGetAPIKeyQuery{
+		repository: repository,
+	}
+}
+
+func (q *ViewerGetAPIKeyQuery) Execute(ctx context.Context, userID, keyID string) (*domain.DTOAPIKeyResponse, error) {
+	key, err := q.repository.GetByID(ctx, userID, keyID)
+	if err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	return domain.NewDTOAPIKeyResponse(key), nil
+}
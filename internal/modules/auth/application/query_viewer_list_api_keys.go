@@ -0,0 +1,32 @@
+package application
+
+import (
+	"context"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+)
+
+type ViewerListAPIKeysQuery struct {
+	repository domain.IAPIKeyRepository
+}
+
+func NewViewerListAPIKeysQuery(repository domain.IAPIKeyRepository) *ViewerListAPIKeysQuery {
+	return &ViewerListAPIKeysQuery{
+		repository: repository,
+	}
+}
+
+func (q *ViewerListAPIKeysQuery) Execute(ctx context.Context, userID string) ([]*domain.DTOAPIKeyResponse, error) {
+	keys, err := q.repository.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	response := make([]*domain.DTOAPIKeyResponse, 0, len(keys))
+	for _, key := range keys {
+		response = append(response, domain.NewDTOAPIKeyResponse(key))
+	}
+
+	return response, nil
+}
@@ -0,0 +1,31 @@
+package application
+
+import (
+	"context"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+)
+
+type ViewerListSessionsQuery struct {
+	tokenStorage domain.ITokenStorage
+}
+
+func NewViewerListSessionsQuery(tokenStorage domain.ITokenStorage) *ViewerListSessionsQuery {
+	return &ViewerListSessionsQuery{
+		tokenStorage: tokenStorage,
+	}
+}
+
+func (q *ViewerListSessionsQuery) Execute(ctx context.Context, userID, currentSessionID string) ([]*domain.DTOSessionResponse, error) {
+	sessions, err := q.tokenStorage.ListSessions(ctx, userID)
+	if err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	result := make([]*domain.DTOSessionResponse, len(sessions))
+	for i, session := range sessions {
+		result[i] = domain.NewDTOSessionResponse(session, currentSessionID)
+	}
+	return result, nil
+}
@@ -0,0 +1,65 @@
+package application
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+)
+
+// SigninFinalizer completes a sign-in once the first factor succeeded. It
+// issues the token pair, or an MFA challenge when the user has a second factor
+// enabled or their role requires one.
+type SigninFinalizer struct {
+	tokenService  domain.ITokenService
+	tokenStorage  domain.ITokenStorage
+	mfaRepository domain.IMFARepository
+	mfaOptions    domain.MFAOptions
+}
+
+func NewSigninFinalizer(
+	tokenService domain.ITokenService,
+	tokenStorage domain.ITokenStorage,
+	mfaRepository domain.IMFARepository,
+	mfaOptions domain.MFAOptions,
+) *SigninFinalizer {
+	return &SigninFinalizer{
+		tokenService:  tokenService,
+		tokenStorage:  tokenStorage,
+		mfaRepository: mfaRepository,
+		mfaOptions:    mfaOptions,
+	}
+}
+
+func (f *SigninFinalizer) Finalize(ctx context.Context, user *domain.UserInfo, device *domain.DeviceInfo) (*domain.DTOSigninResponse, error) {
+	if user.Disabled {
+		return nil, domain.ErrAccountDisabled
+	}
+
+	mfa, err := f.mfaRepository.GetByUserID(ctx, user.ID)
+	if err != nil && !errors.Is(err, domain.ErrMFANotFound) {
+		return nil, base.ToDomainError(err)
+	}
+
+	enabled := mfa != nil && mfa.IsEnabled()
+	if enabled || f.mfaOptions.IsRequiredFor(user.Role) {
+		mfaToken, err := f.tokenService.GenerateActionToken(domain.TokenPurposeMFAChallenge, user.ID, user.Email, "")
+		if err != nil {
+			return nil, base.ToDomainError(err)
+		}
+
+		return &domain.DTOSigninResponse{
+			MFARequired:           true,
+			MFAEnrollmentRequired: !enabled,
+			MFAToken:              mfaToken,
+		}, nil
+	}
+
+	tokens, err := issueTokens(ctx, f.tokenService, f.tokenStorage, user, device)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.DTOSigninResponse{DTOTokenResponse: tokens}, nil
+}
@@ -0,0 +1,66 @@
+package application
+
+import (
+	"context"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	user_domain "github.com/dukk308/beetool.dev-go-starter/internal/modules/user/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+)
+
+// UserSessionRevoker signs users out for other modules, through
+// user_domain.ISessionRevoker.
+type UserSessionRevoker struct {
+	tokenStorage domain.ITokenStorage
+	tokenService domain.ITokenService
+	denylist     domain.IAccessTokenDenylist
+}
+
+func NewUserSessionRevoker(
+	tokenStorage domain.ITokenStorage,
+	tokenService domain.ITokenService,
+	denylist domain.IAccessTokenDenylist,
+) user_domain.ISessionRevoker {
+	return &UserSessionRevoker{
+		tokenStorage: tokenStorage,
+		tokenService: tokenService,
+		denylist:     denylist,
+	}
+}
+
+// RevokeUserSessions signs the user out of every device and denies all access
+// tokens issued to them so far.
+func (r *UserSessionRevoker) RevokeUserSessions(ctx context.Context, userID string) error {
+	if err := r.tokenStorage.DeleteAllSessions(ctx, userID); err != nil {
+		return base.ToDomainError(err)
+	}
+
+	if err := r.denylist.DenyUser(ctx, userID, r.tokenService.AccessTokenExpiry()); err != nil {
+		return base.ToDomainError(err)
+	}
+
+	return nil
+}
+
+// RevokeOtherSessions deletes every other session, so their refresh tokens stop
+// working, and denies the access tokens issued to them.
+func (r *UserSessionRevoker) RevokeOtherSessions(ctx context.Context, userID, keepSessionID string) error {
+	sessions, err := r.tokenStorage.ListSessions(ctx, userID)
+	if err != nil {
+		return base.ToDomainError(err)
+	}
+
+	for _, session := range sessions {
+		if session.ID == keepSessionID {
+			continue
+		}
+		if err := r.tokenStorage.DeleteSession(ctx, userID, session.ID); err != nil {
+			return base.ToDomainError(err)
+		}
+		if err := r.denylist.DenySession(ctx, session.ID, r.tokenService.AccessTokenExpiry()); err != nil {
+			return base.ToDomainError(err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package application
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRevokeOtherSessionsDeniesTheirAccessTokens(t *testing.T) {
+	env := newTestEnv(t)
+	revoker := NewUserSessionRevoker(env.tokenStorage, env.tokenService, env.denylist)
+	current := env.signIn(t, "user-1")
+	other := env.signIn(t, "user-1")
+	otherUser := env.signIn(t, "user-2")
+
+	if err := revoker.RevokeOtherSessions(context.Background(), "user-1", env.sessionID(t, current.AccessToken)); err != nil {
+		t.Fatal(err)
+	}
+
+	if !env.authenticate(t, current.AccessToken) {
+		t.Error("access token of the kept session was revoked")
+	}
+	if env.authenticate(t, other.AccessToken) {
+		t.Error("access token of another session still accepted")
+	}
+	if !env.authenticate(t, otherUser.AccessToken) {
+		t.Error("access token of another user was revoked")
+	}
+
+	sessions, err := env.tokenStorage.ListSessions(context.Background(), "user-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 1 || sessions[0].ID != env.sessionID(t, current.AccessToken) {
+		t.Fatalf("sessions left = %d, want only the kept one", len(sessions))
+	}
+}
+
+func TestRevokeUserSessionsDeniesEveryAccessToken(t *testing.T) {
+	env := newTestEnv(t)
+	revoker := NewUserSessionRevoker(env.tokenStorage, env.tokenService, env.denylist)
+	first := env.signIn(t, "user-1")
+	second := env.signIn(t, "user-1")
+
+	if err := revoker.RevokeUserSessions(context.Background(), "user-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if env.authenticate(t, first.AccessToken) || env.authenticate(t, second.AccessToken) {
+		t.Error("access token still accepted after revoking every session")
+	}
+}
@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// IAccessTokenDenylist revokes access tokens before they expire. Entries only
+// need to outlive the tokens they deny, so they are kept with a matching TTL.
+type IAccessTokenDenylist interface {
+	// Deny revokes a single access token by its jti.
+	Deny(ctx context.Context, claims *TokenClaims) error
+	// DenyUser revokes every access token issued to the user up to now.
+	DenyUser(ctx context.Context, userID string, ttl time.Duration) error
+	// DenySession revokes every access token issued to a session, identified
+	// by their sid.
+	DenySession(ctx context.Context, sessionID string, ttl time.Duration) error
+	IsDenied(ctx context.Context, claims *TokenClaims) (bool, error)
+}
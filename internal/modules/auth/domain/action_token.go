@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrInvalidActionToken = base.NewUnauthorizedError("link is invalid or has expired")
+	ErrEmailNotVerified   = base.NewForbiddenError("email address is not verified")
+)
+
+// TokenPurpose scopes an action token to the single flow it was issued for.
+type TokenPurpose string
+
+const (
+	TokenPurposeVerifyEmail   TokenPurpose = "verify_email"
+	TokenPurposeResetPassword TokenPurpose = "reset_password"
+	TokenPurposeMFAChallenge  TokenPurpose = "mfa_challenge"
+)
+
+func (p TokenPurpose) Expiry() time.Duration {
+	switch p {
+	case TokenPurposeResetPassword:
+		return 30 * time.Minute
+	case TokenPurposeMFAChallenge:
+		return 5 * time.Minute
+	default:
+		return 24 * time.Hour
+	}
+}
+
+// ActionTokenClaims carries the user an emailed link was issued for. Every
+// token has a jti so it can only be redeemed once. PasswordStamp ties a
+// password reset link to the password it was issued against.
+type ActionTokenClaims struct {
+	UserID        string       `json:"userId"`
+	Email         string       `json:"email"`
+	Purpose       TokenPurpose `json:"purpose"`
+	PasswordStamp string       `json:"passwordStamp,omitempty"`
+	jwt.RegisteredClaims
+}
+
+func (c *ActionTokenClaims) TTL() time.Duration {
+	return time.Until(c.ExpiresAt.Time)
+}
+
+// PasswordStamp fingerprints a password hash without exposing it. Every new
+// hash is salted, so the stamp changes with every password change, and reset
+// links issued before it stop working.
+func PasswordStamp(hashedPassword string) string {
+	sum := sha256.Sum256([]byte(hashedPassword))
+	return hex.EncodeToString(sum[:16])
+}
+
+// EmailLinks are the frontend pages emailed tokens are sent to, as a "token"
+// query parameter.
+type EmailLinks struct {
+	VerifyEmailURL   string
+	ResetPasswordURL string
+}
+
+type DTOEmail struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type DTOVerifyEmail struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type DTOResetPassword struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=8"`
+}
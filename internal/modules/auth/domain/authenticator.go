@@ -0,0 +1,14 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/types"
+)
+
+// IAuthenticator turns an Authorization header value, "Bearer <access token>"
+// or "ApiKey <key>", into the request principal. It is the only way requests
+// are authenticated.
+type IAuthenticator interface {
+	Authenticate(ctx context.Context, authorization string) (*types.UserAuthenticated, error)
+}
@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+type DTOCreateAPIKey struct {
+	Name      string     `json:"name" binding:"required,max=100"`
+	Scopes    []string   `json:"scopes" binding:"omitempty,dive,oneof=read write"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+}
+
+type DTOUpdateAPIKey struct {
+	Name string `json:"name" binding:"required,max=100"`
+}
+
+type DTOAPIKeyResponse struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	CreatedAt  *time.Time `json:"createdAt"`
+}
+
+// DTOCreatedAPIKeyResponse is only returned once; the key cannot be read back.
+type DTOCreatedAPIKeyResponse struct {
+	*DTOAPIKeyResponse
+	Key string `json:"key"`
+}
+
+func NewDTOAPIKeyResponse(key *APIKey) *DTOAPIKeyResponse {
+	scopes := key.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+
+	return &DTOAPIKeyResponse{
+		ID:         key.ID.String(),
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     scopes,
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
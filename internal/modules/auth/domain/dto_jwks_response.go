@@ -0,0 +1,44 @@
+package domain
+
+import "sort"
+
+type DTOJSONWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type DTOJWKSResponse struct {
+	Keys []*DTOJSONWebKey `json:"keys"`
+}
+
+func NewDTOJWKSResponse(keys []*SigningKey) *DTOJWKSResponse {
+	response := &DTOJWKSResponse{
+		Keys: make([]*DTOJSONWebKey, 0, len(keys)),
+	}
+
+	for _, key := range keys {
+		jwk := key.JWK()
+		response.Keys = append(response.Keys, &DTOJSONWebKey{
+			Kty: jwk["kty"],
+			Kid: key.ID,
+			Use: "sig",
+			Alg: key.Method.Alg(),
+			N:   jwk["n"],
+			E:   jwk["e"],
+			Crv: jwk["crv"],
+			X:   jwk["x"],
+		})
+	}
+
+	sort.Slice(response.Keys, func(i, j int) bool {
+		return response.Keys[i].Kid < response.Keys[j].Kid
+	})
+
+	return response
+}
@@ -0,0 +1,52 @@
+package domain
+
+// DTOSigninResponse carries the token pair, or an MFA challenge to complete
+// through /v1/auth/mfa/challenge when a second factor is needed.
+type DTOSigninResponse struct {
+	*DTOTokenResponse
+	MFARequired           bool     `json:"mfaRequired,omitempty"`
+	MFAEnrollmentRequired bool     `json:"mfaEnrollmentRequired,omitempty"`
+	MFAToken              string   `json:"mfaToken,omitempty"`
+	RecoveryCodes         []string `json:"recoveryCodes,omitempty"`
+}
+
+type DTOTOTPEnrollmentResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioningUri"`
+}
+
+type DTORecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+type DTOMFACode struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type DTOMFAChallenge struct {
+	MFAToken string `json:"mfaToken" binding:"required"`
+	Code     string `json:"code"`
+}
+
+// MFAOptions configures TOTP enrollment and the roles that cannot sign in
+// without a second factor.
+type MFAOptions struct {
+	Issuer        string
+	RequiredRoles []string
+}
+
+func (o MFAOptions) IsRequiredFor(role string) bool {
+	for _, required := range o.RequiredRoles {
+		if required == role {
+			return true
+		}
+	}
+	return false
+}
+
+func NewDTOTOTPEnrollmentResponse(options MFAOptions, email string, mfa *UserMFA) *DTOTOTPEnrollmentResponse {
+	return &DTOTOTPEnrollmentResponse{
+		Secret:          mfa.Secret,
+		ProvisioningURI: TOTPProvisioningURI(options.Issuer, email, mfa.Secret),
+	}
+}
@@ -0,0 +1,10 @@
+package domain
+
+type DTOOIDCCallback struct {
+	Code             string `form:"code"`
+	State            string `form:"state" binding:"required"`
+	Error            string `form:"error"`
+	ErrorDescription string `form:"error_description"`
+	// StateBinding is the OIDCStateCookie of the request.
+	StateBinding string `form:"-"`
+}
@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/types"
+)
+
+const (
+	// APIKeyScheme is the Authorization scheme for API keys, next to Bearer.
+	APIKeyScheme = "ApiKey"
+
+	apiKeyMarker = "gca"
+	// apiKeyTouchInterval limits how often LastUsedAt is written back.
+	apiKeyTouchInterval = time.Minute
+)
+
+// Scopes an API key can be limited to. A key without scopes acts with the full
+// rights of its owner.
+const (
+	APIKeyScopeRead  = "read"
+	APIKeyScopeWrite = "write"
+)
+
+var (
+	ErrAPIKeyNotFound   = base.NewNotFoundError("api key not found")
+	ErrInvalidAPIKey    = base.NewUnauthorizedError("invalid api key")
+	ErrExpiredAPIKey    = base.NewUnauthorizedError("api key has expired")
+	ErrAPIKeyNotAllowed = base.NewForbiddenError("this action requires signing in, api keys are not accepted")
+	ErrInvalidAPIKeyTTL = base.NewValidationError("expiresAt must be in the future")
+)
+
+// APIKey is a long-lived credential a user creates for scripts and
+// integrations. Only a hash of the key is stored; Prefix is kept in clear to
+// look the key up and to tell keys apart.
+type APIKey struct {
+	base.BaseModel
+	UserID     string
+	Name       string
+	Prefix     string
+	KeyHash    string
+	Scopes     []string
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+}
+
+// NewAPIKey returns the key together with its clear-text value, which is only
+// available at creation time.
+func NewAPIKey(userID, name string, scopes []string, expiresAt *time.Time) (*APIKey, string, error) {
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, "", ErrInvalidAPIKeyTTL
+	}
+
+	prefix, err := randomHex(6)
+	if err != nil {
+		return nil, "", err
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", err
+	}
+
+	rawKey := apiKeyMarker + "_" + prefix + "_" + base64.RawURLEncoding.EncodeToString(secret)
+	return &APIKey{
+		BaseModel: *base.GenerateBaseModel(),
+		UserID:    userID,
+		Name:      name,
+		Prefix:    prefix,
+		KeyHash:   hashAPIKey(rawKey),
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+	}, rawKey, nil
+}
+
+// ParseAPIKeyPrefix extracts the lookup prefix from a clear-text key.
+func ParseAPIKeyPrefix(rawKey string) (string, bool) {
+	parts := strings.SplitN(rawKey, "_", 3)
+	if len(parts) != 3 || parts[0] != apiKeyMarker || parts[1] == "" || parts[2] == "" {
+		return "", false
+	}
+	return parts[1], true
+}
+
+func (k *APIKey) Matches(rawKey string) bool {
+	return subtle.ConstantTimeCompare([]byte(k.KeyHash), []byte(hashAPIKey(rawKey))) == 1
+}
+
+func (k *APIKey) IsExpired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
+
+// NeedsTouch reports whether LastUsedAt is stale enough to be written again.
+func (k *APIKey) NeedsTouch(now time.Time) bool {
+	return k.LastUsedAt == nil || now.Sub(*k.LastUsedAt) >= apiKeyTouchInterval
+}
+
+// RequiredAPIKeyScope maps an HTTP method to the scope a key needs for it.
+func RequiredAPIKeyScope(method string) string {
+	switch method {
+	case "GET", "HEAD", "OPTIONS":
+		return APIKeyScopeRead
+	default:
+		return APIKeyScopeWrite
+	}
+}
+
+func hashAPIKey(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+type IAPIKeyRepository interface {
+	Create(ctx context.Context, key *APIKey) error
+	ListByUserID(ctx context.Context, userID string) ([]*APIKey, error)
+	// GetByID only returns keys owned by userID.
+	GetByID(ctx context.Context, userID, id string) (*APIKey, error)
+	GetByPrefix(ctx context.Context, prefix string) (*APIKey, error)
+	Update(ctx context.Context, key *APIKey) error
+	TouchLastUsed(ctx context.Context, id string, at time.Time) error
+	Delete(ctx context.Context, userID, id string) error
+}
+
+// IAPIKeyAuthenticator resolves the principal behind an "ApiKey" credential.
+type IAPIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, rawKey string) (*types.UserAuthenticated, error)
+}
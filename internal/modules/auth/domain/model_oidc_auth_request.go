@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+const OIDCAuthRequestExpiry = 10 * time.Minute
+
+// OIDCStateCookie binds a sign-in attempt to the browser that started it. It
+// holds the Binding of the attempt, so a victim's browser cannot be made to
+// complete a callback started by someone else (login CSRF).
+const OIDCStateCookie = "oidc_state"
+
+// OIDCAuthRequest is the pending state of one sign-in attempt, kept between
+// the redirect to the provider and its callback.
+type OIDCAuthRequest struct {
+	State        string    `json:"state"`
+	Nonce        string    `json:"nonce"`
+	CodeVerifier string    `json:"codeVerifier"`
+	ExpiresAt    time.Time `json:"expiresAt"`
+}
+
+func NewOIDCAuthRequest() (*OIDCAuthRequest, error) {
+	state, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+
+	return &OIDCAuthRequest{
+		State:        state,
+		Nonce:        nonce,
+		CodeVerifier: oauth2.GenerateVerifier(),
+		ExpiresAt:    time.Now().Add(OIDCAuthRequestExpiry),
+	}, nil
+}
+
+func (r *OIDCAuthRequest) TTL() time.Duration {
+	return time.Until(r.ExpiresAt)
+}
+
+// Binding returns the value of OIDCStateCookie for the attempt, a hash of its
+// state.
+func (r *OIDCAuthRequest) Binding() string {
+	sum := sha256.Sum256([]byte(r.State))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// MatchesOIDCBinding reports whether the browser that sent binding started the
+// attempt for state.
+func MatchesOIDCBinding(state, binding string) bool {
+	expected := (&OIDCAuthRequest{State: state}).Binding()
+	return binding != "" && subtle.ConstantTimeCompare([]byte(expected), []byte(binding)) == 1
+}
+
+// IOIDCStateStorage keeps pending sign-in attempts until their callback.
+type IOIDCStateStorage interface {
+	StoreAuthRequest(ctx context.Context, request *OIDCAuthRequest) error
+	// ConsumeAuthRequest returns and removes the request for state, so a
+	// callback can only be redeemed once.
+	ConsumeAuthRequest(ctx context.Context, state string) (*OIDCAuthRequest, error)
+}
+
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DeviceInfo struct {
+	UserAgent string
+	IPAddress string
+}
+
+// Session is a refresh token family bound to one device. Its ID is the family
+// ID carried by every refresh token issued for it; TokenID is the jti of the
+// only refresh token of the family that may still be used.
+type Session struct {
+	ID         string    `json:"id"`
+	UserID     string    `json:"userId"`
+	TokenID    string    `json:"tokenId"`
+	UserAgent  string    `json:"userAgent"`
+	IPAddress  string    `json:"ipAddress"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastUsedAt time.Time `json:"lastUsedAt"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+}
+
+func NewSession(userID string, device *DeviceInfo, expiry time.Duration) *Session {
+	now := time.Now()
+	session := &Session{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		CreatedAt: now,
+	}
+	session.Rotate(device, expiry)
+	return session
+}
+
+// Rotate moves the session to a new refresh token ID and extends its lifetime.
+func (s *Session) Rotate(device *DeviceInfo, expiry time.Duration) {
+	now := time.Now()
+	s.TokenID = uuid.NewString()
+	s.LastUsedAt = now
+	s.ExpiresAt = now.Add(expiry)
+	if device != nil {
+		s.UserAgent = device.UserAgent
+		s.IPAddress = device.IPAddress
+	}
+}
+
+// IsCurrentToken reports whether tokenID is the latest refresh token of the family.
+func (s *Session) IsCurrentToken(tokenID string) bool {
+	return tokenID != "" && s.TokenID == tokenID
+}
+
+func (s *Session) TTL() time.Duration {
+	return time.Until(s.ExpiresAt)
+}
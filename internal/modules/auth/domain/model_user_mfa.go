@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+)
+
+const recoveryCodeCount = 10
+
+// MaxMFAChallengeFailures is how many wrong codes one MFA token takes before
+// it stops working and the user has to enter their password again.
+const MaxMFAChallengeFailures = 5
+
+var (
+	ErrMFANotFound         = base.NewNotFoundError("two-factor authentication is not set up")
+	ErrMFAAlreadyEnabled   = base.NewConflictError("two-factor authentication is already enabled")
+	ErrMFANotEnabled       = base.NewBusinessRuleError("two-factor authentication is not enabled")
+	ErrMFARequiredForRole  = base.NewForbiddenError("two-factor authentication is required for your role")
+	ErrInvalidMFACode      = base.NewUnauthorizedError("invalid two-factor authentication code")
+	ErrMFAEnrollmentNeeded = base.NewBusinessRuleError("two-factor authentication must be set up before signing in")
+)
+
+// UserMFA is the TOTP second factor of a user. It is pending until the user
+// proves the authenticator works by activating it with a first code.
+type UserMFA struct {
+	base.BaseModel
+	UserID string
+	Secret string
+	// RecoveryCodes holds SHA-256 hashes of the unused recovery codes.
+	RecoveryCodes []string
+	// LastUsedStep is the TOTP time step of the last accepted code, so a code
+	// cannot be replayed within its validity window.
+	LastUsedStep int64
+	EnabledAt    *time.Time
+}
+
+func NewUserMFA(userID string) (*UserMFA, error) {
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	return &UserMFA{
+		BaseModel: *base.GenerateBaseModel(),
+		UserID:    userID,
+		Secret:    secret,
+	}, nil
+}
+
+func (m *UserMFA) IsEnabled() bool {
+	return m.EnabledAt != nil
+}
+
+// VerifyTOTP accepts a TOTP code once.
+func (m *UserMFA) VerifyTOTP(code string, now time.Time) bool {
+	step, ok := matchTOTP(m.Secret, code, now)
+	if !ok || step <= m.LastUsedStep {
+		return false
+	}
+	m.LastUsedStep = step
+	return true
+}
+
+// Verify accepts either a TOTP code or, once enabled, an unused recovery code.
+func (m *UserMFA) Verify(code string, now time.Time) bool {
+	if m.VerifyTOTP(code, now) {
+		return true
+	}
+	return m.IsEnabled() && m.useRecoveryCode(code)
+}
+
+// Activate enables the factor and returns fresh recovery codes in clear text.
+// They are only stored hashed and cannot be shown again.
+func (m *UserMFA) Activate(now time.Time) ([]string, error) {
+	m.EnabledAt = &now
+	return m.RegenerateRecoveryCodes()
+}
+
+func (m *UserMFA) RegenerateRecoveryCodes() ([]string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes[i] = code
+		hashes[i] = hashRecoveryCode(code)
+	}
+
+	m.RecoveryCodes = hashes
+	return codes, nil
+}
+
+func (m *UserMFA) useRecoveryCode(code string) bool {
+	hash := hashRecoveryCode(code)
+	for i, stored := range m.RecoveryCodes {
+		if subtle.ConstantTimeCompare([]byte(stored), []byte(hash)) == 1 {
+			m.RecoveryCodes = append(m.RecoveryCodes[:i], m.RecoveryCodes[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func generateRecoveryCode() (string, error) {
+	b := make([]byte, 5)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	code := strings.ToLower(hex.EncodeToString(b))
+	return code[:5] + "-" + code[5:], nil
+}
+
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.TrimSpace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+type IMFARepository interface {
+	GetByUserID(ctx context.Context, userID string) (*UserMFA, error)
+	// Save creates or replaces the factor of the user.
+	Save(ctx context.Context, mfa *UserMFA) error
+	Delete(ctx context.Context, userID string) error
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUserMFAVerifyTOTPAcceptsACodeOnce(t *testing.T) {
+	mfa := &UserMFA{Secret: rfc6238Secret}
+	at := time.Unix(1111111111, 0)
+
+	if !mfa.VerifyTOTP("050471", at) {
+		t.Fatal("first use of the code rejected")
+	}
+	if mfa.VerifyTOTP("050471", at.Add(10*time.Second)) {
+		t.Fatal("code replayed within its period")
+	}
+	// A code from the previous step is in the drift window but older than
+	// the accepted one.
+	previous, err := totpCode(rfc6238Secret, totpStep(at)-1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mfa.VerifyTOTP(previous, at) {
+		t.Fatal("code older than the last accepted one accepted")
+	}
+	next, err := totpCode(rfc6238Secret, totpStep(at)+1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !mfa.VerifyTOTP(next, at.Add(totpPeriod*time.Second)) {
+		t.Fatal("code of the next period rejected")
+	}
+}
+
+func TestUserMFARecoveryCodesAreSingleUse(t *testing.T) {
+	mfa, err := NewUserMFA("user-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	codes, err := mfa.RegenerateRecoveryCodes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mfa.Verify(codes[0], time.Now()) {
+		t.Fatal("recovery code accepted before the factor is enabled")
+	}
+
+	codes, err = mfa.Activate(time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !mfa.Verify(" "+codes[0]+" ", time.Now()) {
+		t.Fatal("recovery code rejected")
+	}
+	if mfa.Verify(codes[0], time.Now()) {
+		t.Fatal("recovery code accepted twice")
+	}
+	if len(mfa.RecoveryCodes) != len(codes)-1 {
+		t.Fatalf("%d recovery codes left, want %d", len(mfa.RecoveryCodes), len(codes)-1)
+	}
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+)
+
+var (
+	ErrOIDCProviderDisabled = base.NewNotFoundError("sign-in provider is not configured")
+	ErrInvalidOIDCState     = base.NewUnauthorizedError("sign-in request is invalid or has expired")
+	ErrOIDCEmailNotVerified = base.NewForbiddenError("provider account email is not verified")
+	ErrOIDCEmailInUse       = base.NewConflictError("an account with this email already exists, sign in with your password")
+)
+
+// OIDCIdentity is the verified subject of an ID token.
+type OIDCIdentity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// IOIDCProvider runs the authorization code flow with PKCE against an OpenID
+// Connect issuer.
+type IOIDCProvider interface {
+	AuthCodeURL(ctx context.Context, request *OIDCAuthRequest) (string, error)
+	// Exchange redeems the code and returns the identity of the ID token,
+	// after checking its signature, audience, expiry and nonce.
+	Exchange(ctx context.Context, code string, request *OIDCAuthRequest) (*OIDCIdentity, error)
+}
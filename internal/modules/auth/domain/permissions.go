@@ -0,0 +1,7 @@
+package domain
+
+// Permissions checked by the auth module, granted to roles by the authz policy.
+const (
+	PermissionRevokeUserTokens = "auth:tokens:revoke:any"
+	PermissionUnlockUserSignin = "auth:signin:unlock"
+)
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+)
+
+func NewErrSigninLocked(retryAfter time.Duration) error {
+	return base.NewTooManyRequestsError("too many failed sign-in attempts, try again later", retryAfter)
+}
+
+// LockoutPolicy locks sign-in once MaxAttempts failures are reached and
+// doubles the lockout with every further failure, up to MaxLockout.
+type LockoutPolicy struct {
+	MaxAttempts      int64
+	MaxAttemptsPerIP int64
+	BaseLockout      time.Duration
+	MaxLockout       time.Duration
+	// FailureWindow is how long failures are remembered after the last one.
+	FailureWindow time.Duration
+}
+
+func (p LockoutPolicy) LockoutFor(failures, maxAttempts int64) time.Duration {
+	if maxAttempts <= 0 || failures < maxAttempts {
+		return 0
+	}
+
+	exponent := float64(failures - maxAttempts)
+	lockout := time.Duration(float64(p.BaseLockout) * math.Pow(2, exponent))
+	if lockout <= 0 || lockout > p.MaxLockout {
+		return p.MaxLockout
+	}
+	return lockout
+}
+
+// ISigninThrottle counts failed sign-ins per email and per client IP.
+type ISigninThrottle interface {
+	// LockedFor returns how long sign-in stays locked for email or ip.
+	LockedFor(ctx context.Context, email, ip string) (time.Duration, error)
+	// RecordFailure counts a failed attempt and returns the lockout it caused.
+	RecordFailure(ctx context.Context, email, ip string) (time.Duration, error)
+	// Reset forgets the failures of email after a successful sign-in.
+	Reset(ctx context.Context, email string) error
+}
+
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
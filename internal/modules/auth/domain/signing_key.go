@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var ErrUnsupportedSigningKey = errors.New("unsupported signing key, expected RSA or Ed25519")
+
+// SigningKey is a key used to sign or verify access tokens. Asymmetric keys are
+// identified by their RFC 7638 thumbprint, which is sent as the "kid" header.
+type SigningKey struct {
+	ID         string
+	Method     jwt.SigningMethod
+	PrivateKey crypto.PrivateKey
+	PublicKey  crypto.PublicKey
+}
+
+func NewHMACSigningKey(secret string) *SigningKey {
+	return &SigningKey{
+		Method:     jwt.SigningMethodHS256,
+		PrivateKey: []byte(secret),
+		PublicKey:  []byte(secret),
+	}
+}
+
+// NewAsymmetricSigningKey builds a key from an RSA or Ed25519 key. privateKey
+// may be nil for keys that are only used for verification.
+func NewAsymmetricSigningKey(privateKey crypto.PrivateKey, publicKey crypto.PublicKey) (*SigningKey, error) {
+	key := &SigningKey{
+		PrivateKey: privateKey,
+		PublicKey:  publicKey,
+	}
+
+	switch publicKey.(type) {
+	case *rsa.PublicKey:
+		key.Method = jwt.SigningMethodRS256
+	case ed25519.PublicKey:
+		key.Method = jwt.SigningMethodEdDSA
+	default:
+		return nil, ErrUnsupportedSigningKey
+	}
+
+	thumbprint, err := json.Marshal(key.JWK())
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(thumbprint)
+	key.ID = base64.RawURLEncoding.EncodeToString(sum[:])
+
+	return key, nil
+}
+
+func (k *SigningKey) IsSymmetric() bool {
+	_, ok := k.Method.(*jwt.SigningMethodHMAC)
+	return ok
+}
+
+// JWK returns the public part of the key with only the members required by
+// RFC 7638, in lexicographic order.
+func (k *SigningKey) JWK() map[string]string {
+	switch publicKey := k.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return map[string]string{
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes()),
+			"kty": "RSA",
+			"n":   base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+		}
+	case ed25519.PublicKey:
+		return map[string]string{
+			"crv": "Ed25519",
+			"kty": "OKP",
+			"x":   base64.RawURLEncoding.EncodeToString(publicKey),
+		}
+	default:
+		return nil
+	}
+}
+
+// KeySet holds the key access tokens are signed with and every key they may
+// still be verified with. Keeping the previous key in the verification set
+// lets tokens issued before a rotation expire naturally.
+type KeySet struct {
+	signing      *SigningKey
+	verification map[string]*SigningKey
+}
+
+func NewKeySet(signing *SigningKey, verification ...*SigningKey) *KeySet {
+	keys := &KeySet{
+		signing:      signing,
+		verification: make(map[string]*SigningKey, len(verification)+1),
+	}
+
+	keys.verification[signing.ID] = signing
+	for _, key := range verification {
+		keys.verification[key.ID] = key
+	}
+
+	return keys
+}
+
+func (k *KeySet) SigningKey() *SigningKey {
+	return k.signing
+}
+
+// VerificationKey is a jwt.Keyfunc resolving the key by the "kid" header.
+func (k *KeySet) VerificationKey(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := k.verification[kid]
+	if !ok || key.Method.Alg() != token.Method.Alg() {
+		return nil, ErrInvalidToken
+	}
+	return key.PublicKey, nil
+}
+
+// PublicKeys returns the verification keys that can be published. Symmetric
+// keys are secrets and never part of it.
+func (k *KeySet) PublicKeys() []*SigningKey {
+	keys := make([]*SigningKey, 0, len(k.verification))
+	for _, key := range k.verification {
+		if !key.IsSymmetric() {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
@@ -1,10 +1,14 @@
 package domain
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
 	"errors"
 	"time"
 
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -12,37 +16,77 @@ var (
 	ErrInvalidCredentials = errors.New("invalid email or password")
 	ErrInvalidToken       = errors.New("invalid token")
 	ErrExpiredToken       = errors.New("token expired")
+	ErrRevokedToken       = base.NewUnauthorizedError("token has been revoked")
 )
 
+func init() {
+	// Token times carry milliseconds, so that the denylist can tell a token
+	// issued right after a revocation from one issued right before it.
+	jwt.TimePrecision = time.Millisecond
+}
+
+// TokenClaims carries the user identity. For refresh tokens, SessionID is the
+// token family: it stays the same across rotations, while the jti
+// (RegisteredClaims.ID) changes with every issued token.
 type TokenClaims struct {
-	UserID string `json:"userId"`
-	Email  string `json:"email"`
-	Role   string `json:"role"`
+	UserID    string `json:"userId"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+	SessionID string `json:"sid"`
 	jwt.RegisteredClaims
 }
 
 type ITokenService interface {
 	HashPassword(password string) (string, error)
 	ComparePassword(hashedPassword, password string) error
-	GenerateAccessToken(userID, email, role string) (string, error)
-	GenerateRefreshToken(userID, email, role string) (string, error)
+	GenerateAccessToken(userID, email, role, sessionID string) (string, error)
+	GenerateRefreshToken(userID, email, role, sessionID, tokenID string) (string, error)
+	AccessTokenExpiry() time.Duration
+	RefreshTokenExpiry() time.Duration
 	ValidateToken(tokenString string) (*TokenClaims, error)
 	ValidateRefreshToken(tokenString string) (*TokenClaims, error)
+	GenerateActionToken(purpose TokenPurpose, userID, email, passwordStamp string) (string, error)
+	ValidateActionToken(purpose TokenPurpose, tokenString string) (*ActionTokenClaims, error)
+}
+
+// TokenOptions controls the lifetime and the registered claims of issued
+// tokens. Issuer and Audience are only set and enforced when not empty.
+type TokenOptions struct {
+	AccessTokenExpiry  time.Duration
+	RefreshTokenExpiry time.Duration
+	Issuer             string
+	Audience           string
+	ClockSkew          time.Duration
 }
 
 type TokenService struct {
-	accessTokenSecret  string
+	accessTokenKeys    *KeySet
 	refreshTokenSecret string
-	accessTokenExpiry  time.Duration
-	refreshTokenExpiry time.Duration
+	options            TokenOptions
+	parserOptions      []jwt.ParserOption
 }
 
-func NewTokenService(accessTokenSecret, refreshTokenSecret string) ITokenService {
+// NewTokenService signs access tokens with the signing key of accessTokenKeys so
+// other services can verify them through the published JWKS. Refresh tokens are
+// only ever read by this service and stay HMAC signed.
+func NewTokenService(accessTokenKeys *KeySet, refreshTokenSecret string, options TokenOptions) ITokenService {
+	parserOptions := []jwt.ParserOption{
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(options.ClockSkew),
+	}
+	if options.Issuer != "" {
+		parserOptions = append(parserOptions, jwt.WithIssuer(options.Issuer))
+	}
+	if options.Audience != "" {
+		parserOptions = append(parserOptions, jwt.WithAudience(options.Audience))
+	}
+
 	return &TokenService{
-		accessTokenSecret:  accessTokenSecret,
+		accessTokenKeys:    accessTokenKeys,
 		refreshTokenSecret: refreshTokenSecret,
-		accessTokenExpiry:  15 * time.Minute,
-		refreshTokenExpiry: 7 * 24 * time.Hour,
+		options:            options,
+		parserOptions:      parserOptions,
 	}
 }
 
@@ -58,43 +102,60 @@ func (s *TokenService) ComparePassword(hashedPassword, password string) error {
 	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
 }
 
-func (s *TokenService) GenerateAccessToken(userID, email, role string) (string, error) {
+func (s *TokenService) GenerateAccessToken(userID, email, role, sessionID string) (string, error) {
 	claims := &TokenClaims{
-		UserID: userID,
-		Email:  email,
-		Role:   role,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.accessTokenExpiry)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
+		UserID:           userID,
+		Email:            email,
+		Role:             role,
+		SessionID:        sessionID,
+		RegisteredClaims: s.registeredClaims(uuid.NewString(), s.options.AccessTokenExpiry),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(s.accessTokenSecret))
+	key := s.accessTokenKeys.SigningKey()
+	token := jwt.NewWithClaims(key.Method, claims)
+	if key.ID != "" {
+		token.Header["kid"] = key.ID
+	}
+	return token.SignedString(key.PrivateKey)
 }
 
-func (s *TokenService) GenerateRefreshToken(userID, email, role string) (string, error) {
+func (s *TokenService) GenerateRefreshToken(userID, email, role, sessionID, tokenID string) (string, error) {
 	claims := &TokenClaims{
-		UserID: userID,
-		Email:  email,
-		Role:   role,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.refreshTokenExpiry)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
+		UserID:           userID,
+		Email:            email,
+		Role:             role,
+		SessionID:        sessionID,
+		RegisteredClaims: s.registeredClaims(tokenID, s.options.RefreshTokenExpiry),
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString([]byte(s.refreshTokenSecret))
 }
 
+func (s *TokenService) registeredClaims(tokenID string, expiry time.Duration) jwt.RegisteredClaims {
+	now := time.Now()
+	claims := jwt.RegisteredClaims{
+		ID:        tokenID,
+		Issuer:    s.options.Issuer,
+		ExpiresAt: jwt.NewNumericDate(now.Add(expiry)),
+		IssuedAt:  jwt.NewNumericDate(now),
+	}
+	if s.options.Audience != "" {
+		claims.Audience = jwt.ClaimStrings{s.options.Audience}
+	}
+	return claims
+}
+
+func (s *TokenService) AccessTokenExpiry() time.Duration {
+	return s.options.AccessTokenExpiry
+}
+
+func (s *TokenService) RefreshTokenExpiry() time.Duration {
+	return s.options.RefreshTokenExpiry
+}
+
 func (s *TokenService) ValidateToken(tokenString string) (*TokenClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &TokenClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, ErrInvalidToken
-		}
-		return []byte(s.accessTokenSecret), nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &TokenClaims{}, s.accessTokenKeys.VerificationKey, s.parserOptions...)
 
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
@@ -116,7 +177,7 @@ func (s *TokenService) ValidateRefreshToken(tokenString string) (*TokenClaims, e
 			return nil, ErrInvalidToken
 		}
 		return []byte(s.refreshTokenSecret), nil
-	})
+	}, s.parserOptions...)
 
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
@@ -131,3 +192,44 @@ func (s *TokenService) ValidateRefreshToken(tokenString string) (*TokenClaims, e
 
 	return nil, ErrInvalidToken
 }
+
+// actionTokenSecret derives a distinct key per purpose from the refresh token
+// secret, so an action token is never accepted as a refresh token or for
+// another purpose.
+func (s *TokenService) actionTokenSecret(purpose TokenPurpose) []byte {
+	mac := hmac.New(sha256.New, []byte(s.refreshTokenSecret))
+	mac.Write([]byte(purpose))
+	return mac.Sum(nil)
+}
+
+func (s *TokenService) GenerateActionToken(purpose TokenPurpose, userID, email, passwordStamp string) (string, error) {
+	claims := &ActionTokenClaims{
+		UserID:           userID,
+		Email:            email,
+		Purpose:          purpose,
+		PasswordStamp:    passwordStamp,
+		RegisteredClaims: s.registeredClaims(uuid.NewString(), purpose.Expiry()),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(s.actionTokenSecret(purpose))
+}
+
+func (s *TokenService) ValidateActionToken(purpose TokenPurpose, tokenString string) (*ActionTokenClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &ActionTokenClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, ErrInvalidActionToken
+		}
+		return s.actionTokenSecret(purpose), nil
+	}, s.parserOptions...)
+	if err != nil {
+		return nil, ErrInvalidActionToken
+	}
+
+	claims, ok := token.Claims.(*ActionTokenClaims)
+	if !ok || !token.Valid || claims.Purpose != purpose || claims.ID == "" {
+		return nil, ErrInvalidActionToken
+	}
+
+	return claims, nil
+}
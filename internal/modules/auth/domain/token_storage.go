@@ -1,46 +1,31 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
 
-type ITokenStorage interface {
-	StoreRefreshToken(ctx context.Context, userID, token string) error
-	GetRefreshToken(ctx context.Context, userID string) (string, error)
-	DeleteRefreshToken(ctx context.Context, userID string) error
-	IsRefreshTokenValid(ctx context.Context, userID, token string) (bool, error)
-}
-
-type InMemoryTokenStorage struct {
-	tokens map[string]string
-}
-
-func NewInMemoryTokenStorage() ITokenStorage {
-	return &InMemoryTokenStorage{
-		tokens: make(map[string]string),
-	}
-}
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+)
 
-func (s *InMemoryTokenStorage) StoreRefreshToken(ctx context.Context, userID, token string) error {
-	s.tokens[userID] = token
-	return nil
-}
-
-func (s *InMemoryTokenStorage) GetRefreshToken(ctx context.Context, userID string) (string, error) {
-	token, exists := s.tokens[userID]
-	if !exists {
-		return "", ErrInvalidToken
-	}
-	return token, nil
-}
+var (
+	ErrSessionNotFound    = base.NewNotFoundError("session not found")
+	ErrRefreshTokenReused = base.NewUnauthorizedError("refresh token has already been used, please sign in again")
+)
 
-func (s *InMemoryTokenStorage) DeleteRefreshToken(ctx context.Context, userID string) error {
-	delete(s.tokens, userID)
-	return nil
-}
-
-func (s *InMemoryTokenStorage) IsRefreshTokenValid(ctx context.Context, userID, token string) (bool, error) {
-	storedToken, err := s.GetRefreshToken(ctx, userID)
-	if err != nil {
-		return false, err
-	}
-	return storedToken == token, nil
+// ITokenStorage keeps one refresh token session per signed-in device.
+// Sessions expire together with the refresh token they hold.
+type ITokenStorage interface {
+	StoreSession(ctx context.Context, session *Session) error
+	GetSession(ctx context.Context, userID, sessionID string) (*Session, error)
+	ListSessions(ctx context.Context, userID string) ([]*Session, error)
+	DeleteSession(ctx context.Context, userID, sessionID string) error
+	DeleteAllSessions(ctx context.Context, userID string) error
+	// ConsumeRefreshToken atomically marks a refresh token ID as used and
+	// reports whether this call was the first to do so.
+	ConsumeRefreshToken(ctx context.Context, tokenID string, ttl time.Duration) (bool, error)
+	// ConsumeActionToken does the same for emailed action tokens.
+	ConsumeActionToken(ctx context.Context, tokenID string, ttl time.Duration) (bool, error)
+	// RecordActionTokenFailure counts a wrong code entered with an action
+	// token and returns the count so far.
+	RecordActionTokenFailure(ctx context.Context, tokenID string, ttl time.Duration) (int64, error)
 }
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+)
+
+// rfc6238Secret is the SHA-1 seed of the RFC 6238 appendix B test vectors.
+var rfc6238Secret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+// The RFC lists 8-digit codes; 6-digit codes are their last six digits.
+var rfc6238Vectors = []struct {
+	unix int64
+	code string
+}{
+	{59, "287082"},
+	{1111111109, "081804"},
+	{1111111111, "050471"},
+	{1234567890, "005924"},
+	{2000000000, "279037"},
+	{20000000000, "353130"},
+}
+
+func TestTOTPCodeMatchesRFC6238Vectors(t *testing.T) {
+	for _, vector := range rfc6238Vectors {
+		code, err := totpCode(rfc6238Secret, totpStep(time.Unix(vector.unix, 0)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if code != vector.code {
+			t.Errorf("code at %d = %s, want %s", vector.unix, code, vector.code)
+		}
+	}
+}
+
+func TestMatchTOTPAcceptsOneStepOfClockDrift(t *testing.T) {
+	// 1111111111 is in step 37037037.
+	at := time.Unix(1111111111, 0)
+	tests := []struct {
+		now  time.Time
+		want bool
+	}{
+		{at, true},
+		{at.Add(-totpPeriod * time.Second), true},
+		{at.Add(totpPeriod * time.Second), true},
+		{at.Add(-2 * totpPeriod * time.Second), false},
+		{at.Add(2 * totpPeriod * time.Second), false},
+	}
+	for _, tt := range tests {
+		step, ok := matchTOTP(rfc6238Secret, "050471", tt.now)
+		if ok != tt.want {
+			t.Errorf("match at %d = %v, want %v", tt.now.Unix(), ok, tt.want)
+		}
+		if ok && step != 37037037 {
+			t.Errorf("matched step %d, want 37037037", step)
+		}
+	}
+}
+
+func TestMatchTOTPRejectsMalformedCodes(t *testing.T) {
+	at := time.Unix(1111111111, 0)
+	if _, ok := matchTOTP(rfc6238Secret, " 050471 ", at); !ok {
+		t.Error("code with surrounding spaces rejected")
+	}
+	for _, code := range []string{"", "05047", "0504710", "14050471"} {
+		if _, ok := matchTOTP(rfc6238Secret, code, at); ok {
+			t.Errorf("code %q accepted", code)
+		}
+	}
+	if _, ok := matchTOTP("not base32!", "050471", at); ok {
+		t.Error("code accepted for an undecodable secret")
+	}
+}
@@ -1,6 +1,12 @@
 package domain
 
-import "context"
+import (
+	"context"
+
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+)
+
+var ErrAccountDisabled = base.NewForbiddenError("account is disabled")
 
 type IUserRepository interface {
 	GetByEmail(ctx context.Context, email string) (*UserInfo, error)
@@ -8,8 +14,10 @@ type IUserRepository interface {
 }
 
 type UserInfo struct {
-	ID       string
-	Email    string
-	Password string
-	Role     string
+	ID            string
+	Email         string
+	Password      string
+	Role          string
+	EmailVerified bool
+	Disabled      bool
 }
@@ -4,36 +4,152 @@ import (
 	"github.com/dukk308/beetool.dev-go-starter/internal/config"
 	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/application"
 	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/infrastructure/keys"
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/infrastructure/oidc"
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/infrastructure/persistence"
 	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/infrastructure/repository"
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/infrastructure/storage"
 	auth_http "github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/presentation/http"
 	user_domain "github.com/dukk308/beetool.dev-go-starter/internal/modules/user/domain"
+	redis_component "github.com/dukk308/beetool.dev-go-starter/pkgs/components/cache_comp"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/logger"
+	middleware "github.com/dukk308/beetool.dev-go-starter/pkgs/middlewares/gin"
 	"go.uber.org/fx"
 )
 
 var Module = fx.Module("auth",
 	fx.Provide(
-		func(cfg *config.Config) domain.ITokenService {
-			return domain.NewTokenService(cfg.Auth.AccessTokenSecret, cfg.Auth.RefreshTokenSecret)
+		keys.LoadKeySet,
+		func(cfg *config.Config, accessTokenKeys *domain.KeySet) domain.ITokenService {
+			return domain.NewTokenService(accessTokenKeys, cfg.Auth.RefreshTokenSecret, domain.TokenOptions{
+				AccessTokenExpiry:  cfg.Auth.AccessTokenExpiry,
+				RefreshTokenExpiry: cfg.Auth.RefreshTokenExpiry,
+				Issuer:             cfg.Auth.Issuer,
+				Audience:           cfg.Auth.Audience,
+				ClockSkew:          cfg.Auth.ClockSkew,
+			})
 		},
 	),
 	fx.Provide(
 		fx.Annotate(
-			domain.NewInMemoryTokenStorage,
+			storage.NewRedisTokenStorage,
 			fx.As(new(domain.ITokenStorage)),
 		),
 	),
+	fx.Provide(
+		func(cfg *config.Config, cache redis_component.ICacheService) domain.IAccessTokenDenylist {
+			return storage.NewRedisAccessTokenDenylist(cache, cfg.Auth.ClockSkew)
+		},
+		fx.Annotate(
+			storage.NewRedisOIDCStateStorage,
+			fx.As(new(domain.IOIDCStateStorage)),
+		),
+	),
+	fx.Provide(
+		func(cfg *config.Config, cache redis_component.ICacheService) domain.ISigninThrottle {
+			return storage.NewRedisSigninThrottle(cache, domain.LockoutPolicy{
+				MaxAttempts:      cfg.Auth.Lockout.MaxAttempts,
+				MaxAttemptsPerIP: cfg.Auth.Lockout.MaxAttemptsPerIP,
+				BaseLockout:      cfg.Auth.Lockout.BaseLockout,
+				MaxLockout:       cfg.Auth.Lockout.MaxLockout,
+				FailureWindow:    cfg.Auth.Lockout.FailureWindow,
+			})
+		},
+	),
+	fx.Provide(
+		func(cfg *config.Config) domain.IOIDCProvider {
+			return oidc.NewProvider(cfg.Auth.Google)
+		},
+	),
 	fx.Provide(
 		func(userRepository user_domain.IViewerRepository) domain.IUserRepository {
 			return repository.NewUserRepositoryAdapter(userRepository)
 		},
 	),
+	// Services the user module consumes through its own interfaces.
+	fx.Provide(
+		application.NewUserSessionRevoker,
+		application.NewVerificationEmailSender,
+		func(tokenService domain.ITokenService) user_domain.IPasswordHasher {
+			return tokenService
+		},
+	),
+	fx.Provide(
+		fx.Annotate(
+			persistence.NewMFARepository,
+			fx.As(new(domain.IMFARepository)),
+		),
+		func(cfg *config.Config) domain.MFAOptions {
+			return domain.MFAOptions{
+				Issuer:        cfg.Auth.MFA.Issuer,
+				RequiredRoles: cfg.Auth.MFA.RequiredRoles,
+			}
+		},
+		application.NewSigninFinalizer,
+	),
+	fx.Provide(
+		fx.Annotate(
+			persistence.NewAPIKeyRepository,
+			fx.As(new(domain.IAPIKeyRepository)),
+		),
+		fx.Annotate(
+			application.NewAPIKeyAuthenticator,
+			fx.As(new(domain.IAPIKeyAuthenticator)),
+		),
+		fx.Annotate(
+			application.NewAuthenticator,
+			fx.As(new(domain.IAuthenticator)),
+		),
+	),
+	fx.Provide(
+		func(cfg *config.Config) domain.EmailLinks {
+			return domain.EmailLinks{
+				VerifyEmailURL:   cfg.Auth.VerifyEmailURL,
+				ResetPasswordURL: cfg.Auth.ResetPasswordURL,
+			}
+		},
+	),
+	fx.Provide(
+		func(
+			cfg *config.Config,
+			repository domain.IUserRepository,
+			tokenService domain.ITokenService,
+			finalizer *application.SigninFinalizer,
+			throttle domain.ISigninThrottle,
+			log logger.Logger,
+		) *application.SigninCommand {
+			return application.NewSigninCommand(repository, tokenService, finalizer, throttle, log, cfg.Auth.RequireVerifiedEmail)
+		},
+	),
 	fx.Provide(
 		application.NewSignupCommand,
-		application.NewSigninCommand,
 		application.NewSignoutCommand,
 		application.NewRefreshTokenCommand,
+		application.NewViewerListSessionsQuery,
+		application.NewViewerRevokeSessionCommand,
+		application.NewAdminRevokeUserTokensCommand,
+		application.NewAdminUnlockUserSigninCommand,
+		application.NewPublicGetJWKSQuery,
+		application.NewPublicStartGoogleSigninCommand,
+		application.NewPublicGoogleSigninCallbackCommand,
+		application.NewPublicSendVerificationEmailCommand,
+		application.NewPublicVerifyEmailCommand,
+		application.NewPublicForgotPasswordCommand,
+		application.NewPublicResetPasswordCommand,
+		application.NewPublicEnrollMFAChallengeCommand,
+		application.NewPublicVerifyMFAChallengeCommand,
+		application.NewViewerEnrollTOTPCommand,
+		application.NewViewerActivateTOTPCommand,
+		application.NewViewerDisableTOTPCommand,
+		application.NewViewerRegenerateRecoveryCodesCommand,
+		application.NewViewerCreateAPIKeyCommand,
+		application.NewViewerListAPIKeysQuery,
+		application.NewViewerGetAPIKeyQuery,
+		application.NewViewerUpdateAPIKeyCommand,
+		application.NewViewerDeleteAPIKeyCommand,
 	),
 	fx.Provide(
 		auth_http.NewHttp,
+		middleware.NewRouteGroups,
 	),
 )
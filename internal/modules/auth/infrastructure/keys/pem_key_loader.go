@@ -0,0 +1,96 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/config"
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+)
+
+// LoadKeySet builds the access token key set from the auth config. Without a
+// signing key file it falls back to HS256 with the access token secret.
+func LoadKeySet(cfg *config.Config) (*domain.KeySet, error) {
+	if cfg.Auth.SigningKeyFile == "" {
+		return domain.NewKeySet(domain.NewHMACSigningKey(cfg.Auth.AccessTokenSecret)), nil
+	}
+
+	signing, err := loadKeyFile(cfg.Auth.SigningKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	if signing.PrivateKey == nil {
+		return nil, fmt.Errorf("signing key file %s does not contain a private key", cfg.Auth.SigningKeyFile)
+	}
+
+	verification := make([]*domain.SigningKey, 0, len(cfg.Auth.VerificationKeyFiles))
+	for _, path := range cfg.Auth.VerificationKeyFiles {
+		key, err := loadKeyFile(path)
+		if err != nil {
+			return nil, err
+		}
+		verification = append(verification, key)
+	}
+
+	return domain.NewKeySet(signing, verification...), nil
+}
+
+// loadKeyFile reads a PEM file holding either a private key (PKCS#8 or PKCS#1)
+// or a public key (PKIX).
+func loadKeyFile(path string) (*domain.SigningKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read key file %s: %w", path, err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("key file %s is not PEM encoded", path)
+	}
+
+	var key *domain.SigningKey
+	switch block.Type {
+	case "PUBLIC KEY":
+		publicKey, parseErr := x509.ParsePKIXPublicKey(block.Bytes)
+		if parseErr != nil {
+			return nil, fmt.Errorf("parse public key %s: %w", path, parseErr)
+		}
+		key, err = domain.NewAsymmetricSigningKey(nil, publicKey)
+	case "PRIVATE KEY", "RSA PRIVATE KEY":
+		privateKey, parseErr := parsePrivateKey(block)
+		if parseErr != nil {
+			return nil, fmt.Errorf("parse private key %s: %w", path, parseErr)
+		}
+		key, err = domain.NewAsymmetricSigningKey(privateKey, publicKeyOf(privateKey))
+	default:
+		return nil, fmt.Errorf("key file %s has unsupported PEM block %q", path, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("key file %s: %w", path, err)
+	}
+
+	return key, nil
+}
+
+func parsePrivateKey(block *pem.Block) (crypto.PrivateKey, error) {
+	if block.Type == "RSA PRIVATE KEY" {
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	}
+	return x509.ParsePKCS8PrivateKey(block.Bytes)
+}
+
+func publicKeyOf(privateKey crypto.PrivateKey) crypto.PublicKey {
+	switch key := privateKey.(type) {
+	case *rsa.PrivateKey:
+		return &key.PublicKey
+	case ed25519.PrivateKey:
+		return key.Public()
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,114 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/dukk308/beetool.dev-go-starter/internal/config"
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	"golang.org/x/oauth2"
+)
+
+// Provider is an OpenID Connect provider discovered from its issuer URL. The
+// discovery runs on first use, so the service starts even when the issuer is
+// unreachable, and is retried until it succeeds.
+type Provider struct {
+	cfg config.OIDCProviderConfig
+
+	mu       sync.Mutex
+	oauth2   *oauth2.Config
+	verifier *oidc.IDTokenVerifier
+}
+
+func NewProvider(cfg config.OIDCProviderConfig) domain.IOIDCProvider {
+	return &Provider{
+		cfg: cfg,
+	}
+}
+
+func (p *Provider) discover(ctx context.Context) (*oauth2.Config, *oidc.IDTokenVerifier, error) {
+	if p.cfg.ClientID == "" {
+		return nil, nil, domain.ErrOIDCProviderDisabled
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.oauth2 != nil {
+		return p.oauth2, p.verifier, nil
+	}
+
+	// The provider keeps using this context to refresh its keys, so it must
+	// not be bound to the request that triggered the discovery.
+	provider, err := oidc.NewProvider(context.WithoutCancel(ctx), p.cfg.IssuerURL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	p.oauth2 = &oauth2.Config{
+		ClientID:     p.cfg.ClientID,
+		ClientSecret: p.cfg.ClientSecret,
+		RedirectURL:  p.cfg.RedirectURL,
+		Endpoint:     provider.Endpoint(),
+		Scopes:       []string{oidc.ScopeOpenID, "email", "profile"},
+	}
+	p.verifier = provider.Verifier(&oidc.Config{ClientID: p.cfg.ClientID})
+
+	return p.oauth2, p.verifier, nil
+}
+
+func (p *Provider) AuthCodeURL(ctx context.Context, request *domain.OIDCAuthRequest) (string, error) {
+	oauth2Config, _, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return oauth2Config.AuthCodeURL(
+		request.State,
+		oidc.Nonce(request.Nonce),
+		oauth2.S256ChallengeOption(request.CodeVerifier),
+	), nil
+}
+
+func (p *Provider) Exchange(ctx context.Context, code string, request *domain.OIDCAuthRequest) (*domain.OIDCIdentity, error) {
+	oauth2Config, verifier, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := oauth2Config.Exchange(ctx, code, oauth2.VerifierOption(request.CodeVerifier))
+	if err != nil {
+		return nil, domain.ErrInvalidOIDCState.Wrap(err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, domain.ErrInvalidOIDCState.Wrap(errors.New("token response has no id_token"))
+	}
+
+	idToken, err := verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, domain.ErrInvalidOIDCState.Wrap(err)
+	}
+	if idToken.Nonce != request.Nonce {
+		return nil, domain.ErrInvalidOIDCState.Wrap(errors.New("id_token nonce mismatch"))
+	}
+
+	var claims struct {
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+		Name          string `json:"name"`
+	}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, err
+	}
+
+	return &domain.OIDCIdentity{
+		Subject:       idToken.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		Name:          claims.Name,
+	}, nil
+}
@@ -0,0 +1,71 @@
+package persistence
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	common "github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gorm_comp"
+	"github.com/google/uuid"
+)
+
+type SQLAPIKey struct {
+	gorm_comp.SQLModel
+	UserID     string     `gorm:"column:user_id;type:text;index:idx_api_keys_user_id;not null"`
+	Name       string     `gorm:"column:name;type:varchar(100);not null"`
+	Prefix     string     `gorm:"column:prefix;type:varchar(32);uniqueIndex:uni_api_keys_prefix;not null"`
+	KeyHash    string     `gorm:"column:key_hash;type:varchar(64);not null"`
+	Scopes     string     `gorm:"column:scopes;type:text"`
+	ExpiresAt  *time.Time `gorm:"column:expires_at;type:timestamp without time zone"`
+	LastUsedAt *time.Time `gorm:"column:last_used_at;type:timestamp without time zone"`
+}
+
+func (m *SQLAPIKey) TableName() string {
+	return "api_keys"
+}
+
+func (m *SQLAPIKey) ToDomain() (*domain.APIKey, error) {
+	var scopes []string
+	if m.Scopes != "" {
+		if err := json.Unmarshal([]byte(m.Scopes), &scopes); err != nil {
+			return nil, err
+		}
+	}
+
+	return &domain.APIKey{
+		BaseModel: common.BaseModel{
+			ID:        uuid.MustParse(m.ID),
+			Version:   m.Version,
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+		},
+		UserID:     m.UserID,
+		Name:       m.Name,
+		Prefix:     m.Prefix,
+		KeyHash:    m.KeyHash,
+		Scopes:     scopes,
+		ExpiresAt:  m.ExpiresAt,
+		LastUsedAt: m.LastUsedAt,
+	}, nil
+}
+
+func (m *SQLAPIKey) FromDomain(key *domain.APIKey) error {
+	scopes, err := json.Marshal(key.Scopes)
+	if err != nil {
+		return err
+	}
+
+	m.ID = key.ID.String()
+	m.Version = key.Version
+	m.CreatedAt = key.CreatedAt
+	m.UpdatedAt = key.UpdatedAt
+	m.UserID = key.UserID
+	m.Name = key.Name
+	m.Prefix = key.Prefix
+	m.KeyHash = key.KeyHash
+	m.Scopes = string(scopes)
+	m.ExpiresAt = key.ExpiresAt
+	m.LastUsedAt = key.LastUsedAt
+	return nil
+}
@@ -0,0 +1,98 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+)
+
+type APIKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewAPIKeyRepository(db *gorm.DB) domain.IAPIKeyRepository {
+	return &APIKeyRepository{
+		db: db,
+	}
+}
+
+func (r *APIKeyRepository) Create(ctx context.Context, key *domain.APIKey) error {
+	sqlKey := &SQLAPIKey{}
+	if err := sqlKey.FromDomain(key); err != nil {
+		return err
+	}
+
+	return r.db.WithContext(ctx).Create(sqlKey).Error
+}
+
+func (r *APIKeyRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.APIKey, error) {
+	var sqlKeys []SQLAPIKey
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at DESC").Find(&sqlKeys).Error; err != nil {
+		return nil, err
+	}
+
+	keys := make([]*domain.APIKey, 0, len(sqlKeys))
+	for i := range sqlKeys {
+		key, err := sqlKeys[i].ToDomain()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+func (r *APIKeyRepository) GetByID(ctx context.Context, userID, id string) (*domain.APIKey, error) {
+	return r.first(r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID))
+}
+
+func (r *APIKeyRepository) GetByPrefix(ctx context.Context, prefix string) (*domain.APIKey, error) {
+	return r.first(r.db.WithContext(ctx).Where("prefix = ?", prefix))
+}
+
+func (r *APIKeyRepository) first(query *gorm.DB) (*domain.APIKey, error) {
+	var sqlKey SQLAPIKey
+	if err := query.First(&sqlKey).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+
+	return sqlKey.ToDomain()
+}
+
+func (r *APIKeyRepository) Update(ctx context.Context, key *domain.APIKey) error {
+	return r.db.WithContext(ctx).
+		Model(&SQLAPIKey{}).
+		Where("id = ? AND user_id = ?", key.ID.String(), key.UserID).
+		Updates(map[string]interface{}{
+			"name":       key.Name,
+			"updated_at": key.UpdatedAt,
+		}).Error
+}
+
+func (r *APIKeyRepository) TouchLastUsed(ctx context.Context, id string, at time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&SQLAPIKey{}).
+		Where("id = ?", id).
+		UpdateColumn("last_used_at", at).Error
+}
+
+// Delete removes the key for good; revoked credentials are not kept in the
+// trash.
+func (r *APIKeyRepository) Delete(ctx context.Context, userID, id string) error {
+	result := r.db.WithContext(ctx).Unscoped().Where("id = ? AND user_id = ?", id, userID).Delete(&SQLAPIKey{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrAPIKeyNotFound
+	}
+	return nil
+}
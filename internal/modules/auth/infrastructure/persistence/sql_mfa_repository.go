@@ -0,0 +1,53 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+)
+
+type MFARepository struct {
+	db *gorm.DB
+}
+
+func NewMFARepository(db *gorm.DB) domain.IMFARepository {
+	return &MFARepository{
+		db: db,
+	}
+}
+
+func (r *MFARepository) GetByUserID(ctx context.Context, userID string) (*domain.UserMFA, error) {
+	var sqlMFA SQLUserMFA
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&sqlMFA).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrMFANotFound
+		}
+		return nil, err
+	}
+
+	return sqlMFA.ToDomain()
+}
+
+func (r *MFARepository) Save(ctx context.Context, mfa *domain.UserMFA) error {
+	sqlMFA := &SQLUserMFA{}
+	if err := sqlMFA.FromDomain(mfa); err != nil {
+		return err
+	}
+
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"secret", "recovery_codes", "last_used_step", "enabled_at", "updated_at", "updated_by"}),
+		}).
+		Create(sqlMFA).Error
+}
+
+// Delete removes the enrollment for good, so the user can enroll again under
+// the unique user_id.
+func (r *MFARepository) Delete(ctx context.Context, userID string) error {
+	return r.db.WithContext(ctx).Unscoped().Where("user_id = ?", userID).Delete(&SQLUserMFA{}).Error
+}
@@ -0,0 +1,65 @@
+package persistence
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	common "github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gorm_comp"
+	"github.com/google/uuid"
+)
+
+type SQLUserMFA struct {
+	gorm_comp.SQLModel
+	UserID        string     `gorm:"column:user_id;type:text;uniqueIndex:uni_user_mfa_user_id;not null"`
+	Secret        string     `gorm:"column:secret;type:varchar(255);not null"`
+	RecoveryCodes string     `gorm:"column:recovery_codes;type:text"`
+	LastUsedStep  int64      `gorm:"column:last_used_step;not null;default:0"`
+	EnabledAt     *time.Time `gorm:"column:enabled_at;type:timestamp without time zone"`
+}
+
+func (m *SQLUserMFA) TableName() string {
+	return "user_mfa"
+}
+
+func (m *SQLUserMFA) ToDomain() (*domain.UserMFA, error) {
+	var recoveryCodes []string
+	if m.RecoveryCodes != "" {
+		if err := json.Unmarshal([]byte(m.RecoveryCodes), &recoveryCodes); err != nil {
+			return nil, err
+		}
+	}
+
+	return &domain.UserMFA{
+		BaseModel: common.BaseModel{
+			ID:        uuid.MustParse(m.ID),
+			Version:   m.Version,
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+		},
+		UserID:        m.UserID,
+		Secret:        m.Secret,
+		RecoveryCodes: recoveryCodes,
+		LastUsedStep:  m.LastUsedStep,
+		EnabledAt:     m.EnabledAt,
+	}, nil
+}
+
+func (m *SQLUserMFA) FromDomain(mfa *domain.UserMFA) error {
+	recoveryCodes, err := json.Marshal(mfa.RecoveryCodes)
+	if err != nil {
+		return err
+	}
+
+	m.ID = mfa.ID.String()
+	m.Version = mfa.Version
+	m.CreatedAt = mfa.CreatedAt
+	m.UpdatedAt = mfa.UpdatedAt
+	m.UserID = mfa.UserID
+	m.Secret = mfa.Secret
+	m.RecoveryCodes = string(recoveryCodes)
+	m.LastUsedStep = mfa.LastUsedStep
+	m.EnabledAt = mfa.EnabledAt
+	return nil
+}
@@ -24,10 +24,12 @@ func (a *UserRepositoryAdapter) GetByEmail(ctx context.Context, email string) (*
 	}
 
 	return &domain.UserInfo{
-		ID:       viewer.ID.String(),
-		Email:    viewer.Email.Value,
-		Password: viewer.Password,
-		Role:     viewer.Role.String(),
+		ID:            viewer.ID.String(),
+		Email:         viewer.Email.Value,
+		Password:      viewer.Password,
+		Role:          viewer.Role.String(),
+		EmailVerified: viewer.IsEmailVerified(),
+		Disabled:      viewer.IsDisabled(),
 	}, nil
 }
 
@@ -38,9 +40,11 @@ func (a *UserRepositoryAdapter) GetByID(ctx context.Context, id string) (*domain
 	}
 
 	return &domain.UserInfo{
-		ID:       viewer.ID.String(),
-		Email:    viewer.Email.Value,
-		Password: viewer.Password,
-		Role:     viewer.Role.String(),
+		ID:            viewer.ID.String(),
+		Email:         viewer.Email.Value,
+		Password:      viewer.Password,
+		Role:          viewer.Role.String(),
+		EmailVerified: viewer.IsEmailVerified(),
+		Disabled:      viewer.IsDisabled(),
 	}, nil
 }
@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	redis_component "github.com/dukk308/beetool.dev-go-starter/pkgs/components/cache_comp"
+)
+
+const (
+	deniedTokenKeyPattern   = "auth:denylist:token:%s"
+	deniedUserKeyPattern    = "auth:denylist:user:%s"
+	deniedSessionKeyPattern = "auth:denylist:session:%s"
+)
+
+// RedisAccessTokenDenylist keeps entries clockSkew longer than the tokens
+// they deny, since tokens are still accepted for that long after they expire.
+type RedisAccessTokenDenylist struct {
+	cache     redis_component.ICacheService
+	clockSkew time.Duration
+}
+
+func NewRedisAccessTokenDenylist(cache redis_component.ICacheService, clockSkew time.Duration) domain.IAccessTokenDenylist {
+	return &RedisAccessTokenDenylist{
+		cache:     cache,
+		clockSkew: clockSkew,
+	}
+}
+
+func deniedTokenKey(tokenID string) string {
+	return fmt.Sprintf(deniedTokenKeyPattern, tokenID)
+}
+
+func deniedUserKey(userID string) string {
+	return fmt.Sprintf(deniedUserKeyPattern, userID)
+}
+
+func deniedSessionKey(sessionID string) string {
+	return fmt.Sprintf(deniedSessionKeyPattern, sessionID)
+}
+
+func (d *RedisAccessTokenDenylist) Deny(ctx context.Context, claims *domain.TokenClaims) error {
+	if claims.ID == "" || claims.ExpiresAt == nil {
+		return domain.ErrInvalidToken
+	}
+
+	ttl := time.Until(claims.ExpiresAt.Time) + d.clockSkew
+	if ttl <= 0 {
+		return nil
+	}
+
+	return d.cache.SetEx(ctx, deniedTokenKey(claims.ID), "1", ttl)
+}
+
+// DenyUser stores the revocation time in milliseconds, the precision of iat.
+// Tokens issued within the millisecond of the revocation may predate it, so
+// it is stored as the next millisecond.
+func (d *RedisAccessTokenDenylist) DenyUser(ctx context.Context, userID string, ttl time.Duration) error {
+	revokedAt := strconv.FormatInt(time.Now().UnixMilli()+1, 10)
+	return d.cache.SetEx(ctx, deniedUserKey(userID), revokedAt, ttl+d.clockSkew)
+}
+
+// DenySession denies the session for ttl. Sessions are never reused, so
+// unlike DenyUser the entry does not need a revocation time.
+func (d *RedisAccessTokenDenylist) DenySession(ctx context.Context, sessionID string, ttl time.Duration) error {
+	return d.cache.SetEx(ctx, deniedSessionKey(sessionID), "1", ttl+d.clockSkew)
+}
+
+func (d *RedisAccessTokenDenylist) IsDenied(ctx context.Context, claims *domain.TokenClaims) (bool, error) {
+	for id, key := range map[string]string{claims.ID: deniedTokenKey(claims.ID), claims.SessionID: deniedSessionKey(claims.SessionID)} {
+		if id == "" {
+			continue
+		}
+		denied, err := d.cache.IsExist(ctx, key)
+		if err != nil {
+			return false, err
+		}
+		if denied {
+			return true, nil
+		}
+	}
+
+	raw, err := d.cache.Get(ctx, deniedUserKey(claims.UserID))
+	if err != nil {
+		return false, err
+	}
+	if raw == nil {
+		return false, nil
+	}
+
+	revokedAt, err := strconv.ParseInt(*raw, 10, 64)
+	if err != nil {
+		return false, err
+	}
+
+	return claims.IssuedAt == nil || claims.IssuedAt.UnixMilli() < revokedAt, nil
+}
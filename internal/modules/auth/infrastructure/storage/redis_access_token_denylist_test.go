@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/cache_comp/cachetest"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func accessClaims(userID, sessionID string, issuedAt time.Time) *domain.TokenClaims {
+	return &domain.TokenClaims{
+		UserID:    userID,
+		SessionID: sessionID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        userID + "-" + sessionID,
+			IssuedAt:  jwt.NewNumericDate(issuedAt),
+			ExpiresAt: jwt.NewNumericDate(issuedAt.Add(15 * time.Minute)),
+		},
+	}
+}
+
+func isDenied(t *testing.T, denylist domain.IAccessTokenDenylist, claims *domain.TokenClaims) bool {
+	t.Helper()
+
+	denied, err := denylist.IsDenied(context.Background(), claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return denied
+}
+
+func TestDenyUserOnlyDeniesTokensIssuedBefore(t *testing.T) {
+	denylist := NewRedisAccessTokenDenylist(cachetest.NewMemoryCache(), 0)
+
+	before := accessClaims("user-1", "session-1", time.Now())
+	time.Sleep(5 * time.Millisecond)
+	if err := denylist.DenyUser(context.Background(), "user-1", 15*time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	// Issued within the same second as the revocation, e.g. by signing in
+	// again right after a password reset.
+	after := accessClaims("user-1", "session-2", time.Now())
+
+	if !isDenied(t, denylist, before) {
+		t.Error("token issued before the revocation accepted")
+	}
+	if isDenied(t, denylist, after) {
+		t.Error("token issued after the revocation denied")
+	}
+	if isDenied(t, denylist, accessClaims("user-2", "session-3", before.IssuedAt.Time)) {
+		t.Error("token of another user denied")
+	}
+}
+
+func TestDenylistOutlivesTheClockSkew(t *testing.T) {
+	cache := cachetest.NewMemoryCache()
+	denylist := NewRedisAccessTokenDenylist(cache, time.Minute)
+	ctx := context.Background()
+	claims := accessClaims("user-1", "session-1", time.Now())
+
+	if err := denylist.Deny(ctx, claims); err != nil {
+		t.Fatal(err)
+	}
+	if err := denylist.DenySession(ctx, "session-1", 15*time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if err := denylist.DenyUser(ctx, "user-1", 15*time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{deniedTokenKey(claims.ID), deniedSessionKey("session-1"), deniedUserKey("user-1")} {
+		ttl, err := cache.TTL(ctx, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ttl <= 15*time.Minute {
+			t.Errorf("%s expires in %s, want past the token expiry plus the skew", key, ttl)
+		}
+	}
+}
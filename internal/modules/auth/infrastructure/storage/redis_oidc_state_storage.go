@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	redis_component "github.com/dukk308/beetool.dev-go-starter/pkgs/components/cache_comp"
+)
+
+const oidcAuthRequestKeyPattern = "auth:oidc:state:%s"
+
+type RedisOIDCStateStorage struct {
+	cache redis_component.ICacheService
+}
+
+func NewRedisOIDCStateStorage(cache redis_component.ICacheService) domain.IOIDCStateStorage {
+	return &RedisOIDCStateStorage{
+		cache: cache,
+	}
+}
+
+func oidcAuthRequestKey(state string) string {
+	return fmt.Sprintf(oidcAuthRequestKeyPattern, state)
+}
+
+func (s *RedisOIDCStateStorage) StoreAuthRequest(ctx context.Context, request *domain.OIDCAuthRequest) error {
+	return s.cache.SetEx(ctx, oidcAuthRequestKey(request.State), request, request.TTL())
+}
+
+func (s *RedisOIDCStateStorage) ConsumeAuthRequest(ctx context.Context, state string) (*domain.OIDCAuthRequest, error) {
+	raw, err := s.cache.GetDel(ctx, oidcAuthRequestKey(state))
+	if err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		return nil, domain.ErrInvalidOIDCState
+	}
+
+	var request domain.OIDCAuthRequest
+	if err := json.Unmarshal([]byte(*raw), &request); err != nil {
+		return nil, err
+	}
+
+	return &request, nil
+}
@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/cache_comp/cachetest"
+)
+
+var testLockoutPolicy = domain.LockoutPolicy{
+	MaxAttempts:      3,
+	MaxAttemptsPerIP: 5,
+	BaseLockout:      time.Minute,
+	MaxLockout:       4 * time.Minute,
+	FailureWindow:    time.Hour,
+}
+
+func recordFailures(t *testing.T, throttle domain.ISigninThrottle, email, ip string, n int) []time.Duration {
+	t.Helper()
+
+	lockouts := make([]time.Duration, n)
+	for i := range lockouts {
+		lockout, err := throttle.RecordFailure(context.Background(), email, ip)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lockouts[i] = lockout
+	}
+	return lockouts
+}
+
+func TestSigninThrottleBacksOffExponentially(t *testing.T) {
+	throttle := NewRedisSigninThrottle(cachetest.NewMemoryCache(), testLockoutPolicy)
+
+	got := recordFailures(t, throttle, "jane@example.com", "", 6)
+	want := []time.Duration{0, 0, time.Minute, 2 * time.Minute, 4 * time.Minute, 4 * time.Minute}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("lockouts = %v, want %v", got, want)
+		}
+	}
+
+	lockedFor, err := throttle.LockedFor(context.Background(), "jane@example.com", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lockedFor <= 3*time.Minute || lockedFor > 4*time.Minute {
+		t.Fatalf("locked for %s, want about 4m", lockedFor)
+	}
+}
+
+func TestSigninThrottleLocksTheClientIPAcrossEmails(t *testing.T) {
+	throttle := NewRedisSigninThrottle(cachetest.NewMemoryCache(), testLockoutPolicy)
+	ctx := context.Background()
+
+	emails := []string{"a@example.com", "b@example.com", "c@example.com", "d@example.com", "e@example.com"}
+	var lockout time.Duration
+	for _, email := range emails {
+		lockout = recordFailures(t, throttle, email, "192.0.2.1", 1)[0]
+	}
+	if lockout != time.Minute {
+		t.Fatalf("lockout after %d emails = %s, want 1m", len(emails), lockout)
+	}
+
+	lockedFor, err := throttle.LockedFor(ctx, "new@example.com", "192.0.2.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lockedFor <= 0 {
+		t.Fatal("client IP is not locked for another email")
+	}
+	lockedFor, err = throttle.LockedFor(ctx, "new@example.com", "192.0.2.2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lockedFor > 0 {
+		t.Fatalf("another client IP is locked for %s", lockedFor)
+	}
+}
+
+func TestSigninThrottleResetForgetsTheEmailFailures(t *testing.T) {
+	throttle := NewRedisSigninThrottle(cachetest.NewMemoryCache(), testLockoutPolicy)
+	ctx := context.Background()
+
+	recordFailures(t, throttle, "jane@example.com", "", 3)
+	if err := throttle.Reset(ctx, "jane@example.com"); err != nil {
+		t.Fatal(err)
+	}
+
+	lockedFor, err := throttle.LockedFor(ctx, "jane@example.com", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lockedFor > 0 {
+		t.Fatalf("locked for %s after reset", lockedFor)
+	}
+	// Counting starts over.
+	if lockout := recordFailures(t, throttle, "jane@example.com", "", 1)[0]; lockout != 0 {
+		t.Fatalf("first failure after reset locked for %s", lockout)
+	}
+}
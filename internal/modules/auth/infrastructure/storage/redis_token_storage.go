@@ -0,0 +1,173 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	redis_component "github.com/dukk308/beetool.dev-go-starter/pkgs/components/cache_comp"
+)
+
+const (
+	sessionKeyPattern      = "auth:session:%s:%s"
+	userSessionsKeyPattern = "auth:sessions:%s"
+	usedTokenKeyPattern    = "auth:refresh:used:%s"
+	usedActionKeyPattern   = "auth:action:used:%s"
+	actionFailuresPattern  = "auth:action:failures:%s"
+)
+
+type RedisTokenStorage struct {
+	cache redis_component.ICacheService
+}
+
+func NewRedisTokenStorage(cache redis_component.ICacheService) domain.ITokenStorage {
+	return &RedisTokenStorage{
+		cache: cache,
+	}
+}
+
+func sessionKey(userID, sessionID string) string {
+	return fmt.Sprintf(sessionKeyPattern, userID, sessionID)
+}
+
+func userSessionsKey(userID string) string {
+	return fmt.Sprintf(userSessionsKeyPattern, userID)
+}
+
+func usedTokenKey(tokenID string) string {
+	return fmt.Sprintf(usedTokenKeyPattern, tokenID)
+}
+
+func (s *RedisTokenStorage) StoreSession(ctx context.Context, session *domain.Session) error {
+	ttl := session.TTL()
+	if ttl <= 0 {
+		return domain.ErrExpiredToken
+	}
+
+	if err := s.cache.SetEx(ctx, sessionKey(session.UserID, session.ID), session, ttl); err != nil {
+		return err
+	}
+
+	if _, err := s.cache.SAdd(ctx, userSessionsKey(session.UserID), session.ID); err != nil {
+		return err
+	}
+
+	// The index lives as long as the most recently stored session.
+	return s.cache.Expire(ctx, userSessionsKey(session.UserID), ttl)
+}
+
+func (s *RedisTokenStorage) GetSession(ctx context.Context, userID, sessionID string) (*domain.Session, error) {
+	raw, err := s.cache.Get(ctx, sessionKey(userID, sessionID))
+	if err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		return nil, domain.ErrSessionNotFound
+	}
+
+	var session domain.Session
+	if err := json.Unmarshal([]byte(*raw), &session); err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+func (s *RedisTokenStorage) ListSessions(ctx context.Context, userID string) ([]*domain.Session, error) {
+	sessionIDs, err := s.cache.SMembers(ctx, userSessionsKey(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]*domain.Session, 0, len(sessionIDs))
+	for _, sessionID := range sessionIDs {
+		session, err := s.GetSession(ctx, userID, sessionID)
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			// Expired sessions drop out of the index lazily.
+			if _, err := s.cache.SRem(ctx, userSessionsKey(userID), sessionID); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+
+	return sessions, nil
+}
+
+func (s *RedisTokenStorage) DeleteSession(ctx context.Context, userID, sessionID string) error {
+	if err := s.cache.Delete(ctx, sessionKey(userID, sessionID)); err != nil {
+		return err
+	}
+
+	_, err := s.cache.SRem(ctx, userSessionsKey(userID), sessionID)
+	return err
+}
+
+func (s *RedisTokenStorage) DeleteAllSessions(ctx context.Context, userID string) error {
+	sessionIDs, err := s.cache.SMembers(ctx, userSessionsKey(userID))
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(sessionIDs)+1)
+	for _, sessionID := range sessionIDs {
+		keys = append(keys, sessionKey(userID, sessionID))
+	}
+	keys = append(keys, userSessionsKey(userID))
+
+	return s.cache.Delete(ctx, keys...)
+}
+
+func (s *RedisTokenStorage) ConsumeRefreshToken(ctx context.Context, tokenID string, ttl time.Duration) (bool, error) {
+	return s.consumeOnce(ctx, usedTokenKey(tokenID), ttl)
+}
+
+func (s *RedisTokenStorage) ConsumeActionToken(ctx context.Context, tokenID string, ttl time.Duration) (bool, error) {
+	return s.consumeOnce(ctx, fmt.Sprintf(usedActionKeyPattern, tokenID), ttl)
+}
+
+func (s *RedisTokenStorage) RecordActionTokenFailure(ctx context.Context, tokenID string, ttl time.Duration) (int64, error) {
+	if ttl <= 0 {
+		return 0, domain.ErrExpiredToken
+	}
+
+	key := fmt.Sprintf(actionFailuresPattern, tokenID)
+	failures, err := s.cache.Incr(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+
+	if failures == 1 {
+		if err := s.cache.Expire(ctx, key, ttl); err != nil {
+			return 0, err
+		}
+	}
+
+	return failures, nil
+}
+
+func (s *RedisTokenStorage) consumeOnce(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	if ttl <= 0 {
+		return false, domain.ErrExpiredToken
+	}
+
+	uses, err := s.cache.Incr(ctx, key)
+	if err != nil {
+		return false, err
+	}
+
+	if uses == 1 {
+		if err := s.cache.Expire(ctx, key, ttl); err != nil {
+			return false, err
+		}
+	}
+
+	return uses == 1, nil
+}
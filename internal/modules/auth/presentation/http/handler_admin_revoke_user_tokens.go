@@ -0,0 +1,19 @@
+package http
+
+import (
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gin_comp"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Http) HandlerAdminRevokeUserTokens() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		if err := h.adminRevokeUserTokensCommand.Execute(ctx, c.Param("id")); err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		gin_comp.ResponseSuccess(c, map[string]string{"message": "user tokens revoked successfully"})
+	}
+}
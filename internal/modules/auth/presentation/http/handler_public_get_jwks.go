@@ -0,0 +1,16 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandlerPublicGetJWKS serves the key set as a bare JWKS document, without the
+// usual response envelope, so standard JWT libraries can consume it.
+func (h *Http) HandlerPublicGetJWKS() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Cache-Control", "public, max-age=300")
+		c.JSON(http.StatusOK, h.publicGetJWKSQuery.Execute(c.Request.Context()))
+	}
+}
@@ -0,0 +1,56 @@
+package http
+
+import (
+	"net/http"
+	"path"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gin_comp"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Http) HandlerPublicStartGoogleSignin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authURL, binding, err := h.publicStartGoogleSigninCommand.Execute(c.Request.Context())
+		if err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		setOIDCStateCookie(c, binding, int(domain.OIDCAuthRequestExpiry.Seconds()))
+		c.Redirect(http.StatusFound, authURL)
+	}
+}
+
+func (h *Http) HandlerPublicGoogleSigninCallback() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			dto domain.DTOOIDCCallback
+			ctx = c.Request.Context()
+		)
+
+		if err := c.ShouldBindQuery(&dto); err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+		dto.StateBinding, _ = c.Cookie(domain.OIDCStateCookie)
+		setOIDCStateCookie(c, "", -1)
+
+		result, err := h.publicGoogleSigninCallbackCommand.Execute(ctx, &dto, deviceInfo(c))
+		if err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		gin_comp.ResponseSuccess(c, result)
+	}
+}
+
+// setOIDCStateCookie sets, or with a negative maxAge deletes, the cookie
+// binding a sign-in attempt to the browser. It is sent to the callback, which
+// sits next to the start route, on the provider's redirect (SameSite=Lax).
+func setOIDCStateCookie(c *gin.Context, value string, maxAge int) {
+	secure := c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https"
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(domain.OIDCStateCookie, value, maxAge, path.Dir(c.FullPath()), "", secure, true)
+}
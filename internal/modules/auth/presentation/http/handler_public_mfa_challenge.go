@@ -0,0 +1,51 @@
+package http
+
+import (
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gin_comp"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Http) HandlerPublicEnrollMFAChallenge() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			dto domain.DTOMFAChallenge
+			ctx = c.Request.Context()
+		)
+
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		response, err := h.publicEnrollMFAChallengeCommand.Execute(ctx, &dto)
+		if err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		gin_comp.ResponseSuccess(c, response)
+	}
+}
+
+func (h *Http) HandlerPublicVerifyMFAChallenge() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			dto domain.DTOMFAChallenge
+			ctx = c.Request.Context()
+		)
+
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		response, err := h.publicVerifyMFAChallengeCommand.Execute(ctx, &dto, deviceInfo(c))
+		if err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		gin_comp.ResponseSuccess(c, response)
+	}
+}
@@ -0,0 +1,49 @@
+package http
+
+import (
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gin_comp"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Http) HandlerPublicSendVerificationEmail() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			dto domain.DTOEmail
+			ctx = c.Request.Context()
+		)
+
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		if err := h.publicSendVerificationEmailCommand.Execute(ctx, &dto); err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		gin_comp.ResponseSuccess(c, map[string]string{"message": "if the account exists and is not verified, a verification email has been sent"})
+	}
+}
+
+func (h *Http) HandlerPublicVerifyEmail() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			dto domain.DTOVerifyEmail
+			ctx = c.Request.Context()
+		)
+
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		if err := h.publicVerifyEmailCommand.Execute(ctx, &dto); err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		gin_comp.ResponseSuccess(c, map[string]string{"message": "email verified successfully"})
+	}
+}
@@ -18,7 +18,7 @@ func (h *Http) HandlerRefreshToken() gin.HandlerFunc {
 			return
 		}
 
-		response, err := h.refreshTokenCommand.Execute(ctx, &dto)
+		response, err := h.refreshTokenCommand.Execute(ctx, &dto, deviceInfo(c))
 		if err != nil {
 			gin_comp.ResponseError(c, err)
 			return
@@ -18,7 +18,7 @@ func (h *Http) HandlerSignin() gin.HandlerFunc {
 			return
 		}
 
-		response, err := h.signinCommand.Execute(ctx, &dto)
+		response, err := h.signinCommand.Execute(ctx, &dto, deviceInfo(c))
 		if err != nil {
 			gin_comp.ResponseError(c, err)
 			return
@@ -0,0 +1,120 @@
+package http
+
+import (
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gin_comp"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/types"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Http) HandlerViewerCreateAPIKey() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			dto domain.DTOCreateAPIKey
+			ctx = c.Request.Context()
+		)
+
+		user, ok := types.UserFromContext(ctx)
+		if !ok {
+			gin_comp.ResponseError(c, domain.ErrInvalidToken)
+			return
+		}
+
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		response, err := h.viewerCreateAPIKeyCommand.Execute(ctx, user.GetID(), &dto)
+		if err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		gin_comp.ResponseSuccess(c, response)
+	}
+}
+
+func (h *Http) HandlerViewerListAPIKeys() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		user, ok := types.UserFromContext(ctx)
+		if !ok {
+			gin_comp.ResponseError(c, domain.ErrInvalidToken)
+			return
+		}
+
+		response, err := h.viewerListAPIKeysQuery.Execute(ctx, user.GetID())
+		if err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		gin_comp.ResponseSuccess(c, response)
+	}
+}
+
+func (h *Http) HandlerViewerGetAPIKey() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		user, ok := types.UserFromContext(ctx)
+		if !ok {
+			gin_comp.ResponseError(c, domain.ErrInvalidToken)
+			return
+		}
+
+		response, err := h.viewerGetAPIKeyQuery.Execute(ctx, user.GetID(), c.Param("id"))
+		if err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		gin_comp.ResponseSuccess(c, response)
+	}
+}
+
+func (h *Http) HandlerViewerUpdateAPIKey() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			dto domain.DTOUpdateAPIKey
+			ctx = c.Request.Context()
+		)
+
+		user, ok := types.UserFromContext(ctx)
+		if !ok {
+			gin_comp.ResponseError(c, domain.ErrInvalidToken)
+			return
+		}
+
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		response, err := h.viewerUpdateAPIKeyCommand.Execute(ctx, user.GetID(), c.Param("id"), &dto)
+		if err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		gin_comp.ResponseSuccess(c, response)
+	}
+}
+
+func (h *Http) HandlerViewerDeleteAPIKey() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		user, ok := types.UserFromContext(ctx)
+		if !ok {
+			gin_comp.ResponseError(c, domain.ErrInvalidToken)
+			return
+		}
+
+		if err := h.viewerDeleteAPIKeyCommand.Execute(ctx, user.GetID(), c.Param("id")); err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		gin_comp.ResponseSuccess(c, map[string]string{"message": "api key deleted successfully"})
+	}
+}
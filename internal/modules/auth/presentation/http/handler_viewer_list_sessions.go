@@ -0,0 +1,27 @@
+package http
+
+import (
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gin_comp"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/types"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Http) HandlerViewerListSessions() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		user, ok := types.UserFromContext(ctx)
+		if !ok {
+			gin_comp.ResponseError(c, domain.ErrInvalidToken)
+			return
+		}
+
+		response, err := h.viewerListSessionsQuery.Execute(ctx, user.GetID(), user.GetSessionID())
+		if err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		gin_comp.ResponseSuccess(c, response)
+	}
+}
@@ -0,0 +1,110 @@
+package http
+
+import (
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gin_comp"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/types"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Http) HandlerViewerEnrollTOTP() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		user, ok := types.UserFromContext(ctx)
+		if !ok {
+			gin_comp.ResponseError(c, domain.ErrInvalidToken)
+			return
+		}
+
+		response, err := h.viewerEnrollTOTPCommand.Execute(ctx, user.GetID(), user.GetEmail())
+		if err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		gin_comp.ResponseSuccess(c, response)
+	}
+}
+
+func (h *Http) HandlerViewerActivateTOTP() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			dto domain.DTOMFACode
+			ctx = c.Request.Context()
+		)
+
+		user, ok := types.UserFromContext(ctx)
+		if !ok {
+			gin_comp.ResponseError(c, domain.ErrInvalidToken)
+			return
+		}
+
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		response, err := h.viewerActivateTOTPCommand.Execute(ctx, user.GetID(), &dto)
+		if err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		gin_comp.ResponseSuccess(c, response)
+	}
+}
+
+func (h *Http) HandlerViewerDisableTOTP() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			dto domain.DTOMFACode
+			ctx = c.Request.Context()
+		)
+
+		user, ok := types.UserFromContext(ctx)
+		if !ok {
+			gin_comp.ResponseError(c, domain.ErrInvalidToken)
+			return
+		}
+
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		if err := h.viewerDisableTOTPCommand.Execute(ctx, user.GetID(), user.GetRole(), &dto); err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		gin_comp.ResponseSuccess(c, map[string]string{"message": "two-factor authentication disabled"})
+	}
+}
+
+func (h *Http) HandlerViewerRegenerateRecoveryCodes() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			dto domain.DTOMFACode
+			ctx = c.Request.Context()
+		)
+
+		user, ok := types.UserFromContext(ctx)
+		if !ok {
+			gin_comp.ResponseError(c, domain.ErrInvalidToken)
+			return
+		}
+
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		response, err := h.viewerRegenerateRecoveryCodesCommand.Execute(ctx, user.GetID(), &dto)
+		if err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		gin_comp.ResponseSuccess(c, response)
+	}
+}
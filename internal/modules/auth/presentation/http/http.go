@@ -2,14 +2,41 @@ package http
 
 import (
 	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/application"
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/authz"
+	middleware "github.com/dukk308/beetool.dev-go-starter/pkgs/middlewares/gin"
 	"github.com/gin-gonic/gin"
 )
 
 type Http struct {
-	signupCommand       *application.SignupCommand
-	signinCommand       *application.SigninCommand
-	signoutCommand      *application.SignoutCommand
-	refreshTokenCommand *application.RefreshTokenCommand
+	signupCommand                        *application.SignupCommand
+	signinCommand                        *application.SigninCommand
+	signoutCommand                       *application.SignoutCommand
+	refreshTokenCommand                  *application.RefreshTokenCommand
+	viewerListSessionsQuery              *application.ViewerListSessionsQuery
+	viewerRevokeSessionCommand           *application.ViewerRevokeSessionCommand
+	adminRevokeUserTokensCommand         *application.AdminRevokeUserTokensCommand
+	adminUnlockUserSigninCommand         *application.AdminUnlockUserSigninCommand
+	publicGetJWKSQuery                   *application.PublicGetJWKSQuery
+	publicStartGoogleSigninCommand       *application.PublicStartGoogleSigninCommand
+	publicGoogleSigninCallbackCommand    *application.PublicGoogleSigninCallbackCommand
+	publicSendVerificationEmailCommand   *application.PublicSendVerificationEmailCommand
+	publicVerifyEmailCommand             *application.PublicVerifyEmailCommand
+	publicForgotPasswordCommand          *application.PublicForgotPasswordCommand
+	publicResetPasswordCommand           *application.PublicResetPasswordCommand
+	publicEnrollMFAChallengeCommand      *application.PublicEnrollMFAChallengeCommand
+	publicVerifyMFAChallengeCommand      *application.PublicVerifyMFAChallengeCommand
+	viewerEnrollTOTPCommand              *application.ViewerEnrollTOTPCommand
+	viewerActivateTOTPCommand            *application.ViewerActivateTOTPCommand
+	viewerDisableTOTPCommand             *application.ViewerDisableTOTPCommand
+	viewerRegenerateRecoveryCodesCommand *application.ViewerRegenerateRecoveryCodesCommand
+	viewerCreateAPIKeyCommand            *application.ViewerCreateAPIKeyCommand
+	viewerListAPIKeysQuery               *application.ViewerListAPIKeysQuery
+	viewerGetAPIKeyQuery                 *application.ViewerGetAPIKeyQuery
+	viewerUpdateAPIKeyCommand            *application.ViewerUpdateAPIKeyCommand
+	viewerDeleteAPIKeyCommand            *application.ViewerDeleteAPIKeyCommand
+	authorizer                           authz.IAuthorizer
+	routeGroups                          *middleware.RouteGroups
 }
 
 func NewHttp(
@@ -17,12 +44,70 @@ func NewHttp(
 	signinCommand *application.SigninCommand,
 	signoutCommand *application.SignoutCommand,
 	refreshTokenCommand *application.RefreshTokenCommand,
+	viewerListSessionsQuery *application.ViewerListSessionsQuery,
+	viewerRevokeSessionCommand *application.ViewerRevokeSessionCommand,
+	adminRevokeUserTokensCommand *application.AdminRevokeUserTokensCommand,
+	adminUnlockUserSigninCommand *application.AdminUnlockUserSigninCommand,
+	publicGetJWKSQuery *application.PublicGetJWKSQuery,
+	publicStartGoogleSigninCommand *application.PublicStartGoogleSigninCommand,
+	publicGoogleSigninCallbackCommand *application.PublicGoogleSigninCallbackCommand,
+	publicSendVerificationEmailCommand *application.PublicSendVerificationEmailCommand,
+	publicVerifyEmailCommand *application.PublicVerifyEmailCommand,
+	publicForgotPasswordCommand *application.PublicForgotPasswordCommand,
+	publicResetPasswordCommand *application.PublicResetPasswordCommand,
+	publicEnrollMFAChallengeCommand *application.PublicEnrollMFAChallengeCommand,
+	publicVerifyMFAChallengeCommand *application.PublicVerifyMFAChallengeCommand,
+	viewerEnrollTOTPCommand *application.ViewerEnrollTOTPCommand,
+	viewerActivateTOTPCommand *application.ViewerActivateTOTPCommand,
+	viewerDisableTOTPCommand *application.ViewerDisableTOTPCommand,
+	viewerRegenerateRecoveryCodesCommand *application.ViewerRegenerateRecoveryCodesCommand,
+	viewerCreateAPIKeyCommand *application.ViewerCreateAPIKeyCommand,
+	viewerListAPIKeysQuery *application.ViewerListAPIKeysQuery,
+	viewerGetAPIKeyQuery *application.ViewerGetAPIKeyQuery,
+	viewerUpdateAPIKeyCommand *application.ViewerUpdateAPIKeyCommand,
+	viewerDeleteAPIKeyCommand *application.ViewerDeleteAPIKeyCommand,
+	authorizer authz.IAuthorizer,
+	routeGroups *middleware.RouteGroups,
 ) *Http {
 	return &Http{
-		signupCommand:       signupCommand,
-		signinCommand:       signinCommand,
-		signoutCommand:      signoutCommand,
-		refreshTokenCommand: refreshTokenCommand,
+		signupCommand:                        signupCommand,
+		signinCommand:                        signinCommand,
+		signoutCommand:                       signoutCommand,
+		refreshTokenCommand:                  refreshTokenCommand,
+		viewerListSessionsQuery:              viewerListSessionsQuery,
+		viewerRevokeSessionCommand:           viewerRevokeSessionCommand,
+		adminRevokeUserTokensCommand:         adminRevokeUserTokensCommand,
+		adminUnlockUserSigninCommand:         adminUnlockUserSigninCommand,
+		publicGetJWKSQuery:                   publicGetJWKSQuery,
+		publicStartGoogleSigninCommand:       publicStartGoogleSigninCommand,
+		publicGoogleSigninCallbackCommand:    publicGoogleSigninCallbackCommand,
+		publicSendVerificationEmailCommand:   publicSendVerificationEmailCommand,
+		publicVerifyEmailCommand:             publicVerifyEmailCommand,
+		publicForgotPasswordCommand:          publicForgotPasswordCommand,
+		publicResetPasswordCommand:           publicResetPasswordCommand,
+		publicEnrollMFAChallengeCommand:      publicEnrollMFAChallengeCommand,
+		publicVerifyMFAChallengeCommand:      publicVerifyMFAChallengeCommand,
+		viewerEnrollTOTPCommand:              viewerEnrollTOTPCommand,
+		viewerActivateTOTPCommand:            viewerActivateTOTPCommand,
+		viewerDisableTOTPCommand:             viewerDisableTOTPCommand,
+		viewerRegenerateRecoveryCodesCommand: viewerRegenerateRecoveryCodesCommand,
+		viewerCreateAPIKeyCommand:            viewerCreateAPIKeyCommand,
+		viewerListAPIKeysQuery:               viewerListAPIKeysQuery,
+		viewerGetAPIKeyQuery:                 viewerGetAPIKeyQuery,
+		viewerUpdateAPIKeyCommand:            viewerUpdateAPIKeyCommand,
+		viewerDeleteAPIKeyCommand:            viewerDeleteAPIKeyCommand,
+		authorizer:                           authorizer,
+		routeGroups:                          routeGroups,
+	}
+}
+
+// deviceInfo describes the client of a request. Its IP address is the peer
+// address unless the peer is one of -gin-trusted-proxies, so clients cannot
+// pick the address sign-in throttling counts failures against.
+func deviceInfo(c *gin.Context) *domain.DeviceInfo {
+	return &domain.DeviceInfo{
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
 	}
 }
 
@@ -31,4 +116,53 @@ func (h *Http) RegisterRoutes(router *gin.RouterGroup) {
 	router.POST("/v1/auth/signin", h.HandlerSignin())
 	router.POST("/v1/auth/signout", h.HandlerSignout())
 	router.POST("/v1/auth/refresh", h.HandlerRefreshToken())
+	router.GET("/v1/auth/google/start", h.HandlerPublicStartGoogleSignin())
+	router.GET("/v1/auth/google/callback", h.HandlerPublicGoogleSigninCallback())
+	router.POST("/v1/auth/verify-email", h.HandlerPublicVerifyEmail())
+	router.POST("/v1/auth/verify-email/resend", h.HandlerPublicSendVerificationEmail())
+	router.POST("/v1/auth/password/forgot", h.HandlerPublicForgotPassword())
+	router.POST("/v1/auth/password/reset", h.HandlerPublicResetPassword())
+	router.POST("/v1/auth/mfa/challenge/enroll", h.HandlerPublicEnrollMFAChallenge())
+	router.POST("/v1/auth/mfa/challenge/verify", h.HandlerPublicVerifyMFAChallenge())
+
+	sessionsGroup := h.routeGroups.Authenticated(router, "/v1/auth/sessions")
+	{
+		sessionsGroup.GET("", h.HandlerViewerListSessions())
+		sessionsGroup.DELETE("/:id", h.HandlerViewerRevokeSession())
+	}
+
+	totpGroup := h.routeGroups.Authenticated(router, "/v1/auth/mfa/totp")
+	totpGroup.Use(middleware.DenyAPIKeys())
+	{
+		totpGroup.POST("/enroll", h.HandlerViewerEnrollTOTP())
+		totpGroup.POST("/activate", h.HandlerViewerActivateTOTP())
+		totpGroup.POST("/disable", h.HandlerViewerDisableTOTP())
+		totpGroup.POST("/recovery-codes", h.HandlerViewerRegenerateRecoveryCodes())
+	}
+
+	apiKeysGroup := h.routeGroups.Authenticated(router, "/v1/account/api-keys")
+	apiKeysGroup.Use(middleware.DenyAPIKeys())
+	{
+		apiKeysGroup.POST("", h.HandlerViewerCreateAPIKey())
+		apiKeysGroup.GET("", h.HandlerViewerListAPIKeys())
+		apiKeysGroup.GET("/:id", h.HandlerViewerGetAPIKey())
+		apiKeysGroup.PATCH("/:id", h.HandlerViewerUpdateAPIKey())
+		apiKeysGroup.DELETE("/:id", h.HandlerViewerDeleteAPIKey())
+	}
+
+	adminGroup := h.routeGroups.Authenticated(router, "/admin/v1/auth")
+	{
+		adminGroup.POST("/users/:id/revoke-tokens",
+			middleware.RequirePermission(h.authorizer, domain.PermissionRevokeUserTokens),
+			h.HandlerAdminRevokeUserTokens())
+		adminGroup.POST("/users/:id/unlock",
+			middleware.RequirePermission(h.authorizer, domain.PermissionUnlockUserSignin),
+			h.HandlerAdminUnlockUserSignin())
+	}
+}
+
+// RegisterWellKnownRoutes registers routes that must live at the server root,
+// outside of the API prefix.
+func (h *Http) RegisterWellKnownRoutes(router gin.IRoutes) {
+	router.GET("/.well-known/jwks.json", h.HandlerPublicGetJWKS())
 }
@@ -2,23 +2,40 @@ package application
 
 import (
 	"context"
+	"time"
 
 	"github.com/dukk308/beetool.dev-go-starter/internal/modules/blog/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/authz"
 	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/types"
 )
 
 type CreateBlogCommand struct {
 	repository domain.IBlogRepository
+	authorizer authz.IAuthorizer
 }
 
-func NewCreateBlogCommand(repository domain.IBlogRepository) *CreateBlogCommand {
+func NewCreateBlogCommand(repository domain.IBlogRepository, authorizer authz.IAuthorizer) *CreateBlogCommand {
 	return &CreateBlogCommand{
 		repository: repository,
+		authorizer: authorizer,
 	}
 }
 
 func (c *CreateBlogCommand) Execute(ctx context.Context, dto *domain.DTOCreateBlog) (*domain.DTOBlogResponse, error) {
-	blog := domain.NewBlog(dto.Title, dto.Slug, dto.Content)
+	user, _ := types.UserFromContext(ctx)
+	if err := c.authorizer.Authorize(user, domain.PermissionCreate); err != nil {
+		return nil, err
+	}
+
+	blog := domain.NewBlog(dto.Title, dto.Slug, dto.Content, user.GetID())
+	if dto.Status == domain.BlogStatusPublished {
+		if err := c.authorizer.Authorize(user, domain.PermissionPublish); err != nil {
+			return nil, err
+		}
+		blog.Publish(time.Now())
+	}
+
 	if err := c.repository.Create(ctx, blog); err != nil {
 		return nil, base.ToDomainError(err)
 	}
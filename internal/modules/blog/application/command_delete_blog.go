@@ -4,19 +4,33 @@ import (
 	"context"
 
 	"github.com/dukk308/beetool.dev-go-starter/internal/modules/blog/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/authz"
 	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/types"
 )
 
 type DeleteBlogCommand struct {
 	repository domain.IBlogRepository
+	authorizer authz.IAuthorizer
 }
 
-func NewDeleteBlogCommand(repository domain.IBlogRepository) *DeleteBlogCommand {
+func NewDeleteBlogCommand(repository domain.IBlogRepository, authorizer authz.IAuthorizer) *DeleteBlogCommand {
 	return &DeleteBlogCommand{
 		repository: repository,
+		authorizer: authorizer,
 	}
 }
 
 func (c *DeleteBlogCommand) Execute(ctx context.Context, id string) error {
+	blog, err := c.repository.GetByID(ctx, id)
+	if err != nil {
+		return base.ToDomainError(err)
+	}
+
+	user, _ := types.UserFromContext(ctx)
+	if err := c.authorizer.AuthorizeResource(user, domain.PermissionDelete, blog.AuthorID); err != nil {
+		return err
+	}
+
 	return base.ToDomainError(c.repository.Delete(ctx, id))
 }
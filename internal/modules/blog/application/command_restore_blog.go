@@ -0,0 +1,40 @@
+package application
+
+import (
+	"context"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/blog/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/authz"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/types"
+)
+
+type RestoreBlogCommand struct {
+	repository domain.IBlogRepository
+	authorizer authz.IAuthorizer
+}
+
+func NewRestoreBlogCommand(repository domain.IBlogRepository, authorizer authz.IAuthorizer) *RestoreBlogCommand {
+	return &RestoreBlogCommand{
+		repository: repository,
+		authorizer: authorizer,
+	}
+}
+
+// Execute takes the post out of the trash. It fails with a conflict when
+// another post has taken its slug in the meantime.
+func (c *RestoreBlogCommand) Execute(ctx context.Context, id string) (*domain.DTOBlogResponse, error) {
+	user, _ := types.UserFromContext(ctx)
+	if err := c.authorizer.Authorize(user, domain.PermissionRestore); err != nil {
+		return nil, err
+	}
+
+	if err := c.repository.Restore(ctx, id); err != nil {
+		return nil, base.ToDomainError(err)
+	}
+	blog, err := c.repository.GetByID(ctx, id)
+	if err != nil {
+		return nil, base.ToDomainError(err)
+	}
+	return domain.NewDTOBlogResponse(blog), nil
+}
@@ -2,29 +2,61 @@ package application
 
 import (
 	"context"
+	"time"
 
 	"github.com/dukk308/beetool.dev-go-starter/internal/modules/blog/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/authz"
 	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/types"
 )
 
 type UpdateBlogCommand struct {
 	repository domain.IBlogRepository
+	authorizer authz.IAuthorizer
 }
 
-func NewUpdateBlogCommand(repository domain.IBlogRepository) *UpdateBlogCommand {
+func NewUpdateBlogCommand(repository domain.IBlogRepository, authorizer authz.IAuthorizer) *UpdateBlogCommand {
 	return &UpdateBlogCommand{
 		repository: repository,
+		authorizer: authorizer,
 	}
 }
 
-func (c *UpdateBlogCommand) Execute(ctx context.Context, id string, dto *domain.DTOCreateBlog) (*domain.DTOBlogResponse, error) {
+// Execute updates a post. Without blog:publish, only drafts can be edited and
+// the status cannot change, so editors stay limited to their own drafts.
+// version is the one the editor last read, nil to overwrite any version.
+func (c *UpdateBlogCommand) Execute(ctx context.Context, id string, version *int64, dto *domain.DTOCreateBlog) (*domain.DTOBlogResponse, error) {
 	blog, err := c.repository.GetByID(ctx, id)
 	if err != nil {
 		return nil, base.ToDomainError(err)
 	}
+
+	user, _ := types.UserFromContext(ctx)
+	if err := c.authorizer.AuthorizeResource(user, domain.PermissionUpdate, blog.AuthorID); err != nil {
+		return nil, err
+	}
+	if err := blog.MatchVersion(version); err != nil {
+		return nil, err
+	}
+
+	statusChanged := dto.Status != "" && dto.Status != blog.Status
+	if blog.IsPublished() || statusChanged {
+		if err := c.authorizer.Authorize(user, domain.PermissionPublish); err != nil {
+			return nil, err
+		}
+	}
+
 	blog.Title = dto.Title
 	blog.Slug = dto.Slug
 	blog.Content = dto.Content
+	switch {
+	case !statusChanged:
+	case dto.Status == domain.BlogStatusPublished:
+		blog.Publish(time.Now())
+	default:
+		blog.Unpublish()
+	}
+
 	if err := c.repository.Update(ctx, blog); err != nil {
 		return nil, base.ToDomainError(err)
 	}
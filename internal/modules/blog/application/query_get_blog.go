@@ -4,31 +4,45 @@ import (
 	"context"
 
 	"github.com/dukk308/beetool.dev-go-starter/internal/modules/blog/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/authz"
 	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/types"
 )
 
 type GetBlogQuery struct {
 	repository domain.IBlogRepository
+	authorizer authz.IAuthorizer
 }
 
-func NewGetBlogQuery(repository domain.IBlogRepository) *GetBlogQuery {
+func NewGetBlogQuery(repository domain.IBlogRepository, authorizer authz.IAuthorizer) *GetBlogQuery {
 	return &GetBlogQuery{
 		repository: repository,
+		authorizer: authorizer,
 	}
 }
 
+// ExecuteByID serves the admin routes and includes drafts the user may read.
 func (q *GetBlogQuery) ExecuteByID(ctx context.Context, id string) (*domain.DTOBlogResponse, error) {
 	blog, err := q.repository.GetByID(ctx, id)
 	if err != nil {
 		return nil, base.ToDomainError(err)
 	}
+
+	user, _ := types.UserFromContext(ctx)
+	if err := q.authorizer.AuthorizeResource(user, domain.PermissionRead, blog.AuthorID); err != nil {
+		return nil, err
+	}
 	return domain.NewDTOBlogResponse(blog), nil
 }
 
+// ExecuteBySlug serves the public routes and only finds published posts.
 func (q *GetBlogQuery) ExecuteBySlug(ctx context.Context, slug string) (*domain.DTOBlogResponse, error) {
 	blog, err := q.repository.GetBySlug(ctx, slug)
 	if err != nil {
 		return nil, base.ToDomainError(err)
 	}
+	if !blog.IsPublished() {
+		return nil, domain.ErrBlogNotFound
+	}
 	return domain.NewDTOBlogResponse(blog), nil
 }
@@ -4,31 +4,82 @@ import (
 	"context"
 
 	"github.com/dukk308/beetool.dev-go-starter/internal/modules/blog/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/authz"
 	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/types"
 )
 
 type ListBlogsQuery struct {
 	repository domain.IBlogRepository
+	authorizer authz.IAuthorizer
 }
 
-func NewListBlogsQuery(repository domain.IBlogRepository) *ListBlogsQuery {
+func NewListBlogsQuery(repository domain.IBlogRepository, authorizer authz.IAuthorizer) *ListBlogsQuery {
 	return &ListBlogsQuery{
 		repository: repository,
+		authorizer: authorizer,
 	}
 }
 
+// Execute lists published posts for the public routes.
 func (q *ListBlogsQuery) Execute(ctx context.Context, page, limit int) (*domain.DTOBlogListResponse, error) {
-	if page < 1 {
-		page = 1
+	return q.list(ctx, domain.BlogFilter{Status: domain.BlogStatusPublished}, page, limit)
+}
+
+// ExecuteForAdmin lists every post, or only the user's own posts without
+// blog:read:any.
+func (q *ListBlogsQuery) ExecuteForAdmin(ctx context.Context, page, limit int) (*domain.DTOBlogListResponse, error) {
+	user, _ := types.UserFromContext(ctx)
+	if user == nil {
+		return nil, authz.ErrAuthenticationRequired
 	}
-	if limit < 1 || limit > 100 {
-		limit = 10
+
+	var filter domain.BlogFilter
+	if !q.authorizer.Can(user, domain.PermissionRead+":"+authz.ScopeAny) {
+		if !q.authorizer.Can(user, domain.PermissionRead+":"+authz.ScopeOwn) {
+			return nil, authz.ErrPermissionDenied
+		}
+		filter.AuthorID = user.GetID()
+	}
+	return q.list(ctx, filter, page, limit)
+}
+
+// ExecuteTrash lists deleted posts, most recently deleted first.
+func (q *ListBlogsQuery) ExecuteTrash(ctx context.Context, page, limit int) (*domain.DTOBlogListResponse, error) {
+	user, _ := types.UserFromContext(ctx)
+	if err := q.authorizer.Authorize(user, domain.PermissionRestore); err != nil {
+		return nil, err
+	}
+
+	page, limit = normalizePage(page, limit)
+	blogs, total, err := q.repository.ListDeleted(ctx, (page-1)*limit, limit)
+	if err != nil {
+		return nil, base.ToDomainError(err)
 	}
+	return newDTOBlogListResponse(blogs, total, page, limit), nil
+}
+
+func (q *ListBlogsQuery) list(ctx context.Context, filter domain.BlogFilter, page, limit int) (*domain.DTOBlogListResponse, error) {
+	page, limit = normalizePage(page, limit)
 	offset := (page - 1) * limit
-	blogs, total, err := q.repository.GetPage(ctx, offset, limit)
+	blogs, total, err := q.repository.GetPage(ctx, filter, offset, limit)
 	if err != nil {
 		return nil, base.ToDomainError(err)
 	}
+	return newDTOBlogListResponse(blogs, total, page, limit), nil
+}
+
+func normalizePage(page, limit int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+	return page, limit
+}
+
+func newDTOBlogListResponse(blogs []*domain.Blog, total int64, page, limit int) *domain.DTOBlogListResponse {
 	items := make([]*domain.DTOBlogResponse, len(blogs))
 	for i, b := range blogs {
 		items[i] = domain.NewDTOBlogResponse(b)
@@ -38,5 +89,5 @@ func (q *ListBlogsQuery) Execute(ctx context.Context, page, limit int) (*domain.
 		Total: total,
 		Page:  page,
 		Limit: limit,
-	}, nil
+	}
 }
@@ -2,11 +2,22 @@ package domain
 
 import "context"
 
+// BlogFilter narrows GetPage; empty fields match everything.
+type BlogFilter struct {
+	Status   BlogStatus
+	AuthorID string
+}
+
 type IBlogRepository interface {
 	GetByID(ctx context.Context, id string) (*Blog, error)
 	GetBySlug(ctx context.Context, slug string) (*Blog, error)
-	GetPage(ctx context.Context, offset, limit int) ([]*Blog, int64, error)
+	GetPage(ctx context.Context, filter BlogFilter, offset, limit int) ([]*Blog, int64, error)
 	Create(ctx context.Context, blog *Blog) error
+	// Update saves the blog only if it still has the version it was read at,
+	// and bumps the version.
 	Update(ctx context.Context, blog *Blog) error
+	// Delete soft-deletes the blog; it stays restorable until purged.
 	Delete(ctx context.Context, id string) error
+	ListDeleted(ctx context.Context, offset, limit int) ([]*Blog, int64, error)
+	Restore(ctx context.Context, id string) error
 }
@@ -6,25 +6,40 @@ type DTOCreateBlog struct {
 	Title   string `json:"title" binding:"required"`
 	Slug    string `json:"slug" binding:"required"`
 	Content string `json:"content"`
+	// Status defaults to draft on create and is left unchanged on update when
+	// empty. Publishing needs the blog:publish permission.
+	Status BlogStatus `json:"status" binding:"omitempty,oneof=draft published"`
 }
 
 type DTOBlogResponse struct {
-	ID        string    `json:"id"`
-	Title     string    `json:"title"`
-	Slug      string    `json:"slug"`
-	Content   string    `json:"content"`
-	CreatedAt *time.Time `json:"created_at,omitempty"`
-	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	ID          string     `json:"id"`
+	Version     int64      `json:"version"`
+	Title       string     `json:"title"`
+	Slug        string     `json:"slug"`
+	Content     string     `json:"content"`
+	Status      BlogStatus `json:"status"`
+	AuthorID    string     `json:"author_id,omitempty"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+	DeletedBy   *string    `json:"deleted_by,omitempty"`
 }
 
 func NewDTOBlogResponse(blog *Blog) *DTOBlogResponse {
 	return &DTOBlogResponse{
-		ID:        blog.ID.String(),
-		Title:     blog.Title,
-		Slug:      blog.Slug,
-		Content:   blog.Content,
-		CreatedAt: blog.CreatedAt,
-		UpdatedAt: blog.UpdatedAt,
+		ID:          blog.ID.String(),
+		Version:     blog.Version,
+		Title:       blog.Title,
+		Slug:        blog.Slug,
+		Content:     blog.Content,
+		Status:      blog.Status,
+		AuthorID:    blog.AuthorID,
+		PublishedAt: blog.PublishedAt,
+		CreatedAt:   blog.CreatedAt,
+		UpdatedAt:   blog.UpdatedAt,
+		DeletedAt:   blog.DeletedAt,
+		DeletedBy:   blog.DeletedBy,
 	}
 }
 
@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+// BlogPublished is recorded when a draft goes live.
+type BlogPublished struct {
+	BlogID      string    `json:"blog_id"`
+	AuthorID    string    `json:"author_id"`
+	Slug        string    `json:"slug"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+func (BlogPublished) EventName() string {
+	return "blog.published"
+}
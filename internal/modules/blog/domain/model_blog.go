@@ -1,21 +1,61 @@
 package domain
 
 import (
+	"time"
+
 	common "github.com/dukk308/beetool.dev-go-starter/pkgs/base"
 )
 
+type BlogStatus string
+
+const (
+	BlogStatusDraft     BlogStatus = "draft"
+	BlogStatusPublished BlogStatus = "published"
+)
+
+var ErrBlogNotFound = common.NewNotFoundError("blog not found")
+
 type Blog struct {
 	common.BaseModel
-	Title   string `json:"title"`
-	Slug    string `json:"slug"`
-	Content string `json:"content"`
+	Title       string     `json:"title"`
+	Slug        string     `json:"slug"`
+	Content     string     `json:"content"`
+	Status      BlogStatus `json:"status"`
+	AuthorID    string     `json:"author_id"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
 }
 
-func NewBlog(title, slug, content string) *Blog {
+// NewBlog creates a draft owned by authorID.
+func NewBlog(title, slug, content, authorID string) *Blog {
 	return &Blog{
 		BaseModel: *common.GenerateBaseModel(),
 		Title:     title,
 		Slug:      slug,
 		Content:   content,
+		Status:    BlogStatusDraft,
+		AuthorID:  authorID,
+	}
+}
+
+func (b *Blog) IsPublished() bool {
+	return b.Status == BlogStatusPublished
+}
+
+func (b *Blog) Publish(now time.Time) {
+	if b.IsPublished() {
+		return
 	}
+	b.Status = BlogStatusPublished
+	b.PublishedAt = &now
+	b.RecordEvent(BlogPublished{
+		BlogID:      b.ID.String(),
+		AuthorID:    b.AuthorID,
+		Slug:        b.Slug,
+		PublishedAt: now,
+	})
+}
+
+func (b *Blog) Unpublish() {
+	b.Status = BlogStatusDraft
+	b.PublishedAt = nil
 }
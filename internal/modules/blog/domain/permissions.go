@@ -0,0 +1,14 @@
+package domain
+
+// Permissions checked by the blog module, granted to roles by the authz policy.
+// Read, update and delete are resource permissions, suffixed with ":any" or
+// ":own" in the policy.
+const (
+	PermissionCreate  = "blog:create"
+	PermissionRead    = "blog:read"
+	PermissionUpdate  = "blog:update"
+	PermissionDelete  = "blog:delete"
+	PermissionPublish = "blog:publish"
+	// PermissionRestore lists deleted posts and restores them.
+	PermissionRestore = "blog:restore"
+)
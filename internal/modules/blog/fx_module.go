@@ -5,6 +5,8 @@ import (
 	"github.com/dukk308/beetool.dev-go-starter/internal/modules/blog/domain"
 	"github.com/dukk308/beetool.dev-go-starter/internal/modules/blog/infrastructure/persistence"
 	blog_http "github.com/dukk308/beetool.dev-go-starter/internal/modules/blog/presentation/http"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gorm_comp"
+	middleware "github.com/dukk308/beetool.dev-go-starter/pkgs/middlewares/gin"
 	"go.uber.org/fx"
 )
 
@@ -20,6 +22,7 @@ var Module = fx.Module("blog",
 	fx.Provide(application.NewListBlogsQuery),
 	fx.Provide(application.NewUpdateBlogCommand),
 	fx.Provide(application.NewDeleteBlogCommand),
+	fx.Provide(application.NewRestoreBlogCommand),
 	fx.Provide(
 		func(
 			createBlogCommand *application.CreateBlogCommand,
@@ -27,6 +30,8 @@ var Module = fx.Module("blog",
 			listBlogsQuery *application.ListBlogsQuery,
 			updateBlogCommand *application.UpdateBlogCommand,
 			deleteBlogCommand *application.DeleteBlogCommand,
+			restoreBlogCommand *application.RestoreBlogCommand,
+			routeGroups *middleware.RouteGroups,
 		) *blog_http.Http {
 			return blog_http.NewHttp(
 				createBlogCommand,
@@ -34,7 +39,10 @@ var Module = fx.Module("blog",
 				listBlogsQuery,
 				updateBlogCommand,
 				deleteBlogCommand,
+				restoreBlogCommand,
+				routeGroups,
 			)
 		},
 	),
+	gorm_comp.ProvideTrashPurger(&persistence.SQLBlog{}),
 )
@@ -2,39 +2,63 @@ package persistence
 
 import (
 	"context"
+	"errors"
 
 	"gorm.io/gorm"
 
 	"github.com/dukk308/beetool.dev-go-starter/internal/modules/blog/domain"
+	common "github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gorm_comp"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/outbox_comp"
 )
 
+const blogAggregate = "blog"
+
 type BlogRepository struct {
-	db *gorm.DB
+	db        *gorm.DB
+	txManager gorm_comp.ITxManager
+	outbox    outbox_comp.IOutbox
 }
 
-func NewBlogRepository(db *gorm.DB) domain.IBlogRepository {
+func NewBlogRepository(db *gorm.DB, txManager gorm_comp.ITxManager, outbox outbox_comp.IOutbox) domain.IBlogRepository {
 	return &BlogRepository{
-		db: db,
+		db:        db,
+		txManager: txManager,
+		outbox:    outbox,
 	}
 }
 
+// Create and Update save the blog's recorded events in the same transaction.
 func (r *BlogRepository) Create(ctx context.Context, blog *domain.Blog) error {
 	sqlBlog := &SQLBlog{}
 	sqlBlog.FromDomain(blog)
-	return r.db.WithContext(ctx).Create(sqlBlog).Error
+	return r.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := r.db.WithContext(ctx).Create(sqlBlog).Error; err != nil {
+			return err
+		}
+		return r.outbox.Append(ctx, blogAggregate, blog.ID.String(), blog.PullEvents())
+	})
 }
 
 func (r *BlogRepository) Delete(ctx context.Context, id string) error {
 	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&SQLBlog{}).Error
 }
 
-func (r *BlogRepository) GetPage(ctx context.Context, offset, limit int) ([]*domain.Blog, int64, error) {
+func (r *BlogRepository) GetPage(ctx context.Context, filter domain.BlogFilter, offset, limit int) ([]*domain.Blog, int64, error) {
+	query := r.db.WithContext(ctx).Model(&SQLBlog{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", string(filter.Status))
+	}
+	if filter.AuthorID != "" {
+		query = query.Where("author_id = ?", filter.AuthorID)
+	}
+
 	var total int64
-	if err := r.db.WithContext(ctx).Model(&SQLBlog{}).Count(&total).Error; err != nil {
+	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 	var sqlBlogs []SQLBlog
-	if err := r.db.WithContext(ctx).Offset(offset).Limit(limit).Order("created_at DESC").Find(&sqlBlogs).Error; err != nil {
+	if err := query.Offset(offset).Limit(limit).Order("created_at DESC").Find(&sqlBlogs).Error; err != nil {
 		return nil, 0, err
 	}
 	blogs := make([]*domain.Blog, len(sqlBlogs))
@@ -44,9 +68,41 @@ func (r *BlogRepository) GetPage(ctx context.Context, offset, limit int) ([]*dom
 	return blogs, total, nil
 }
 
+func (r *BlogRepository) ListDeleted(ctx context.Context, offset, limit int) ([]*domain.Blog, int64, error) {
+	query := gorm_comp.Trashed(r.db.WithContext(ctx).Model(&SQLBlog{}))
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var sqlBlogs []SQLBlog
+	if err := query.Offset(offset).Limit(limit).Order("deleted_at DESC").Find(&sqlBlogs).Error; err != nil {
+		return nil, 0, err
+	}
+	blogs := make([]*domain.Blog, len(sqlBlogs))
+	for i, b := range sqlBlogs {
+		blogs[i] = b.ToDomain()
+	}
+	return blogs, total, nil
+}
+
+func (r *BlogRepository) Restore(ctx context.Context, id string) error {
+	restored, err := gorm_comp.Restore(ctx, r.db, &SQLBlog{}, id)
+	if err != nil {
+		return err
+	}
+	if !restored {
+		return domain.ErrBlogNotFound
+	}
+	return nil
+}
+
 func (r *BlogRepository) GetBySlug(ctx context.Context, slug string) (*domain.Blog, error) {
 	var sqlBlog SQLBlog
 	if err := r.db.WithContext(ctx).Where("slug = ?", slug).First(&sqlBlog).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrBlogNotFound
+		}
 		return nil, err
 	}
 	return sqlBlog.ToDomain(), nil
@@ -55,6 +111,9 @@ func (r *BlogRepository) GetBySlug(ctx context.Context, slug string) (*domain.Bl
 func (r *BlogRepository) GetByID(ctx context.Context, id string) (*domain.Blog, error) {
 	var sqlBlog SQLBlog
 	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&sqlBlog).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrBlogNotFound
+		}
 		return nil, err
 	}
 	return sqlBlog.ToDomain(), nil
@@ -63,5 +122,18 @@ func (r *BlogRepository) GetByID(ctx context.Context, id string) (*domain.Blog,
 func (r *BlogRepository) Update(ctx context.Context, blog *domain.Blog) error {
 	sqlBlog := &SQLBlog{}
 	sqlBlog.FromDomain(blog)
-	return r.db.WithContext(ctx).Save(sqlBlog).Error
+	err := r.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := gorm_comp.UpdateVersioned(ctx, r.db, sqlBlog); err != nil {
+			return err
+		}
+		return r.outbox.Append(ctx, blogAggregate, blog.ID.String(), blog.PullEvents())
+	})
+	if err != nil {
+		if errors.Is(err, gorm_comp.ErrStaleVersion) {
+			return common.ErrConcurrentUpdate
+		}
+		return err
+	}
+	blog.Version = sqlBlog.Version
+	return nil
 }
@@ -1,6 +1,8 @@
 package persistence
 
 import (
+	"time"
+
 	"github.com/dukk308/beetool.dev-go-starter/internal/modules/blog/domain"
 	common "github.com/dukk308/beetool.dev-go-starter/pkgs/base"
 	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gorm_comp"
@@ -9,35 +11,59 @@ import (
 
 type SQLBlog struct {
 	gorm_comp.SQLModel
-	Title   string `gorm:"column:title;type:varchar(255);not null"`
-	Slug    string `gorm:"column:slug;type:varchar(255);uniqueIndex:uni_blogs_slug;not null"`
-	Content string `gorm:"column:content;type:text"`
+	Title       string     `gorm:"column:title;type:varchar(255);not null"`
+	Slug        string     `gorm:"column:slug;type:varchar(255);uniqueIndex:uni_blogs_slug,where:deleted_at IS NULL;not null"`
+	Content     string     `gorm:"column:content;type:text"`
+	Status      string     `gorm:"column:status;type:varchar(20);index:idx_blogs_status;not null;default:draft"`
+	AuthorID    *string    `gorm:"column:author_id;type:text;index:idx_blogs_author_id"`
+	PublishedAt *time.Time `gorm:"column:published_at;type:timestamp without time zone"`
 }
 
 func (b *SQLBlog) TableName() string {
 	return "blogs"
 }
 
+func (b *SQLBlog) AuditEntity() string {
+	return "blog"
+}
+
 func (b *SQLBlog) ToDomain() *domain.Blog {
+	var authorID string
+	if b.AuthorID != nil {
+		authorID = *b.AuthorID
+	}
+
 	return &domain.Blog{
 		BaseModel: common.BaseModel{
 			ID:        uuid.MustParse(b.ID),
+			Version:   b.Version,
 			CreatedAt: b.CreatedAt,
 			UpdatedAt: b.UpdatedAt,
-			DeletedAt: b.DeletedAt,
+			DeletedAt: gorm_comp.DeletedAtTime(b.DeletedAt),
+			DeletedBy: b.DeletedBy,
 		},
-		Title:   b.Title,
-		Slug:    b.Slug,
-		Content: b.Content,
+		Title:       b.Title,
+		Slug:        b.Slug,
+		Content:     b.Content,
+		Status:      domain.BlogStatus(b.Status),
+		AuthorID:    authorID,
+		PublishedAt: b.PublishedAt,
 	}
 }
 
 func (b *SQLBlog) FromDomain(blog *domain.Blog) {
 	b.ID = blog.ID.String()
+	b.Version = blog.Version
 	b.CreatedAt = blog.CreatedAt
 	b.UpdatedAt = blog.UpdatedAt
-	b.DeletedAt = blog.DeletedAt
+	b.DeletedAt = gorm_comp.ToDeletedAt(blog.DeletedAt)
 	b.Title = blog.Title
 	b.Slug = blog.Slug
 	b.Content = blog.Content
+	b.Status = string(blog.Status)
+	b.AuthorID = nil
+	if blog.AuthorID != "" {
+		b.AuthorID = &blog.AuthorID
+	}
+	b.PublishedAt = blog.PublishedAt
 }
@@ -20,3 +20,31 @@ func (h *Http) HandlerListBlogs() gin.HandlerFunc {
 		gin_comp.ResponseSuccess(c, response)
 	}
 }
+
+func (h *Http) HandlerAdminListBlogs() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+		limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+		ctx := c.Request.Context()
+		response, err := h.listBlogsQuery.ExecuteForAdmin(ctx, page, limit)
+		if err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+		gin_comp.ResponseSuccess(c, response)
+	}
+}
+
+func (h *Http) HandlerListDeletedBlogs() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+		limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+		ctx := c.Request.Context()
+		response, err := h.listBlogsQuery.ExecuteTrash(ctx, page, limit)
+		if err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+		gin_comp.ResponseSuccess(c, response)
+	}
+}
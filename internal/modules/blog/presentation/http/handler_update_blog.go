@@ -9,17 +9,23 @@ import (
 func (h *Http) HandlerUpdateBlog() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		id := c.Param("id")
+		version, err := gin_comp.IfMatchVersion(c)
+		if err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
 		var dto domain.DTOCreateBlog
 		if err := c.ShouldBindJSON(&dto); err != nil {
 			gin_comp.ResponseError(c, err)
 			return
 		}
 		ctx := c.Request.Context()
-		response, err := h.updateBlogCommand.Execute(ctx, id, &dto)
+		response, err := h.updateBlogCommand.Execute(ctx, id, version, &dto)
 		if err != nil {
 			gin_comp.ResponseError(c, err)
 			return
 		}
+		gin_comp.SetETag(c, response.Version)
 		gin_comp.ResponseSuccess(c, response)
 	}
 }
@@ -2,15 +2,18 @@ package http
 
 import (
 	"github.com/dukk308/beetool.dev-go-starter/internal/modules/blog/application"
+	middleware "github.com/dukk308/beetool.dev-go-starter/pkgs/middlewares/gin"
 	"github.com/gin-gonic/gin"
 )
 
 type Http struct {
-	createBlogCommand *application.CreateBlogCommand
-	getBlogQuery      *application.GetBlogQuery
-	listBlogsQuery    *application.ListBlogsQuery
-	updateBlogCommand *application.UpdateBlogCommand
-	deleteBlogCommand *application.DeleteBlogCommand
+	createBlogCommand  *application.CreateBlogCommand
+	getBlogQuery       *application.GetBlogQuery
+	listBlogsQuery     *application.ListBlogsQuery
+	updateBlogCommand  *application.UpdateBlogCommand
+	deleteBlogCommand  *application.DeleteBlogCommand
+	restoreBlogCommand *application.RestoreBlogCommand
+	routeGroups        *middleware.RouteGroups
 }
 
 func NewHttp(
@@ -19,28 +22,36 @@ func NewHttp(
 	listBlogsQuery *application.ListBlogsQuery,
 	updateBlogCommand *application.UpdateBlogCommand,
 	deleteBlogCommand *application.DeleteBlogCommand,
+	restoreBlogCommand *application.RestoreBlogCommand,
+	routeGroups *middleware.RouteGroups,
 ) *Http {
 	return &Http{
-		createBlogCommand: createBlogCommand,
-		getBlogQuery:      getBlogQuery,
-		listBlogsQuery:    listBlogsQuery,
-		updateBlogCommand: updateBlogCommand,
-		deleteBlogCommand: deleteBlogCommand,
+		createBlogCommand:  createBlogCommand,
+		getBlogQuery:       getBlogQuery,
+		listBlogsQuery:     listBlogsQuery,
+		updateBlogCommand:  updateBlogCommand,
+		deleteBlogCommand:  deleteBlogCommand,
+		restoreBlogCommand: restoreBlogCommand,
+		routeGroups:        routeGroups,
 	}
 }
 
 func (h *Http) RegisterRoutes(router *gin.RouterGroup) {
-	public := router.Group("/public/v1/blogs")
+	public := h.routeGroups.Public(router, "/public/v1/blogs")
 	{
 		public.GET("", h.HandlerListBlogs())
 		public.GET("/:slug", h.HandlerGetBlogBySlug())
 	}
-	admin := router.Group("/admin/v1/blogs")
+	// Only admins and editors reach these routes; ownership, publishing and
+	// deletion are still checked by the commands against the authz policy.
+	admin := h.routeGroups.Admin(router, "/admin/v1/blogs", "admin", "editor")
 	{
 		admin.POST("", h.HandlerCreateBlog())
-		admin.GET("", h.HandlerListBlogs())
+		admin.GET("", h.HandlerAdminListBlogs())
+		admin.GET("/trash", h.HandlerListDeletedBlogs())
 		admin.GET("/:id", h.HandlerGetBlogByID())
 		admin.PUT("/:id", h.HandlerUpdateBlog())
 		admin.DELETE("/:id", h.HandlerDeleteBlog())
+		admin.POST("/:id/restore", h.HandlerRestoreBlog())
 	}
 }
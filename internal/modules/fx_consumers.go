@@ -0,0 +1,9 @@
+package modules
+
+import "go.uber.org/fx"
+
+// ConsumerModuleFx holds the message consumers the worker runs. Feature
+// modules register them with worker_comp.ProvideConsumer.
+var ConsumerModuleFx = fx.Module(
+	"consumer_modules",
+)
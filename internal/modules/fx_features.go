@@ -3,6 +3,8 @@ package modules
 import (
 	"context"
 
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/audit"
+	audit_http "github.com/dukk308/beetool.dev-go-starter/internal/modules/audit/presentation/http"
 	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth"
 	auth_http "github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/presentation/http"
 	"github.com/dukk308/beetool.dev-go-starter/internal/modules/blog"
@@ -22,13 +24,16 @@ func SetupRoutes(
 	authHTTP *auth_http.Http,
 	noteHTTP *note_http.Http,
 	blogHTTP *blog_http.Http,
+	auditHTTP *audit_http.Http,
 ) {
 	lc.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
 			userHTTP.RegisterRoutes(ginComponent.GetGroup())
 			authHTTP.RegisterRoutes(ginComponent.GetGroup())
+			authHTTP.RegisterWellKnownRoutes(ginComponent.GetRouter())
 			noteHTTP.RegisterRoutes(ginComponent.GetGroup())
 			blogHTTP.RegisterRoutes(ginComponent.GetGroup())
+			auditHTTP.RegisterRoutes(ginComponent.GetGroup())
 			return nil
 		},
 	})
@@ -40,5 +45,6 @@ var FeatureModuleFx = fx.Module(
 	auth.Module,
 	note.Module,
 	blog.Module,
+	audit.Module,
 	fx.Invoke(SetupRoutes),
 )
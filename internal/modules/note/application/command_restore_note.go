@@ -0,0 +1,29 @@
+package application
+
+import (
+	"context"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/note/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+)
+
+type RestoreNoteCommand struct {
+	repository domain.INoteRepository
+}
+
+func NewRestoreNoteCommand(repository domain.INoteRepository) *RestoreNoteCommand {
+	return &RestoreNoteCommand{
+		repository: repository,
+	}
+}
+
+func (c *RestoreNoteCommand) Execute(ctx context.Context, id string) (*domain.DTONoteResponse, error) {
+	if err := c.repository.Restore(ctx, id); err != nil {
+		return nil, base.ToDomainError(err)
+	}
+	note, err := c.repository.GetByID(ctx, id)
+	if err != nil {
+		return nil, base.ToDomainError(err)
+	}
+	return domain.NewDTONoteResponse(note), nil
+}
@@ -17,11 +17,16 @@ func NewUpdateNoteCommand(repository domain.INoteRepository) *UpdateNoteCommand
 	}
 }
 
-func (c *UpdateNoteCommand) Execute(ctx context.Context, id string, dto *domain.DTOCreateNote) (*domain.DTONoteResponse, error) {
+// Execute updates a note. version is the one the editor last read, nil to
+// overwrite any version.
+func (c *UpdateNoteCommand) Execute(ctx context.Context, id string, version *int64, dto *domain.DTOCreateNote) (*domain.DTONoteResponse, error) {
 	note, err := c.repository.GetByID(ctx, id)
 	if err != nil {
 		return nil, base.ToDomainError(err)
 	}
+	if err := note.MatchVersion(version); err != nil {
+		return nil, err
+	}
 	note.Title = dto.Title
 	note.Slug = dto.Slug
 	note.Content = dto.Content
@@ -0,0 +1,30 @@
+package application
+
+import (
+	"context"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/note/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+)
+
+type ListDeletedNotesQuery struct {
+	repository domain.INoteRepository
+}
+
+func NewListDeletedNotesQuery(repository domain.INoteRepository) *ListDeletedNotesQuery {
+	return &ListDeletedNotesQuery{
+		repository: repository,
+	}
+}
+
+func (q *ListDeletedNotesQuery) Execute(ctx context.Context) ([]*domain.DTONoteResponse, error) {
+	notes, err := q.repository.GetAllDeleted(ctx)
+	if err != nil {
+		return nil, base.ToDomainError(err)
+	}
+	result := make([]*domain.DTONoteResponse, len(notes))
+	for i, n := range notes {
+		result[i] = domain.NewDTONoteResponse(n)
+	}
+	return result, nil
+}
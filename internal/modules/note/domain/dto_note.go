@@ -9,21 +9,27 @@ type DTOCreateNote struct {
 }
 
 type DTONoteResponse struct {
-	ID        string    `json:"id"`
-	Title     string    `json:"title"`
-	Slug      string    `json:"slug"`
-	Content   string    `json:"content"`
+	ID        string     `json:"id"`
+	Version   int64      `json:"version"`
+	Title     string     `json:"title"`
+	Slug      string     `json:"slug"`
+	Content   string     `json:"content"`
 	CreatedAt *time.Time `json:"created_at,omitempty"`
 	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	DeletedBy *string    `json:"deleted_by,omitempty"`
 }
 
 func NewDTONoteResponse(note *Note) *DTONoteResponse {
 	return &DTONoteResponse{
 		ID:        note.ID.String(),
+		Version:   note.Version,
 		Title:     note.Title,
 		Slug:      note.Slug,
 		Content:   note.Content,
 		CreatedAt: note.CreatedAt,
 		UpdatedAt: note.UpdatedAt,
+		DeletedAt: note.DeletedAt,
+		DeletedBy: note.DeletedBy,
 	}
 }
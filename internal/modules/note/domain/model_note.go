@@ -4,6 +4,8 @@ import (
 	common "github.com/dukk308/beetool.dev-go-starter/pkgs/base"
 )
 
+var ErrNoteNotFound = common.NewNotFoundError("note not found")
+
 type Note struct {
 	common.BaseModel
 	Title   string `json:"title"`
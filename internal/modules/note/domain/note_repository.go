@@ -7,6 +7,11 @@ type INoteRepository interface {
 	GetBySlug(ctx context.Context, slug string) (*Note, error)
 	GetAll(ctx context.Context) ([]*Note, error)
 	Create(ctx context.Context, note *Note) error
+	// Update saves the note only if it still has the version it was read at,
+	// and bumps the version.
 	Update(ctx context.Context, note *Note) error
+	// Delete soft-deletes the note; it stays restorable until purged.
 	Delete(ctx context.Context, id string) error
+	GetAllDeleted(ctx context.Context) ([]*Note, error)
+	Restore(ctx context.Context, id string) error
 }
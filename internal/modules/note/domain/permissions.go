@@ -0,0 +1,7 @@
+package domain
+
+// Permissions checked by the note module, granted to roles by the authz policy.
+const (
+	// PermissionRestore lists deleted notes and restores them.
+	PermissionRestore = "note:restore"
+)
@@ -5,6 +5,9 @@ import (
 	"github.com/dukk308/beetool.dev-go-starter/internal/modules/note/domain"
 	"github.com/dukk308/beetool.dev-go-starter/internal/modules/note/infrastructure/persistence"
 	note_http "github.com/dukk308/beetool.dev-go-starter/internal/modules/note/presentation/http"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/authz"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gorm_comp"
+	middleware "github.com/dukk308/beetool.dev-go-starter/pkgs/middlewares/gin"
 	"go.uber.org/fx"
 )
 
@@ -20,6 +23,8 @@ var Module = fx.Module("note",
 	fx.Provide(application.NewListNotesQuery),
 	fx.Provide(application.NewUpdateNoteCommand),
 	fx.Provide(application.NewDeleteNoteCommand),
+	fx.Provide(application.NewListDeletedNotesQuery),
+	fx.Provide(application.NewRestoreNoteCommand),
 	fx.Provide(
 		func(
 			createNoteCommand *application.CreateNoteCommand,
@@ -27,6 +32,10 @@ var Module = fx.Module("note",
 			listNotesQuery *application.ListNotesQuery,
 			updateNoteCommand *application.UpdateNoteCommand,
 			deleteNoteCommand *application.DeleteNoteCommand,
+			listDeletedNotesQuery *application.ListDeletedNotesQuery,
+			restoreNoteCommand *application.RestoreNoteCommand,
+			authorizer authz.IAuthorizer,
+			routeGroups *middleware.RouteGroups,
 		) *note_http.Http {
 			return note_http.NewHttp(
 				createNoteCommand,
@@ -34,7 +43,12 @@ var Module = fx.Module("note",
 				listNotesQuery,
 				updateNoteCommand,
 				deleteNoteCommand,
+				listDeletedNotesQuery,
+				restoreNoteCommand,
+				authorizer,
+				routeGroups,
 			)
 		},
 	),
+	gorm_comp.ProvideTrashPurger(&persistence.SQLNote{}),
 )
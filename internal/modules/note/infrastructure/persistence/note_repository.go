@@ -2,10 +2,13 @@ package persistence
 
 import (
 	"context"
+	"errors"
 
 	"gorm.io/gorm"
 
 	"github.com/dukk308/beetool.dev-go-starter/internal/modules/note/domain"
+	common "github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gorm_comp"
 )
 
 type NoteRepository struct {
@@ -40,6 +43,29 @@ func (r *NoteRepository) GetAll(ctx context.Context) ([]*domain.Note, error) {
 	return notes, nil
 }
 
+func (r *NoteRepository) GetAllDeleted(ctx context.Context) ([]*domain.Note, error) {
+	var sqlNotes []SQLNote
+	if err := gorm_comp.Trashed(r.db.WithContext(ctx)).Order("deleted_at DESC").Find(&sqlNotes).Error; err != nil {
+		return nil, err
+	}
+	notes := make([]*domain.Note, len(sqlNotes))
+	for i, n := range sqlNotes {
+		notes[i] = n.ToDomain()
+	}
+	return notes, nil
+}
+
+func (r *NoteRepository) Restore(ctx context.Context, id string) error {
+	restored, err := gorm_comp.Restore(ctx, r.db, &SQLNote{}, id)
+	if err != nil {
+		return err
+	}
+	if !restored {
+		return domain.ErrNoteNotFound
+	}
+	return nil
+}
+
 func (r *NoteRepository) GetBySlug(ctx context.Context, slug string) (*domain.Note, error) {
 	var sqlNote SQLNote
 	if err := r.db.WithContext(ctx).Where("slug = ?", slug).First(&sqlNote).Error; err != nil {
@@ -59,5 +85,12 @@ func (r *NoteRepository) GetByID(ctx context.Context, id string) (*domain.Note,
 func (r *NoteRepository) Update(ctx context.Context, note *domain.Note) error {
 	sqlNote := &SQLNote{}
 	sqlNote.FromDomain(note)
-	return r.db.WithContext(ctx).Save(sqlNote).Error
+	if err := gorm_comp.UpdateVersioned(ctx, r.db, sqlNote); err != nil {
+		if errors.Is(err, gorm_comp.ErrStaleVersion) {
+			return common.ErrConcurrentUpdate
+		}
+		return err
+	}
+	note.Version = sqlNote.Version
+	return nil
 }
@@ -10,7 +10,7 @@ import (
 type SQLNote struct {
 	gorm_comp.SQLModel
 	Title   string `gorm:"column:title;type:varchar(255);not null"`
-	Slug    string `gorm:"column:slug;type:varchar(255);uniqueIndex:uni_notes_slug;not null"`
+	Slug    string `gorm:"column:slug;type:varchar(255);uniqueIndex:uni_notes_slug,where:deleted_at IS NULL;not null"`
 	Content string `gorm:"column:content;type:text"`
 }
 
@@ -18,13 +18,19 @@ func (n *SQLNote) TableName() string {
 	return "notes"
 }
 
+func (n *SQLNote) AuditEntity() string {
+	return "note"
+}
+
 func (n *SQLNote) ToDomain() *domain.Note {
 	return &domain.Note{
 		BaseModel: common.BaseModel{
 			ID:        uuid.MustParse(n.ID),
+			Version:   n.Version,
 			CreatedAt: n.CreatedAt,
 			UpdatedAt: n.UpdatedAt,
-			DeletedAt: n.DeletedAt,
+			DeletedAt: gorm_comp.DeletedAtTime(n.DeletedAt),
+			DeletedBy: n.DeletedBy,
 		},
 		Title:   n.Title,
 		Slug:    n.Slug,
@@ -34,9 +40,10 @@ func (n *SQLNote) ToDomain() *domain.Note {
 
 func (n *SQLNote) FromDomain(note *domain.Note) {
 	n.ID = note.ID.String()
+	n.Version = note.Version
 	n.CreatedAt = note.CreatedAt
 	n.UpdatedAt = note.UpdatedAt
-	n.DeletedAt = note.DeletedAt
+	n.DeletedAt = gorm_comp.ToDeletedAt(note.DeletedAt)
 	n.Title = note.Title
 	n.Slug = note.Slug
 	n.Content = note.Content
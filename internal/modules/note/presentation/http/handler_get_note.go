@@ -14,6 +14,7 @@ func (h *Http) HandlerGetNoteByID() gin.HandlerFunc {
 			gin_comp.ResponseError(c, err)
 			return
 		}
+		gin_comp.SetETag(c, response.Version)
 		gin_comp.ResponseSuccess(c, response)
 	}
 }
@@ -27,6 +28,7 @@ func (h *Http) HandlerGetNoteBySlug() gin.HandlerFunc {
 			gin_comp.ResponseError(c, err)
 			return
 		}
+		gin_comp.SetETag(c, response.Version)
 		gin_comp.ResponseSuccess(c, response)
 	}
 }
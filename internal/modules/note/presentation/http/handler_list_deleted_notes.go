@@ -0,0 +1,18 @@
+package http
+
+import (
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gin_comp"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Http) HandlerListDeletedNotes() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		response, err := h.listDeletedNotesQuery.Execute(ctx)
+		if err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+		gin_comp.ResponseSuccess(c, response)
+	}
+}
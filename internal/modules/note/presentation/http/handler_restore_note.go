@@ -0,0 +1,19 @@
+package http
+
+import (
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gin_comp"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Http) HandlerRestoreNote() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+		ctx := c.Request.Context()
+		response, err := h.restoreNoteCommand.Execute(ctx, id)
+		if err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+		gin_comp.ResponseSuccess(c, response)
+	}
+}
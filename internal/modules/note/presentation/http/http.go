@@ -2,15 +2,22 @@ package http
 
 import (
 	"github.com/dukk308/beetool.dev-go-starter/internal/modules/note/application"
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/note/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/authz"
+	middleware "github.com/dukk308/beetool.dev-go-starter/pkgs/middlewares/gin"
 	"github.com/gin-gonic/gin"
 )
 
 type Http struct {
-	createNoteCommand *application.CreateNoteCommand
-	getNoteQuery      *application.GetNoteQuery
-	listNotesQuery    *application.ListNotesQuery
-	updateNoteCommand *application.UpdateNoteCommand
-	deleteNoteCommand *application.DeleteNoteCommand
+	createNoteCommand     *application.CreateNoteCommand
+	getNoteQuery          *application.GetNoteQuery
+	listNotesQuery        *application.ListNotesQuery
+	updateNoteCommand     *application.UpdateNoteCommand
+	deleteNoteCommand     *application.DeleteNoteCommand
+	listDeletedNotesQuery *application.ListDeletedNotesQuery
+	restoreNoteCommand    *application.RestoreNoteCommand
+	authorizer            authz.IAuthorizer
+	routeGroups           *middleware.RouteGroups
 }
 
 func NewHttp(
@@ -19,13 +26,21 @@ func NewHttp(
 	listNotesQuery *application.ListNotesQuery,
 	updateNoteCommand *application.UpdateNoteCommand,
 	deleteNoteCommand *application.DeleteNoteCommand,
+	listDeletedNotesQuery *application.ListDeletedNotesQuery,
+	restoreNoteCommand *application.RestoreNoteCommand,
+	authorizer authz.IAuthorizer,
+	routeGroups *middleware.RouteGroups,
 ) *Http {
 	return &Http{
-		createNoteCommand: createNoteCommand,
-		getNoteQuery:      getNoteQuery,
-		listNotesQuery:    listNotesQuery,
-		updateNoteCommand: updateNoteCommand,
-		deleteNoteCommand: deleteNoteCommand,
+		createNoteCommand:     createNoteCommand,
+		getNoteQuery:          getNoteQuery,
+		listNotesQuery:        listNotesQuery,
+		updateNoteCommand:     updateNoteCommand,
+		deleteNoteCommand:     deleteNoteCommand,
+		listDeletedNotesQuery: listDeletedNotesQuery,
+		restoreNoteCommand:    restoreNoteCommand,
+		authorizer:            authorizer,
+		routeGroups:           routeGroups,
 	}
 }
 
@@ -39,4 +54,11 @@ func (h *Http) RegisterRoutes(router *gin.RouterGroup) {
 		notesGroup.PUT("/:id", h.HandlerUpdateNote())
 		notesGroup.DELETE("/:id", h.HandlerDeleteNote())
 	}
+
+	adminGroup := h.routeGroups.Authenticated(router, "/admin/v1/notes")
+	adminGroup.Use(middleware.RequirePermission(h.authorizer, domain.PermissionRestore))
+	{
+		adminGroup.GET("/trash", h.HandlerListDeletedNotes())
+		adminGroup.POST("/:id/restore", h.HandlerRestoreNote())
+	}
 }
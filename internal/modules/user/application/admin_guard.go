@@ -0,0 +1,22 @@
+package application
+
+import (
+	"context"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/user/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/authz"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/types"
+)
+
+// ensureNotSelf stops admins from locking themselves out, which could leave
+// the deployment without any admin.
+func ensureNotSelf(ctx context.Context, userID string) error {
+	actor, ok := types.UserFromContext(ctx)
+	if !ok {
+		return authz.ErrAuthenticationRequired
+	}
+	if actor.GetID() == userID {
+		return domain.ErrCannotModifySelf
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package application
+
+import (
+	"context"
+	"strings"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/user/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/authz"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+)
+
+type AdminChangeRoleCommand struct {
+	repository domain.IViewerRepository
+	policy     *authz.Policy
+	revoker    domain.ISessionRevoker
+}
+
+func NewAdminChangeRoleCommand(
+	repository domain.IViewerRepository,
+	policy *authz.Policy,
+	revoker domain.ISessionRevoker,
+) *AdminChangeRoleCommand {
+	return &AdminChangeRoleCommand{
+		repository: repository,
+		policy:     policy,
+		revoker:    revoker,
+	}
+}
+
+// Execute assigns one of the roles defined by the authz policy. Tokens carry
+// the role, so the user's sessions are revoked and the new role applies on
+// their next sign-in.
+func (c *AdminChangeRoleCommand) Execute(ctx context.Context, userID string, dto *domain.DTOChangeRole) (*domain.DTOAdminUserResponse, error) {
+	if err := ensureNotSelf(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	role := domain.Role(strings.TrimSpace(dto.Role))
+	if !c.policy.HasRole(role.String()) {
+		return nil, domain.ErrInvalidRole
+	}
+
+	viewer, err := c.repository.GetByID(ctx, userID)
+	if err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	if viewer.Role == role {
+		return domain.NewDTOAdminUserResponse(viewer), nil
+	}
+
+	if err := viewer.ChangeRole(role); err != nil {
+		return nil, err
+	}
+
+	if err := c.repository.Update(ctx, viewer); err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	if err := c.revoker.RevokeUserSessions(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return domain.NewDTOAdminUserResponse(viewer), nil
+}
@@ -0,0 +1,33 @@
+package application
+
+import (
+	"context"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/user/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+)
+
+type AdminDeleteUserCommand struct {
+	repository domain.IViewerRepository
+	revoker    domain.ISessionRevoker
+}
+
+func NewAdminDeleteUserCommand(repository domain.IViewerRepository, revoker domain.ISessionRevoker) *AdminDeleteUserCommand {
+	return &AdminDeleteUserCommand{
+		repository: repository,
+		revoker:    revoker,
+	}
+}
+
+// Execute soft-deletes the user and signs them out everywhere.
+func (c *AdminDeleteUserCommand) Execute(ctx context.Context, userID string) error {
+	if err := ensureNotSelf(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := c.repository.Delete(ctx, userID); err != nil {
+		return base.ToDomainError(err)
+	}
+
+	return c.revoker.RevokeUserSessions(ctx, userID)
+}
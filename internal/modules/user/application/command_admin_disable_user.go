@@ -0,0 +1,47 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/user/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+)
+
+type AdminDisableUserCommand struct {
+	repository domain.IViewerRepository
+	revoker    domain.ISessionRevoker
+}
+
+func NewAdminDisableUserCommand(repository domain.IViewerRepository, revoker domain.ISessionRevoker) *AdminDisableUserCommand {
+	return &AdminDisableUserCommand{
+		repository: repository,
+		revoker:    revoker,
+	}
+}
+
+// Execute disables the account and signs the user out everywhere. API keys
+// stop working while the account is disabled.
+func (c *AdminDisableUserCommand) Execute(ctx context.Context, userID string) (*domain.DTOAdminUserResponse, error) {
+	if err := ensureNotSelf(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	viewer, err := c.repository.GetByID(ctx, userID)
+	if err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	if !viewer.IsDisabled() {
+		viewer.Disable(time.Now())
+		if err := c.repository.Update(ctx, viewer); err != nil {
+			return nil, base.ToDomainError(err)
+		}
+	}
+
+	if err := c.revoker.RevokeUserSessions(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return domain.NewDTOAdminUserResponse(viewer), nil
+}
@@ -0,0 +1,34 @@
+package application
+
+import (
+	"context"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/user/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+)
+
+type AdminEnableUserCommand struct {
+	repository domain.IViewerRepository
+}
+
+func NewAdminEnableUserCommand(repository domain.IViewerRepository) *AdminEnableUserCommand {
+	return &AdminEnableUserCommand{
+		repository: repository,
+	}
+}
+
+func (c *AdminEnableUserCommand) Execute(ctx context.Context, userID string) (*domain.DTOAdminUserResponse, error) {
+	viewer, err := c.repository.GetByID(ctx, userID)
+	if err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	if viewer.IsDisabled() {
+		viewer.Enable()
+		if err := c.repository.Update(ctx, viewer); err != nil {
+			return nil, base.ToDomainError(err)
+		}
+	}
+
+	return domain.NewDTOAdminUserResponse(viewer), nil
+}
@@ -0,0 +1,33 @@
+package application
+
+import (
+	"context"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/user/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+)
+
+type AdminRestoreUserCommand struct {
+	repository domain.IViewerRepository
+}
+
+func NewAdminRestoreUserCommand(repository domain.IViewerRepository) *AdminRestoreUserCommand {
+	return &AdminRestoreUserCommand{
+		repository: repository,
+	}
+}
+
+// Execute takes the user out of the trash. Accounts their owner deleted come
+// back anonymized; the personal data is not recoverable.
+func (c *AdminRestoreUserCommand) Execute(ctx context.Context, userID string) (*domain.DTOAdminUserResponse, error) {
+	if err := c.repository.Restore(ctx, userID); err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	viewer, err := c.repository.GetByID(ctx, userID)
+	if err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	return domain.NewDTOAdminUserResponse(viewer), nil
+}
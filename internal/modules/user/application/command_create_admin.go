@@ -0,0 +1,68 @@
+package application
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/user/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+)
+
+const minAdminPasswordLength = 8
+
+var ErrAdminPasswordTooShort = base.NewValidationError("password must be at least 8 characters long")
+
+// CreateAdminCommand bootstraps an admin account from the command line. The
+// email is trusted, so the account starts out verified.
+type CreateAdminCommand struct {
+	repository domain.IViewerRepository
+	hasher     domain.IPasswordHasher
+}
+
+func NewCreateAdminCommand(repository domain.IViewerRepository, hasher domain.IPasswordHasher) *CreateAdminCommand {
+	return &CreateAdminCommand{
+		repository: repository,
+		hasher:     hasher,
+	}
+}
+
+func (c *CreateAdminCommand) Execute(ctx context.Context, dto *domain.DTOCreateUser) (*domain.Viewer, error) {
+	if dto.Username == "" {
+		return nil, domain.ErrInvalidUsername
+	}
+	if len(dto.Password) < minAdminPasswordLength {
+		return nil, ErrAdminPasswordTooShort
+	}
+
+	_, err := c.repository.GetByEmail(ctx, dto.Email)
+	if err == nil {
+		return nil, domain.ErrEmailTaken
+	}
+	if !errors.Is(err, domain.ErrUserNotFound) {
+		return nil, base.ToDomainError(err)
+	}
+
+	hashedPassword, err := c.hasher.HashPassword(dto.Password)
+	if err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	admin, err := domain.CreateAdmin(&domain.DTOCreateUser{
+		Username: dto.Username,
+		Email:    dto.Email,
+		Password: hashedPassword,
+		Provider: domain.AuthProviderLocal,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	viewer := &domain.Viewer{User: admin.User}
+	viewer.MarkEmailVerified()
+
+	if err := c.repository.Create(ctx, viewer); err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	return viewer, nil
+}
@@ -0,0 +1,54 @@
+package application
+
+import (
+	"context"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/user/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+)
+
+type ViewerChangePasswordCommand struct {
+	repository domain.IViewerRepository
+	hasher     domain.IPasswordHasher
+	revoker    domain.ISessionRevoker
+}
+
+func NewViewerChangePasswordCommand(
+	repository domain.IViewerRepository,
+	hasher domain.IPasswordHasher,
+	revoker domain.ISessionRevoker,
+) *ViewerChangePasswordCommand {
+	return &ViewerChangePasswordCommand{
+		repository: repository,
+		hasher:     hasher,
+		revoker:    revoker,
+	}
+}
+
+// Execute sets a new password after checking the current one, and signs the
+// user out of every other device. The current session stays signed in.
+func (c *ViewerChangePasswordCommand) Execute(ctx context.Context, userID, sessionID string, dto *domain.DTOChangePassword) error {
+	viewer, err := c.repository.GetByID(ctx, userID)
+	if err != nil {
+		return base.ToDomainError(err)
+	}
+
+	if !viewer.HasPassword() {
+		return domain.ErrPasswordNotSet
+	}
+	if err := c.hasher.ComparePassword(viewer.Password, dto.CurrentPassword); err != nil {
+		return domain.ErrInvalidPassword
+	}
+
+	hashedPassword, err := c.hasher.HashPassword(dto.NewPassword)
+	if err != nil {
+		return base.ToDomainError(err)
+	}
+
+	viewer.Password = hashedPassword
+	if err := c.repository.Update(ctx, viewer); err != nil {
+		return base.ToDomainError(err)
+	}
+
+	return c.revoker.RevokeOtherSessions(ctx, userID, sessionID)
+}
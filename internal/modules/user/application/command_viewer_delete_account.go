@@ -0,0 +1,60 @@
+package application
+
+import (
+	"context"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/user/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gorm_comp"
+)
+
+type ViewerDeleteAccountCommand struct {
+	repository domain.IViewerRepository
+	hasher     domain.IPasswordHasher
+	revoker    domain.ISessionRevoker
+	txManager  gorm_comp.ITxManager
+}
+
+func NewViewerDeleteAccountCommand(
+	repository domain.IViewerRepository,
+	hasher domain.IPasswordHasher,
+	revoker domain.ISessionRevoker,
+	txManager gorm_comp.ITxManager,
+) *ViewerDeleteAccountCommand {
+	return &ViewerDeleteAccountCommand{
+		repository: repository,
+		hasher:     hasher,
+		revoker:    revoker,
+		txManager:  txManager,
+	}
+}
+
+// Execute anonymizes the user's personal data, soft-deletes the account and
+// signs the user out everywhere.
+func (c *ViewerDeleteAccountCommand) Execute(ctx context.Context, userID string, dto *domain.DTODeleteAccount) error {
+	viewer, err := c.repository.GetByID(ctx, userID)
+	if err != nil {
+		return base.ToDomainError(err)
+	}
+
+	if viewer.HasPassword() {
+		if err := c.hasher.ComparePassword(viewer.Password, dto.Password); err != nil {
+			return domain.ErrInvalidPassword
+		}
+	}
+
+	// The account is never left anonymized but active, or deleted with its
+	// personal data intact.
+	err = c.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		viewer.Anonymize()
+		if err := c.repository.Update(ctx, viewer); err != nil {
+			return err
+		}
+		return c.repository.Delete(ctx, userID)
+	})
+	if err != nil {
+		return base.ToDomainError(err)
+	}
+
+	return c.revoker.RevokeUserSessions(ctx, userID)
+}
@@ -0,0 +1,89 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/user/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/logger"
+)
+
+type ViewerUpdateProfileCommand struct {
+	repository domain.IViewerRepository
+	hasher     domain.IPasswordHasher
+	verifier   domain.IEmailVerificationSender
+	log        logger.Logger
+}
+
+func NewViewerUpdateProfileCommand(
+	repository domain.IViewerRepository,
+	hasher domain.IPasswordHasher,
+	verifier domain.IEmailVerificationSender,
+	log logger.Logger,
+) *ViewerUpdateProfileCommand {
+	return &ViewerUpdateProfileCommand{
+		repository: repository,
+		hasher:     hasher,
+		verifier:   verifier,
+		log:        log,
+	}
+}
+
+// Execute updates the username and email. The email is where password resets
+// are sent, so changing it is confirmed with the current password, like a
+// password change. A new email is unverified until the user follows the link
+// sent to it.
+func (c *ViewerUpdateProfileCommand) Execute(ctx context.Context, userID string, dto *domain.DTOUpdateProfile) (*domain.DTOProfileResponse, error) {
+	viewer, err := c.repository.GetByID(ctx, userID)
+	if err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	if dto.Username != nil {
+		if err := viewer.ChangeUsername(*dto.Username); err != nil {
+			return nil, err
+		}
+	}
+
+	emailChanged := false
+	if dto.Email != nil && strings.TrimSpace(*dto.Email) != viewer.Email.Value {
+		if !viewer.HasPassword() {
+			return nil, domain.ErrPasswordNotSet
+		}
+		if err := c.hasher.ComparePassword(viewer.Password, dto.CurrentPassword); err != nil {
+			return nil, domain.ErrInvalidPassword
+		}
+
+		email := strings.TrimSpace(*dto.Email)
+		existing, err := c.repository.GetByEmail(ctx, email)
+		if err == nil && existing.ID != viewer.ID {
+			return nil, domain.ErrEmailTaken
+		}
+		if err != nil && !errors.Is(err, domain.ErrUserNotFound) {
+			return nil, base.ToDomainError(err)
+		}
+
+		if err := viewer.ChangeEmail(email); err != nil {
+			return nil, err
+		}
+		emailChanged = true
+	}
+
+	if err := c.repository.Update(ctx, viewer); err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	// The change is saved; the user can ask for a new link.
+	if emailChanged {
+		if err := c.verifier.SendVerificationEmail(ctx, viewer); err != nil {
+			c.log.Errorw("failed to send verification email", logger.Fields{
+				"user_id": viewer.ID.String(),
+				"error":   err.Error(),
+			})
+		}
+	}
+
+	return domain.NewDTOProfileResponse(viewer), nil
+}
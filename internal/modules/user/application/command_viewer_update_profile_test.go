@@ -0,0 +1,129 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/user/domain"
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/user/infrastructure/persistence"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gorm_comp"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gorm_comp/gormtest"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/outbox_comp"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/global_config"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/logger"
+	log_cfg "github.com/dukk308/beetool.dev-go-starter/pkgs/logger/config"
+)
+
+// plainHasher "hashes" passwords with a prefix.
+type plainHasher struct{}
+
+func (plainHasher) HashPassword(password string) (string, error) {
+	return "hashed:" + password, nil
+}
+
+func (plainHasher) ComparePassword(hashedPassword, password string) error {
+	if hashedPassword != "hashed:"+password {
+		return errors.New("password mismatch")
+	}
+	return nil
+}
+
+type recordingVerifier struct {
+	sentTo []string
+}
+
+func (v *recordingVerifier) SendVerificationEmail(ctx context.Context, viewer *domain.Viewer) error {
+	v.sentTo = append(v.sentTo, viewer.Email.Value)
+	return nil
+}
+
+func newUpdateProfileCommand(t *testing.T, password string) (*ViewerUpdateProfileCommand, domain.IViewerRepository, *recordingVerifier, string) {
+	t.Helper()
+
+	db := gormtest.Open(t, &persistence.SQLUser{}, &gorm_comp.AuditLog{}, &outbox_comp.OutboxMessage{})
+	repository := persistence.NewViewerRepository(db, gorm_comp.NewTxManager(db), outbox_comp.NewOutbox(db))
+
+	dto := &domain.DTOCreateUser{Username: "jane", Email: "jane@example.com", Provider: domain.AuthProviderLocal}
+	if password != "" {
+		dto.Password = "hashed:" + password
+	} else {
+		dto.Provider = domain.AuthProviderGoogle
+		dto.ProviderID = "google-subject"
+	}
+	viewer, err := domain.CreateViewer(dto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repository.Create(context.Background(), viewer); err != nil {
+		t.Fatal(err)
+	}
+
+	verifier := &recordingVerifier{}
+	log := logger.NewZapLogger(&log_cfg.LogOptions{}, &global_config.GlobalConfig{LogLevel: "fatal"})
+	return NewViewerUpdateProfileCommand(repository, plainHasher{}, verifier, log), repository, verifier, viewer.ID.String()
+}
+
+func TestUpdateProfileChangesTheEmailWithTheCurrentPassword(t *testing.T) {
+	command, repository, verifier, userID := newUpdateProfileCommand(t, "secret-password")
+	email := "new@example.com"
+
+	_, err := command.Execute(context.Background(), userID, &domain.DTOUpdateProfile{Email: &email, CurrentPassword: "secret-password"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	viewer, err := repository.GetByID(context.Background(), userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if viewer.Email.Value != email || viewer.IsEmailVerified() {
+		t.Fatalf("email %s, verified %v, want %s unverified", viewer.Email.Value, viewer.IsEmailVerified(), email)
+	}
+	if len(verifier.sentTo) != 1 || verifier.sentTo[0] != email {
+		t.Fatalf("verification sent to %v, want %s", verifier.sentTo, email)
+	}
+}
+
+func TestUpdateProfileRejectsEmailChangesWithoutThePassword(t *testing.T) {
+	command, repository, verifier, userID := newUpdateProfileCommand(t, "secret-password")
+	email := "new@example.com"
+
+	for _, password := range []string{"", "wrong-password"} {
+		_, err := command.Execute(context.Background(), userID, &domain.DTOUpdateProfile{Email: &email, CurrentPassword: password})
+		if !errors.Is(err, domain.ErrInvalidPassword) {
+			t.Fatalf("password %q: err = %v, want ErrInvalidPassword", password, err)
+		}
+	}
+
+	viewer, err := repository.GetByID(context.Background(), userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if viewer.Email.Value != "jane@example.com" || len(verifier.sentTo) != 0 {
+		t.Fatalf("email changed to %s", viewer.Email.Value)
+	}
+}
+
+func TestUpdateProfileChangesTheUsernameWithoutThePassword(t *testing.T) {
+	command, _, _, userID := newUpdateProfileCommand(t, "secret-password")
+	username := "janet"
+
+	response, err := command.Execute(context.Background(), userID, &domain.DTOUpdateProfile{Username: &username})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Username != username {
+		t.Fatalf("username = %s, want %s", response.Username, username)
+	}
+}
+
+func TestUpdateProfileRejectsEmailChangesOfAccountsWithoutPassword(t *testing.T) {
+	command, _, _, userID := newUpdateProfileCommand(t, "")
+	email := "new@example.com"
+
+	_, err := command.Execute(context.Background(), userID, &domain.DTOUpdateProfile{Email: &email})
+	if !errors.Is(err, domain.ErrPasswordNotSet) {
+		t.Fatalf("err = %v, want ErrPasswordNotSet", err)
+	}
+}
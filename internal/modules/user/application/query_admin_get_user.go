@@ -0,0 +1,27 @@
+package application
+
+import (
+	"context"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/user/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+)
+
+type AdminGetUserQuery struct {
+	repository domain.IViewerRepository
+}
+
+func NewAdminGetUserQuery(repository domain.IViewerRepository) *AdminGetUserQuery {
+	return &AdminGetUserQuery{
+		repository: repository,
+	}
+}
+
+func (q *AdminGetUserQuery) Execute(ctx context.Context, userID string) (*domain.DTOAdminUserResponse, error) {
+	viewer, err := q.repository.GetByID(ctx, userID)
+	if err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	return domain.NewDTOAdminUserResponse(viewer), nil
+}
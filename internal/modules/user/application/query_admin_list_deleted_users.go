@@ -0,0 +1,46 @@
+package application
+
+import (
+	"context"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/user/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+)
+
+type AdminListDeletedUsersQuery struct {
+	repository domain.IViewerRepository
+}
+
+func NewAdminListDeletedUsersQuery(repository domain.IViewerRepository) *AdminListDeletedUsersQuery {
+	return &AdminListDeletedUsersQuery{
+		repository: repository,
+	}
+}
+
+// Execute lists the trash, most recently deleted first.
+func (q *AdminListDeletedUsersQuery) Execute(ctx context.Context, dto *domain.DTOAdminListDeletedUsers) (*domain.DTOAdminUserListResponse, error) {
+	page, limit := dto.Page, dto.Limit
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	viewers, total, err := q.repository.ListDeleted(ctx, (page-1)*limit, limit)
+	if err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	items := make([]*domain.DTOAdminUserResponse, len(viewers))
+	for i, viewer := range viewers {
+		items[i] = domain.NewDTOAdminUserResponse(viewer)
+	}
+
+	return &domain.DTOAdminUserListResponse{
+		Items: items,
+		Total: total,
+		Page:  page,
+		Limit: limit,
+	}, nil
+}
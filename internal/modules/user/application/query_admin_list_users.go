@@ -0,0 +1,52 @@
+package application
+
+import (
+	"context"
+	"strings"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/user/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+)
+
+type AdminListUsersQuery struct {
+	repository domain.IViewerRepository
+}
+
+func NewAdminListUsersQuery(repository domain.IViewerRepository) *AdminListUsersQuery {
+	return &AdminListUsersQuery{
+		repository: repository,
+	}
+}
+
+func (q *AdminListUsersQuery) Execute(ctx context.Context, dto *domain.DTOAdminListUsers) (*domain.DTOAdminUserListResponse, error) {
+	page, limit := dto.Page, dto.Limit
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	filter := domain.UserFilter{
+		Role:     domain.Role(strings.TrimSpace(dto.Role)),
+		Provider: domain.AuthProvider(strings.TrimSpace(dto.Provider)),
+		Email:    strings.TrimSpace(dto.Email),
+	}
+
+	viewers, total, err := q.repository.List(ctx, filter, (page-1)*limit, limit)
+	if err != nil {
+		return nil, base.ToDomainError(err)
+	}
+
+	items := make([]*domain.DTOAdminUserResponse, len(viewers))
+	for i, viewer := range viewers {
+		items[i] = domain.NewDTOAdminUserResponse(viewer)
+	}
+
+	return &domain.DTOAdminUserListResponse{
+		Items: items,
+		Total: total,
+		Page:  page,
+		Limit: limit,
+	}, nil
+}
@@ -0,0 +1,20 @@
+package domain
+
+// DTOUpdateProfile changes the username and email. Changing the email needs
+// the current password.
+type DTOUpdateProfile struct {
+	Username        *string `json:"username" binding:"omitempty,min=1"`
+	Email           *string `json:"email" binding:"omitempty,email"`
+	CurrentPassword string  `json:"current_password"`
+}
+
+type DTOChangePassword struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=8"`
+}
+
+// DTODeleteAccount confirms the deletion with the current password. Accounts
+// without a password, e.g. Google sign-ins, leave it empty.
+type DTODeleteAccount struct {
+	Password string `json:"password"`
+}
@@ -0,0 +1,57 @@
+package domain
+
+import "time"
+
+type DTOAdminListUsers struct {
+	Page     int    `form:"page"`
+	Limit    int    `form:"limit"`
+	Role     string `form:"role"`
+	Provider string `form:"provider"`
+	Email    string `form:"email"`
+}
+
+type DTOAdminListDeletedUsers struct {
+	Page  int `form:"page"`
+	Limit int `form:"limit"`
+}
+
+type DTOChangeRole struct {
+	Role string `json:"role" binding:"required"`
+}
+
+type DTOAdminUserResponse struct {
+	ID            string     `json:"id"`
+	Username      string     `json:"username"`
+	Email         string     `json:"email"`
+	Role          string     `json:"role"`
+	AuthProvider  string     `json:"auth_provider"`
+	EmailVerified bool       `json:"email_verified"`
+	DisabledAt    *time.Time `json:"disabled_at,omitempty"`
+	DeletedAt     *time.Time `json:"deleted_at,omitempty"`
+	DeletedBy     *string    `json:"deleted_by,omitempty"`
+	CreatedAt     *time.Time `json:"created_at,omitempty"`
+	UpdatedAt     *time.Time `json:"updated_at,omitempty"`
+}
+
+func NewDTOAdminUserResponse(viewer *Viewer) *DTOAdminUserResponse {
+	return &DTOAdminUserResponse{
+		ID:            viewer.ID.String(),
+		Username:      viewer.Username,
+		Email:         viewer.Email.Value,
+		Role:          viewer.Role.String(),
+		AuthProvider:  string(viewer.AuthProvider),
+		EmailVerified: viewer.IsEmailVerified(),
+		DisabledAt:    viewer.DisabledAt,
+		DeletedAt:     viewer.DeletedAt,
+		DeletedBy:     viewer.DeletedBy,
+		CreatedAt:     viewer.CreatedAt,
+		UpdatedAt:     viewer.UpdatedAt,
+	}
+}
+
+type DTOAdminUserListResponse struct {
+	Items []*DTOAdminUserResponse `json:"items"`
+	Total int64                   `json:"total"`
+	Page  int                     `json:"page"`
+	Limit int                     `json:"limit"`
+}
@@ -15,7 +15,12 @@ var (
 		Message: "invalid role",
 		Code:    "INVALID_ROLE",
 	}
-	ErrUnauthorized = &base.DomainError{
+	ErrUserNotFound     = base.NewNotFoundError("user not found")
+	ErrEmailTaken       = base.NewConflictError("email is already in use")
+	ErrCannotModifySelf = base.NewBusinessRuleError("admins cannot change the role of, disable or delete their own account")
+	ErrInvalidPassword  = base.NewValidationError("current password is incorrect")
+	ErrPasswordNotSet   = base.NewBusinessRuleError("account has no password, set one with a password reset")
+	ErrUnauthorized     = &base.DomainError{
 		Message: "unauthorized action",
 		Code:    "UNAUTHORIZED",
 	}
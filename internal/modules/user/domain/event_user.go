@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+// UserSignedUp is recorded when a visitor creates an account, with a password
+// or through an identity provider.
+type UserSignedUp struct {
+	UserID     string       `json:"user_id"`
+	Username   string       `json:"username"`
+	Email      string       `json:"email"`
+	Provider   AuthProvider `json:"provider"`
+	SignedUpAt time.Time    `json:"signed_up_at"`
+}
+
+func (UserSignedUp) EventName() string {
+	return "user.signed_up"
+}
@@ -7,6 +7,17 @@ func CreateViewer(dto *DTOCreateUser) (*Viewer, error) {
 	}
 
 	viewer.Role = RoleViewer
+	viewer.AuthProvider = dto.Provider
+	if dto.ProviderID != "" {
+		viewer.AuthProviderID = &dto.ProviderID
+	}
+	viewer.RecordEvent(UserSignedUp{
+		UserID:     viewer.ID.String(),
+		Username:   viewer.Username,
+		Email:      viewer.Email.Value,
+		Provider:   viewer.AuthProvider,
+		SignedUpAt: *viewer.CreatedAt,
+	})
 
 	return &Viewer{User: *viewer}, nil
 }
@@ -18,6 +29,7 @@ func CreateAdmin(dto *DTOCreateUser) (*Admin, error) {
 	}
 
 	admin.Role = RoleAdmin
+	admin.AuthProvider = dto.Provider
 
 	return &Admin{User: *admin}, nil
 }
@@ -29,6 +41,7 @@ func CreateEditor(dto *DTOCreateUser) (*Editor, error) {
 	}
 
 	editor.Role = RoleEditor
+	editor.AuthProvider = dto.Provider
 
 	return &Editor{User: *editor}, nil
 }
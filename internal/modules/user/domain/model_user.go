@@ -1,6 +1,9 @@
 package domain
 
 import (
+	"strings"
+	"time"
+
 	common "github.com/dukk308/beetool.dev-go-starter/pkgs/base"
 )
 
@@ -13,12 +16,14 @@ const (
 
 type User struct {
 	common.BaseModel
-	Username       string       `json:"username"`
-	Email          *EmailVO     `json:"email"`
-	Password       string       `json:"password"`
-	Role           Role         `json:"role"`
-	AuthProvider   AuthProvider `json:"auth_provider"`
-	AuthProviderID *string      `json:"auth_provider_id"`
+	Username        string       `json:"username"`
+	Email           *EmailVO     `json:"email"`
+	Password        string       `json:"password"`
+	Role            Role         `json:"role"`
+	AuthProvider    AuthProvider `json:"auth_provider"`
+	AuthProviderID  *string      `json:"auth_provider_id"`
+	EmailVerifiedAt *time.Time   `json:"email_verified_at"`
+	DisabledAt      *time.Time   `json:"disabled_at"`
 }
 
 func NewUser(username string, email string, password string) (*User, error) {
@@ -34,3 +39,79 @@ func NewUser(username string, email string, password string) (*User, error) {
 		Password:  password,
 	}, nil
 }
+
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+func (u *User) MarkEmailVerified() {
+	if u.EmailVerifiedAt != nil {
+		return
+	}
+	now := time.Now()
+	u.EmailVerifiedAt = &now
+}
+
+func (u *User) IsDisabled() bool {
+	return u.DisabledAt != nil
+}
+
+// Disable blocks sign-in and every credential of the user until Enable.
+func (u *User) Disable(now time.Time) {
+	if u.DisabledAt != nil {
+		return
+	}
+	u.DisabledAt = &now
+}
+
+func (u *User) Enable() {
+	u.DisabledAt = nil
+}
+
+func (u *User) ChangeRole(role Role) error {
+	if role == "" {
+		return ErrInvalidRole
+	}
+	u.Role = role
+	return nil
+}
+
+// ChangeEmail replaces the address and marks it unverified until the user
+// follows the link sent to the new one.
+func (u *User) ChangeEmail(email string) error {
+	emailVo := NewEmailVO(strings.TrimSpace(email))
+	if err := emailVo.Validate(); err != nil {
+		return err
+	}
+	if u.Email != nil && u.Email.Value == emailVo.Value {
+		return nil
+	}
+	u.Email = emailVo
+	u.EmailVerifiedAt = nil
+	return nil
+}
+
+func (u *User) ChangeUsername(username string) error {
+	username = strings.TrimSpace(username)
+	if username == "" {
+		return ErrInvalidUsername
+	}
+	u.Username = username
+	return nil
+}
+
+func (u *User) HasPassword() bool {
+	return u.Password != ""
+}
+
+// Anonymize strips personal data before the account is deleted. The email is
+// replaced by an unroutable address unique to the user so it can be reused,
+// and the provider link is cleared so signing in with it creates a new account.
+func (u *User) Anonymize() {
+	id := u.ID.String()
+	u.Username = "deleted-user-" + id[:8]
+	u.Email = NewEmailVO("deleted-" + id + "@deleted.invalid")
+	u.Password = ""
+	u.AuthProviderID = nil
+	u.EmailVerifiedAt = nil
+}
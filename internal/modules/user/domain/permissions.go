@@ -0,0 +1,11 @@
+package domain
+
+// Permissions checked by the user module, granted to roles by the authz policy.
+const (
+	PermissionRead       = "user:read:any"
+	PermissionChangeRole = "user:role:update"
+	PermissionDisable    = "user:disable"
+	PermissionDelete     = "user:delete:any"
+	// PermissionRestore lists deleted users and restores them.
+	PermissionRestore = "user:restore"
+)
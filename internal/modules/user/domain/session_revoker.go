@@ -0,0 +1,24 @@
+package domain
+
+import "context"
+
+// ISessionRevoker ends sessions and access tokens of a user. The auth module
+// implements it.
+type ISessionRevoker interface {
+	RevokeUserSessions(ctx context.Context, userID string) error
+	// RevokeOtherSessions signs the user out of every device except the
+	// session identified by keepSessionID.
+	RevokeOtherSessions(ctx context.Context, userID, keepSessionID string) error
+}
+
+// IPasswordHasher hashes and verifies passwords the way sign-in does.
+type IPasswordHasher interface {
+	HashPassword(password string) (string, error)
+	ComparePassword(hashedPassword, password string) error
+}
+
+// IEmailVerificationSender emails a link that verifies the user's current
+// address.
+type IEmailVerificationSender interface {
+	SendVerificationEmail(ctx context.Context, viewer *Viewer) error
+}
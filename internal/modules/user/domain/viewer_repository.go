@@ -2,11 +2,26 @@ package domain
 
 import "context"
 
+// UserFilter narrows List; empty fields match everything. Email matches
+// case-insensitively on a substring.
+type UserFilter struct {
+	Role     Role
+	Provider AuthProvider
+	Email    string
+}
+
+// IViewerRepository only sees users that are not deleted, except for
+// ListDeleted and Restore.
 type IViewerRepository interface {
 	GetByID(ctx context.Context, id string) (*Viewer, error)
 	GetByEmail(ctx context.Context, email string) (*Viewer, error)
+	GetByProvider(ctx context.Context, provider AuthProvider, providerID string) (*Viewer, error)
 	GetAll(ctx context.Context) ([]*Viewer, error)
+	List(ctx context.Context, filter UserFilter, offset, limit int) ([]*Viewer, int64, error)
 	Create(ctx context.Context, viewer *Viewer) error
 	Update(ctx context.Context, viewer *Viewer) error
+	// Delete soft-deletes the user.
 	Delete(ctx context.Context, id string) error
+	ListDeleted(ctx context.Context, offset, limit int) ([]*Viewer, int64, error)
+	Restore(ctx context.Context, id string) error
 }
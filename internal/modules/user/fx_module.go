@@ -1,11 +1,11 @@
 package user
 
 import (
-	auth_domain "github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
 	"github.com/dukk308/beetool.dev-go-starter/internal/modules/user/application"
 	"github.com/dukk308/beetool.dev-go-starter/internal/modules/user/domain"
 	"github.com/dukk308/beetool.dev-go-starter/internal/modules/user/infrastructure/persistence"
 	user_http "github.com/dukk308/beetool.dev-go-starter/internal/modules/user/presentation/http"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gorm_comp"
 	"go.uber.org/fx"
 )
 
@@ -18,13 +18,21 @@ var Module = fx.Module("user",
 	),
 	fx.Provide(
 		application.NewViewerGetProfileQuery,
+		application.NewViewerUpdateProfileCommand,
+		application.NewViewerChangePasswordCommand,
+		application.NewViewerDeleteAccountCommand,
+		application.NewAdminListUsersQuery,
+		application.NewAdminGetUserQuery,
+		application.NewAdminChangeRoleCommand,
+		application.NewAdminDisableUserCommand,
+		application.NewAdminEnableUserCommand,
+		application.NewAdminDeleteUserCommand,
+		application.NewAdminListDeletedUsersQuery,
+		application.NewAdminRestoreUserCommand,
+		application.NewCreateAdminCommand,
 	),
 	fx.Provide(
-		func(
-			viewerGetProfileQuery *application.ViewerGetProfileQuery,
-			tokenService auth_domain.ITokenService,
-		) *user_http.Http {
-			return user_http.NewHttp(viewerGetProfileQuery, tokenService)
-		},
+		user_http.NewHttp,
 	),
+	gorm_comp.ProvideTrashPurger(&persistence.SQLUser{}),
 )
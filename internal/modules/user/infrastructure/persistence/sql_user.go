@@ -1,6 +1,8 @@
 package persistence
 
 import (
+	"time"
+
 	"github.com/dukk308/beetool.dev-go-starter/internal/modules/user/domain"
 	common "github.com/dukk308/beetool.dev-go-starter/pkgs/base"
 	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gorm_comp"
@@ -9,46 +11,66 @@ import (
 
 type SQLUser struct {
 	gorm_comp.SQLModel
-	AuthProvider   domain.AuthProvider `gorm:"column:auth_provider;type:varchar(255);default:local;index:idx_provider_id;index:idx_provider_username"`
-	AuthProviderID *string             `gorm:"column:auth_provider_id;type:varchar(255);index:idx_provider_id"`
-	Username       *string             `gorm:"column:username;type:varchar(255);index:idx_provider_username"`
-	Email          *string             `gorm:"column:email;type:varchar(255)"`
-	Password       *string             `gorm:"column:password;type:varchar(255)"`
-	Role           domain.Role         `gorm:"column:role;type:varchar(255);default:viewer"`
+	AuthProvider    domain.AuthProvider `gorm:"column:auth_provider;type:varchar(255);default:local;index:idx_provider_id;index:idx_provider_username"`
+	AuthProviderID  *string             `gorm:"column:auth_provider_id;type:varchar(255);index:idx_provider_id"`
+	Username        *string             `gorm:"column:username;type:varchar(255);index:idx_provider_username"`
+	Email           *string             `gorm:"column:email;type:varchar(255)"`
+	Password        *string             `gorm:"column:password;type:varchar(255)"`
+	Role            domain.Role         `gorm:"column:role;type:varchar(255);default:viewer"`
+	EmailVerifiedAt *time.Time          `gorm:"column:email_verified_at"`
+	DisabledAt      *time.Time          `gorm:"column:disabled_at"`
 }
 
 func (u *SQLUser) TableName() string {
 	return "users"
 }
 
+func (u *SQLUser) AuditEntity() string {
+	return "user"
+}
+
+// AuditRedactedColumns keeps password hashes and personal data out of the
+// audit log, which is never cleaned up, so that anonymizing a deleted
+// account leaves no copy of it behind.
+func (u *SQLUser) AuditRedactedColumns() []string {
+	return []string{"password", "email", "username", "auth_provider_id"}
+}
+
 func (u *SQLUser) ToDomainViewer() *domain.Viewer {
 	return &domain.Viewer{
 		User: domain.User{
 			BaseModel: common.BaseModel{
 				ID:        uuid.MustParse(u.ID),
+				Version:   u.Version,
 				CreatedAt: u.CreatedAt,
 				UpdatedAt: u.UpdatedAt,
-				DeletedAt: u.DeletedAt,
+				DeletedAt: gorm_comp.DeletedAtTime(u.DeletedAt),
+				DeletedBy: u.DeletedBy,
 			},
-			Username:       *u.Username,
-			Email:          domain.NewEmailVO(*u.Email),
-			Password:       *u.Password,
-			Role:           domain.Role(u.Role),
-			AuthProvider:   u.AuthProvider,
-			AuthProviderID: u.AuthProviderID,
+			Username:        *u.Username,
+			Email:           domain.NewEmailVO(*u.Email),
+			Password:        *u.Password,
+			Role:            domain.Role(u.Role),
+			AuthProvider:    u.AuthProvider,
+			AuthProviderID:  u.AuthProviderID,
+			EmailVerifiedAt: u.EmailVerifiedAt,
+			DisabledAt:      u.DisabledAt,
 		},
 	}
 }
 
 func (u *SQLUser) FromDomainViewer(viewer *domain.Viewer) {
 	u.ID = viewer.ID.String()
+	u.Version = viewer.Version
 	u.CreatedAt = viewer.CreatedAt
 	u.UpdatedAt = viewer.UpdatedAt
-	u.DeletedAt = viewer.DeletedAt
+	u.DeletedAt = gorm_comp.ToDeletedAt(viewer.DeletedAt)
 	u.AuthProvider = viewer.AuthProvider
 	u.AuthProviderID = viewer.AuthProviderID
 	u.Username = &viewer.Username
 	u.Email = &viewer.Email.Value
 	u.Password = &viewer.Password
 	u.Role = viewer.Role
+	u.EmailVerifiedAt = viewer.EmailVerifiedAt
+	u.DisabledAt = viewer.DisabledAt
 }
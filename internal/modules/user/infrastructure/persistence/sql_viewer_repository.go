@@ -2,30 +2,57 @@ package persistence
 
 import (
 	"context"
+	"errors"
+	"strings"
 
 	"gorm.io/gorm"
 
 	"github.com/dukk308/beetool.dev-go-starter/internal/modules/user/domain"
+	common "github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gorm_comp"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/outbox_comp"
 )
 
+const userAggregate = "user"
+
 type ViewerRepository struct {
-	db *gorm.DB
+	db        *gorm.DB
+	txManager gorm_comp.ITxManager
+	outbox    outbox_comp.IOutbox
 }
 
-func NewViewerRepository(db *gorm.DB) domain.IViewerRepository {
+func NewViewerRepository(db *gorm.DB, txManager gorm_comp.ITxManager, outbox outbox_comp.IOutbox) domain.IViewerRepository {
 	return &ViewerRepository{
-		db: db,
+		db:        db,
+		txManager: txManager,
+		outbox:    outbox,
 	}
 }
 
+// Create and Update save the viewer's recorded events in the same
+// transaction.
 func (r *ViewerRepository) Create(ctx context.Context, viewer *domain.Viewer) error {
 	sqlUser := &SQLUser{}
 	sqlUser.FromDomainViewer(viewer)
-	return r.db.WithContext(ctx).Create(sqlUser).Error
+
+	return r.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := r.db.WithContext(ctx).Create(sqlUser).Error; err != nil {
+			return err
+		}
+
+		return r.outbox.Append(ctx, userAggregate, viewer.ID.String(), viewer.PullEvents())
+	})
 }
 
 func (r *ViewerRepository) Delete(ctx context.Context, id string) error {
-	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&SQLUser{}).Error
+	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&SQLUser{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrUserNotFound
+	}
+	return nil
 }
 
 func (r *ViewerRepository) GetAll(ctx context.Context) ([]*domain.Viewer, error) {
@@ -42,9 +69,90 @@ func (r *ViewerRepository) GetAll(ctx context.Context) ([]*domain.Viewer, error)
 	return viewers, nil
 }
 
+func (r *ViewerRepository) List(ctx context.Context, filter domain.UserFilter, offset, limit int) ([]*domain.Viewer, int64, error) {
+	query := r.db.WithContext(ctx).Model(&SQLUser{})
+	if filter.Role != "" {
+		query = query.Where("role = ?", filter.Role)
+	}
+	if filter.Provider != "" {
+		query = query.Where("auth_provider = ?", filter.Provider)
+	}
+	if filter.Email != "" {
+		query = query.Where("LOWER(email) LIKE ?", "%"+escapeLike(strings.ToLower(filter.Email))+"%")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var sqlUsers []SQLUser
+	if err := query.Order("created_at DESC").Offset(offset).Limit(limit).Find(&sqlUsers).Error; err != nil {
+		return nil, 0, err
+	}
+
+	viewers := make([]*domain.Viewer, len(sqlUsers))
+	for i, sqlUser := range sqlUsers {
+		viewers[i] = sqlUser.ToDomainViewer()
+	}
+
+	return viewers, total, nil
+}
+
+func (r *ViewerRepository) ListDeleted(ctx context.Context, offset, limit int) ([]*domain.Viewer, int64, error) {
+	query := gorm_comp.Trashed(r.db.WithContext(ctx).Model(&SQLUser{}))
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var sqlUsers []SQLUser
+	if err := query.Order("deleted_at DESC").Offset(offset).Limit(limit).Find(&sqlUsers).Error; err != nil {
+		return nil, 0, err
+	}
+
+	viewers := make([]*domain.Viewer, len(sqlUsers))
+	for i, sqlUser := range sqlUsers {
+		viewers[i] = sqlUser.ToDomainViewer()
+	}
+
+	return viewers, total, nil
+}
+
+func (r *ViewerRepository) Restore(ctx context.Context, id string) error {
+	restored, err := gorm_comp.Restore(ctx, r.db, &SQLUser{}, id)
+	if err != nil {
+		return err
+	}
+	if !restored {
+		return domain.ErrUserNotFound
+	}
+	return nil
+}
+
 func (r *ViewerRepository) GetByEmail(ctx context.Context, email string) (*domain.Viewer, error) {
 	var sqlUser SQLUser
 	if err := r.db.WithContext(ctx).Where("email = ?", email).First(&sqlUser).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	viewer := sqlUser.ToDomainViewer()
+	return viewer, nil
+}
+
+func (r *ViewerRepository) GetByProvider(ctx context.Context, provider domain.AuthProvider, providerID string) (*domain.Viewer, error) {
+	var sqlUser SQLUser
+	err := r.db.WithContext(ctx).
+		Where("auth_provider = ? AND auth_provider_id = ?", provider, providerID).
+		First(&sqlUser).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrUserNotFound
+		}
 		return nil, err
 	}
 
@@ -55,6 +163,9 @@ func (r *ViewerRepository) GetByEmail(ctx context.Context, email string) (*domai
 func (r *ViewerRepository) GetByID(ctx context.Context, id string) (*domain.Viewer, error) {
 	var sqlUser SQLUser
 	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&sqlUser).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrUserNotFound
+		}
 		return nil, err
 	}
 
@@ -62,8 +173,30 @@ func (r *ViewerRepository) GetByID(ctx context.Context, id string) (*domain.View
 	return viewer, nil
 }
 
+// Update only saves the viewer while the row still has the version it was
+// read at, so that concurrent updates, such as a profile change racing an
+// admin disabling the account, cannot overwrite each other.
 func (r *ViewerRepository) Update(ctx context.Context, viewer *domain.Viewer) error {
 	sqlUser := &SQLUser{}
 	sqlUser.FromDomainViewer(viewer)
-	return r.db.WithContext(ctx).Save(sqlUser).Error
+
+	err := r.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := gorm_comp.UpdateVersioned(ctx, r.db, sqlUser); err != nil {
+			return err
+		}
+
+		return r.outbox.Append(ctx, userAggregate, viewer.ID.String(), viewer.PullEvents())
+	})
+	if err != nil {
+		if errors.Is(err, gorm_comp.ErrStaleVersion) {
+			return common.ErrConcurrentUpdate
+		}
+		return err
+	}
+	viewer.Version = sqlUser.Version
+	return nil
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
 }
@@ -0,0 +1,151 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/user/domain"
+	common "github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gorm_comp"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gorm_comp/gormtest"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/outbox_comp"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/types"
+	"gorm.io/gorm"
+)
+
+func newTestRepository(t *testing.T) (*ViewerRepository, *gorm.DB) {
+	t.Helper()
+
+	db := gormtest.Open(t, &SQLUser{}, &gorm_comp.AuditLog{}, &outbox_comp.OutboxMessage{})
+	repo := NewViewerRepository(db, gorm_comp.NewTxManager(db), outbox_comp.NewOutbox(db)).(*ViewerRepository)
+	return repo, db
+}
+
+func createTestViewer(t *testing.T, ctx context.Context, repo *ViewerRepository) *domain.Viewer {
+	t.Helper()
+
+	viewer, err := domain.CreateViewer(&domain.DTOCreateUser{
+		Username: "jane",
+		Email:    "jane@example.com",
+		Password: "hash",
+		Provider: domain.AuthProviderLocal,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Create(ctx, viewer); err != nil {
+		t.Fatal(err)
+	}
+	return viewer
+}
+
+func TestViewerRepositoryUpdateKeepsCreationColumns(t *testing.T) {
+	repo, db := newTestRepository(t)
+	creator := types.WithUser(context.Background(), &types.UserAuthenticated{ID: "creator"})
+	viewer := createTestViewer(t, creator, repo)
+
+	editor := types.WithUser(context.Background(), &types.UserAuthenticated{ID: "editor"})
+	if err := viewer.ChangeRole(domain.RoleEditor); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Update(editor, viewer); err != nil {
+		t.Fatal(err)
+	}
+
+	var row SQLUser
+	if err := db.Where("id = ?", viewer.ID.String()).First(&row).Error; err != nil {
+		t.Fatal(err)
+	}
+	if row.CreatedBy == nil || *row.CreatedBy != "creator" {
+		t.Errorf("created_by = %v, want creator", row.CreatedBy)
+	}
+	if row.UpdatedBy == nil || *row.UpdatedBy != "editor" {
+		t.Errorf("updated_by = %v, want editor", row.UpdatedBy)
+	}
+	if row.Role != domain.RoleEditor {
+		t.Errorf("role = %q, want %q", row.Role, domain.RoleEditor)
+	}
+
+	var update gorm_comp.AuditLog
+	if err := db.Where("entity_id = ? AND action = ?", viewer.ID.String(), gorm_comp.AuditActionUpdate).First(&update).Error; err != nil {
+		t.Fatal(err)
+	}
+	var changes map[string]gorm_comp.AuditChange
+	if err := json.Unmarshal([]byte(update.Changes), &changes); err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 2 {
+		t.Errorf("changes = %v, want only role and version", changes)
+	}
+	if _, ok := changes["role"]; !ok {
+		t.Errorf("changes = %v, want role", changes)
+	}
+}
+
+func TestViewerRepositoryUpdateRejectsAStaleViewer(t *testing.T) {
+	repo, db := newTestRepository(t)
+	ctx := context.Background()
+	created := createTestViewer(t, ctx, repo)
+
+	// A profile update reads the viewer while an admin disables it.
+	profile, err := repo.GetByID(ctx, created.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	admin, err := repo.GetByID(ctx, created.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	admin.Disable(time.Now())
+	if err := repo.Update(ctx, admin); err != nil {
+		t.Fatal(err)
+	}
+	if admin.Version != 2 {
+		t.Fatalf("version after update = %d, want 2", admin.Version)
+	}
+
+	if err := profile.ChangeUsername("janet"); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Update(ctx, profile); !errors.Is(err, common.ErrConcurrentUpdate) {
+		t.Fatalf("err = %v, want ErrConcurrentUpdate", err)
+	}
+
+	var row SQLUser
+	if err := db.Where("id = ?", created.ID.String()).First(&row).Error; err != nil {
+		t.Fatal(err)
+	}
+	if row.DisabledAt == nil || *row.Username != "jane" {
+		t.Fatalf("disabled at %v with username %q, want the admin's update kept", row.DisabledAt, *row.Username)
+	}
+}
+
+func TestViewerRepositoryAnonymizeLeavesNoPersonalDataInAuditLog(t *testing.T) {
+	repo, db := newTestRepository(t)
+	ctx := context.Background()
+	viewer := createTestViewer(t, ctx, repo)
+
+	viewer.Anonymize()
+	if err := repo.Update(ctx, viewer); err != nil {
+		t.Fatal(err)
+	}
+
+	var logs []gorm_comp.AuditLog
+	if err := db.Where("entity_id = ?", viewer.ID.String()).Find(&logs).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != 2 {
+		t.Fatalf("got %d audit logs, want create and update", len(logs))
+	}
+	for _, log := range logs {
+		for _, personal := range []string{"jane", "hash"} {
+			if strings.Contains(log.Changes, personal) {
+				t.Errorf("%s audit log contains %q: %s", log.Action, personal, log.Changes)
+			}
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package http
+
+import (
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/user/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gin_comp"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Http) HandlerAdminListUsers() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var dto domain.DTOAdminListUsers
+		if err := c.ShouldBindQuery(&dto); err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		response, err := h.adminListUsersQuery.Execute(c.Request.Context(), &dto)
+		if err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		gin_comp.ResponseSuccess(c, response)
+	}
+}
+
+func (h *Http) HandlerAdminGetUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		response, err := h.adminGetUserQuery.Execute(c.Request.Context(), c.Param("id"))
+		if err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		gin_comp.ResponseSuccess(c, response)
+	}
+}
+
+func (h *Http) HandlerAdminChangeRole() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var dto domain.DTOChangeRole
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		response, err := h.adminChangeRoleCommand.Execute(c.Request.Context(), c.Param("id"), &dto)
+		if err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		gin_comp.ResponseSuccess(c, response)
+	}
+}
+
+func (h *Http) HandlerAdminDisableUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		response, err := h.adminDisableUserCommand.Execute(c.Request.Context(), c.Param("id"))
+		if err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		gin_comp.ResponseSuccess(c, response)
+	}
+}
+
+func (h *Http) HandlerAdminEnableUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		response, err := h.adminEnableUserCommand.Execute(c.Request.Context(), c.Param("id"))
+		if err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		gin_comp.ResponseSuccess(c, response)
+	}
+}
+
+func (h *Http) HandlerAdminDeleteUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := h.adminDeleteUserCommand.Execute(c.Request.Context(), c.Param("id")); err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		gin_comp.ResponseSuccess(c, map[string]string{"message": "user deleted successfully"})
+	}
+}
+
+func (h *Http) HandlerAdminListDeletedUsers() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var dto domain.DTOAdminListDeletedUsers
+		if err := c.ShouldBindQuery(&dto); err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		response, err := h.adminListDeletedUsersQuery.Execute(c.Request.Context(), &dto)
+		if err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		gin_comp.ResponseSuccess(c, response)
+	}
+}
+
+func (h *Http) HandlerAdminRestoreUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		response, err := h.adminRestoreUserCommand.Execute(c.Request.Context(), c.Param("id"))
+		if err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		gin_comp.ResponseSuccess(c, response)
+	}
+}
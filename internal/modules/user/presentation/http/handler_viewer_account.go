@@ -0,0 +1,94 @@
+package http
+
+import (
+	auth_domain "github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/user/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gin_comp"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/types"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Http) HandlerViewerUpdateProfile() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			dto domain.DTOUpdateProfile
+			ctx = c.Request.Context()
+		)
+
+		user, ok := types.UserFromContext(ctx)
+		if !ok {
+			gin_comp.ResponseError(c, auth_domain.ErrInvalidToken)
+			return
+		}
+
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		response, err := h.viewerUpdateProfileCommand.Execute(ctx, user.GetID(), &dto)
+		if err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		gin_comp.ResponseSuccess(c, response)
+	}
+}
+
+func (h *Http) HandlerViewerChangePassword() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			dto domain.DTOChangePassword
+			ctx = c.Request.Context()
+		)
+
+		user, ok := types.UserFromContext(ctx)
+		if !ok {
+			gin_comp.ResponseError(c, auth_domain.ErrInvalidToken)
+			return
+		}
+
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		if err := h.viewerChangePasswordCommand.Execute(ctx, user.GetID(), user.GetSessionID(), &dto); err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		gin_comp.ResponseSuccess(c, map[string]string{"message": "password changed successfully"})
+	}
+}
+
+func (h *Http) HandlerViewerDeleteAccount() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			dto domain.DTODeleteAccount
+			ctx = c.Request.Context()
+		)
+
+		user, ok := types.UserFromContext(ctx)
+		if !ok {
+			gin_comp.ResponseError(c, auth_domain.ErrInvalidToken)
+			return
+		}
+
+		// The body is optional for accounts without a password.
+		if c.Request.ContentLength != 0 {
+			if err := c.ShouldBindJSON(&dto); err != nil {
+				gin_comp.ResponseError(c, err)
+				return
+			}
+		}
+
+		if err := h.viewerDeleteAccountCommand.Execute(ctx, user.GetID(), &dto); err != nil {
+			gin_comp.ResponseError(c, err)
+			return
+		}
+
+		gin_comp.ResponseSuccess(c, map[string]string{"message": "account deleted successfully"})
+	}
+}
@@ -1,30 +1,104 @@
 package http
 
 import (
-	auth_domain "github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
 	"github.com/dukk308/beetool.dev-go-starter/internal/modules/user/application"
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/user/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/authz"
+	middleware "github.com/dukk308/beetool.dev-go-starter/pkgs/middlewares/gin"
 	"github.com/gin-gonic/gin"
 )
 
 type Http struct {
-	viewerGetProfileQuery *application.ViewerGetProfileQuery
-	tokenService          auth_domain.ITokenService
+	viewerGetProfileQuery       *application.ViewerGetProfileQuery
+	viewerUpdateProfileCommand  *application.ViewerUpdateProfileCommand
+	viewerChangePasswordCommand *application.ViewerChangePasswordCommand
+	viewerDeleteAccountCommand  *application.ViewerDeleteAccountCommand
+	adminListUsersQuery         *application.AdminListUsersQuery
+	adminGetUserQuery           *application.AdminGetUserQuery
+	adminChangeRoleCommand      *application.AdminChangeRoleCommand
+	adminDisableUserCommand     *application.AdminDisableUserCommand
+	adminEnableUserCommand      *application.AdminEnableUserCommand
+	adminDeleteUserCommand      *application.AdminDeleteUserCommand
+	adminListDeletedUsersQuery  *application.AdminListDeletedUsersQuery
+	adminRestoreUserCommand     *application.AdminRestoreUserCommand
+	authorizer                  authz.IAuthorizer
+	routeGroups                 *middleware.RouteGroups
 }
 
 func NewHttp(
 	viewerGetProfileQuery *application.ViewerGetProfileQuery,
-	tokenService auth_domain.ITokenService,
+	viewerUpdateProfileCommand *application.ViewerUpdateProfileCommand,
+	viewerChangePasswordCommand *application.ViewerChangePasswordCommand,
+	viewerDeleteAccountCommand *application.ViewerDeleteAccountCommand,
+	adminListUsersQuery *application.AdminListUsersQuery,
+	adminGetUserQuery *application.AdminGetUserQuery,
+	adminChangeRoleCommand *application.AdminChangeRoleCommand,
+	adminDisableUserCommand *application.AdminDisableUserCommand,
+	adminEnableUserCommand *application.AdminEnableUserCommand,
+	adminDeleteUserCommand *application.AdminDeleteUserCommand,
+	adminListDeletedUsersQuery *application.AdminListDeletedUsersQuery,
+	adminRestoreUserCommand *application.AdminRestoreUserCommand,
+	authorizer authz.IAuthorizer,
+	routeGroups *middleware.RouteGroups,
 ) *Http {
 	return &Http{
-		viewerGetProfileQuery: viewerGetProfileQuery,
-		tokenService:          tokenService,
+		viewerGetProfileQuery:       viewerGetProfileQuery,
+		viewerUpdateProfileCommand:  viewerUpdateProfileCommand,
+		viewerChangePasswordCommand: viewerChangePasswordCommand,
+		viewerDeleteAccountCommand:  viewerDeleteAccountCommand,
+		adminListUsersQuery:         adminListUsersQuery,
+		adminGetUserQuery:           adminGetUserQuery,
+		adminChangeRoleCommand:      adminChangeRoleCommand,
+		adminDisableUserCommand:     adminDisableUserCommand,
+		adminEnableUserCommand:      adminEnableUserCommand,
+		adminDeleteUserCommand:      adminDeleteUserCommand,
+		adminListDeletedUsersQuery:  adminListDeletedUsersQuery,
+		adminRestoreUserCommand:     adminRestoreUserCommand,
+		authorizer:                  authorizer,
+		routeGroups:                 routeGroups,
 	}
 }
 
 func (h *Http) RegisterRoutes(router *gin.RouterGroup) {
-	accountGroup := router.Group("/v1/account")
-	accountGroup.Use(AuthMiddleware(h.tokenService))
+	accountGroup := h.routeGroups.Authenticated(router, "/v1/account")
 	{
 		accountGroup.GET("/profile", h.HandlerViewerGetProfile())
 	}
+	// Changing credentials or deleting the account needs a signed-in session,
+	// not an API key.
+	selfServiceGroup := h.routeGroups.Authenticated(router, "/v1/account")
+	selfServiceGroup.Use(middleware.DenyAPIKeys())
+	{
+		selfServiceGroup.PATCH("/profile", h.HandlerViewerUpdateProfile())
+		selfServiceGroup.POST("/password", h.HandlerViewerChangePassword())
+		selfServiceGroup.DELETE("", h.HandlerViewerDeleteAccount())
+	}
+
+	adminGroup := h.routeGroups.Authenticated(router, "/admin/v1/users")
+	{
+		adminGroup.GET("",
+			middleware.RequirePermission(h.authorizer, domain.PermissionRead),
+			h.HandlerAdminListUsers())
+		adminGroup.GET("/trash",
+			middleware.RequirePermission(h.authorizer, domain.PermissionRestore),
+			h.HandlerAdminListDeletedUsers())
+		adminGroup.GET("/:id",
+			middleware.RequirePermission(h.authorizer, domain.PermissionRead),
+			h.HandlerAdminGetUser())
+		adminGroup.PATCH("/:id/role",
+			middleware.RequirePermission(h.authorizer, domain.PermissionChangeRole),
+			h.HandlerAdminChangeRole())
+		adminGroup.POST("/:id/disable",
+			middleware.RequirePermission(h.authorizer, domain.PermissionDisable),
+			h.HandlerAdminDisableUser())
+		adminGroup.POST("/:id/enable",
+			middleware.RequirePermission(h.authorizer, domain.PermissionDisable),
+			h.HandlerAdminEnableUser())
+		adminGroup.DELETE("/:id",
+			middleware.RequirePermission(h.authorizer, domain.PermissionDelete),
+			h.HandlerAdminDeleteUser())
+		adminGroup.POST("/:id/restore",
+			middleware.RequirePermission(h.authorizer, domain.PermissionRestore),
+			h.HandlerAdminRestoreUser())
+	}
 }
@@ -1,44 +0,0 @@
-package http
-
-import (
-	"context"
-	"strings"
-
-	auth_domain "github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
-	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gin_comp"
-	"github.com/dukk308/beetool.dev-go-starter/pkgs/constants"
-	"github.com/gin-gonic/gin"
-)
-
-func AuthMiddleware(tokenService auth_domain.ITokenService) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			gin_comp.ResponseError(c, auth_domain.ErrInvalidToken)
-			c.Abort()
-			return
-		}
-
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			gin_comp.ResponseError(c, auth_domain.ErrInvalidToken)
-			c.Abort()
-			return
-		}
-
-		token := parts[1]
-		claims, err := tokenService.ValidateToken(token)
-		if err != nil {
-			gin_comp.ResponseError(c, err)
-			c.Abort()
-			return
-		}
-
-		c.Set("userID", claims.UserID)
-		c.Set("userEmail", claims.Email)
-		c.Set("userRole", claims.Role)
-		ctx := context.WithValue(c.Request.Context(), constants.ContextKeyUserID, claims.UserID)
-		c.Request = c.Request.WithContext(ctx)
-		c.Next()
-	}
-}
@@ -8,8 +8,12 @@ import (
 	"github.com/dukk308/beetool.dev-go-starter/internal/config"
 	"github.com/dukk308/beetool.dev-go-starter/internal/modules"
 	"github.com/dukk308/beetool.dev-go-starter/internal/validation"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/authz"
+	redis_component "github.com/dukk308/beetool.dev-go-starter/pkgs/components/cache_comp"
 	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gin_comp"
 	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gorm_comp"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/mailer_comp"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/outbox_comp"
 	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/swagger_comp"
 	"github.com/dukk308/beetool.dev-go-starter/pkgs/global_config"
 	"github.com/dukk308/beetool.dev-go-starter/pkgs/logger"
@@ -70,6 +74,11 @@ func Bootstrap(ctx context.Context) *fx.App {
 		fx.Invoke(registerValidation),
 		fx.Options(
 			gorm_comp.GormComponentFx,
+			outbox_comp.OutboxComponentFx,
+			fx.Provide(redis_component.ProvideRedisConfig),
+			redis_component.CacheComponent,
+			mailer_comp.MailerComponentFx,
+			authz.AuthzFx,
 			gin_comp.GinComponentFx,
 			swagger_comp.SwaggerComponentFx,
 			modules.FeatureModuleFx,
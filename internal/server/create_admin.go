@@ -0,0 +1,46 @@
+package server
+
+import (
+	"context"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/config"
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/auth"
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/user"
+	user_application "github.com/dukk308/beetool.dev-go-starter/internal/modules/user/application"
+	user_domain "github.com/dukk308/beetool.dev-go-starter/internal/modules/user/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/authz"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gorm_comp"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/outbox_comp"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/global_config"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/logger"
+	"go.uber.org/fx"
+)
+
+// CreateAdmin creates an admin account without starting the server, for
+// bootstrapping the first admin of a deployment. Only the constructors the
+// command needs are run, so Redis and the HTTP stack are not required.
+func CreateAdmin(ctx context.Context, dto *user_domain.DTOCreateUser) (*user_domain.Viewer, error) {
+	var admin *user_domain.Viewer
+
+	app := fx.New(
+		global_config.GlobalConfigFx,
+		logger.ZapModuleFx,
+		config.ConfigModuleFx,
+		fx.NopLogger,
+		gorm_comp.GormComponentFx,
+		outbox_comp.OutboxComponentFx,
+		authz.AuthzFx,
+		user.Module,
+		auth.Module,
+		fx.Invoke(func(command *user_application.CreateAdminCommand) error {
+			var err error
+			admin, err = command.Execute(ctx, dto)
+			return err
+		}),
+	)
+	if err := app.Err(); err != nil {
+		return nil, err
+	}
+
+	return admin, nil
+}
@@ -0,0 +1,42 @@
+package server
+
+import (
+	"context"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/config"
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/blog"
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/note"
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules/user"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gorm_comp"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/outbox_comp"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/global_config"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/logger"
+	"go.uber.org/fx"
+)
+
+type purgeTrashParams struct {
+	fx.In
+	Config  *config.Config
+	Log     logger.Logger
+	Purgers []*gorm_comp.TrashPurger `group:"trash_purgers"`
+}
+
+// PurgeTrash permanently deletes records that have been soft-deleted for
+// longer than the configured retention. It is meant to run on a schedule.
+func PurgeTrash(ctx context.Context) error {
+	app := fx.New(
+		global_config.GlobalConfigFx,
+		logger.ZapModuleFx,
+		config.ConfigModuleFx,
+		fx.NopLogger,
+		gorm_comp.GormComponentFx,
+		outbox_comp.OutboxComponentFx,
+		user.Module,
+		blog.Module,
+		note.Module,
+		fx.Invoke(func(p purgeTrashParams) error {
+			return gorm_comp.PurgeTrash(ctx, p.Purgers, p.Config.Trash.Retention, p.Log)
+		}),
+	)
+	return app.Err()
+}
@@ -0,0 +1,59 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/dukk308/beetool.dev-go-starter/internal/config"
+	"github.com/dukk308/beetool.dev-go-starter/internal/modules"
+	redis_component "github.com/dukk308/beetool.dev-go-starter/pkgs/components/cache_comp"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gorm_comp"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/otel_comp"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/outbox_comp"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/rabbitmq_comp"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/worker_comp"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/global_config"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/logger"
+	"go.uber.org/fx"
+)
+
+// workerStopMargin is the time left after draining the consumers for the
+// other components to stop.
+const workerStopMargin = 10 * time.Second
+
+func provideServiceName(globalConfig *global_config.GlobalConfig) string {
+	return globalConfig.ServiceName
+}
+
+// BootstrapWorker builds the background worker. It runs the consumers
+// registered by feature modules and relays domain events from the outbox to
+// RabbitMQ.
+func BootstrapWorker(ctx context.Context) *fx.App {
+	app := fx.New(
+		global_config.GlobalConfigFx,
+		logger.ZapModuleFx,
+		config.ConfigModuleFx,
+		fx.WithLogger(logger.ProvideFXEventLogger),
+		fx.StopTimeout(*worker_comp.WorkerDrainTimeout+workerStopMargin),
+		fx.Options(
+			fx.Provide(
+				fx.Annotate(
+					provideServiceName,
+					fx.ResultTags(`name:"serviceName"`),
+				),
+			),
+			otel_comp.TracerModule,
+			otel_comp.MeterModule,
+			gorm_comp.GormComponentFx,
+			fx.Provide(redis_component.ProvideRedisConfig),
+			redis_component.CacheComponent,
+			rabbitmq_comp.RabbitMQComponentFx,
+			outbox_comp.OutboxComponentFx,
+			outbox_comp.OutboxRelayFx,
+			worker_comp.WorkerComponentFx,
+			modules.ConsumerModuleFx,
+		),
+	)
+
+	return app
+}
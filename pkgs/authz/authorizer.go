@@ -0,0 +1,75 @@
+package authz
+
+import (
+	"fmt"
+
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/types"
+)
+
+// Suffixes of resource permissions: "blog:update:any" allows updating every
+// post, "blog:update:own" only the posts the user owns.
+const (
+	ScopeAny = "any"
+	ScopeOwn = "own"
+)
+
+var (
+	ErrAuthenticationRequired = base.NewUnauthorizedError("authentication required")
+	ErrPermissionDenied       = base.NewForbiddenError("insufficient permissions")
+)
+
+// IAuthorizer answers permission checks for the authenticated user. Handlers
+// and commands ask for permissions, never for roles, so roles can be added or
+// changed through the policy alone.
+type IAuthorizer interface {
+	Can(user *types.UserAuthenticated, permission string) bool
+	// Authorize returns a DomainError when the user lacks permission.
+	Authorize(user *types.UserAuthenticated, permission string) error
+	// CanOnResource checks permission on a resource owned by ownerID: either
+	// "<permission>:any" or, for the owner, "<permission>:own".
+	CanOnResource(user *types.UserAuthenticated, permission, ownerID string) bool
+	AuthorizeResource(user *types.UserAuthenticated, permission, ownerID string) error
+}
+
+type Authorizer struct {
+	policy *Policy
+}
+
+func NewAuthorizer(policy *Policy) *Authorizer {
+	return &Authorizer{
+		policy: policy,
+	}
+}
+
+func (a *Authorizer) Can(user *types.UserAuthenticated, permission string) bool {
+	return user != nil && a.policy.Grants(user.GetRole(), permission)
+}
+
+func (a *Authorizer) Authorize(user *types.UserAuthenticated, permission string) error {
+	if user == nil {
+		return ErrAuthenticationRequired
+	}
+	if !a.Can(user, permission) {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
+func (a *Authorizer) CanOnResource(user *types.UserAuthenticated, permission, ownerID string) bool {
+	if a.Can(user, fmt.Sprintf("%s:%s", permission, ScopeAny)) {
+		return true
+	}
+	return user != nil && ownerID != "" && ownerID == user.GetID() &&
+		a.Can(user, fmt.Sprintf("%s:%s", permission, ScopeOwn))
+}
+
+func (a *Authorizer) AuthorizeResource(user *types.UserAuthenticated, permission, ownerID string) error {
+	if user == nil {
+		return ErrAuthenticationRequired
+	}
+	if !a.CanOnResource(user, permission, ownerID) {
+		return ErrPermissionDenied
+	}
+	return nil
+}
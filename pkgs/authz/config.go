@@ -0,0 +1,7 @@
+package authz
+
+type AuthzConfig struct {
+	// PolicyFile is a JSON role to permissions policy. DefaultPolicy applies
+	// while it is empty.
+	PolicyFile string
+}
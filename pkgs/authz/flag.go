@@ -0,0 +1,25 @@
+package authz
+
+import (
+	"flag"
+)
+
+var (
+	authzPolicyFileVal string
+)
+
+var (
+	AuthzPolicyFile = &authzPolicyFileVal
+)
+
+func init() {
+	if flag.Lookup("authz-policy-file") == nil {
+		flag.StringVar(&authzPolicyFileVal, "authz-policy-file", "", "JSON file mapping roles to permissions, built-in policy when empty")
+	}
+}
+
+func LoadAuthzConfig() *AuthzConfig {
+	return &AuthzConfig{
+		PolicyFile: *AuthzPolicyFile,
+	}
+}
@@ -0,0 +1,27 @@
+package authz
+
+import (
+	"go.uber.org/fx"
+)
+
+func ProvideAuthzConfig() *AuthzConfig {
+	return LoadAuthzConfig()
+}
+
+func ProvidePolicy(config *AuthzConfig) (*Policy, error) {
+	if config.PolicyFile == "" {
+		return DefaultPolicy(), nil
+	}
+	return LoadPolicy(config.PolicyFile)
+}
+
+var AuthzFx = fx.Module("authz",
+	fx.Provide(ProvideAuthzConfig),
+	fx.Provide(ProvidePolicy),
+	fx.Provide(
+		fx.Annotate(
+			NewAuthorizer,
+			fx.As(new(IAuthorizer)),
+		),
+	),
+)
@@ -0,0 +1,103 @@
+package authz
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Wildcard grants every permission, or every permission below a prefix when
+// used as the last segment ("blog:*").
+const Wildcard = "*"
+
+// Policy maps role names to the permissions they grant. Permissions are
+// colon-separated names such as "blog:publish" or "note:delete:any".
+type Policy struct {
+	Roles map[string][]string `json:"roles"`
+}
+
+// DefaultPolicy is used when no policy file is configured.
+func DefaultPolicy() *Policy {
+	return &Policy{
+		Roles: map[string][]string{
+			"admin": {Wildcard},
+			"editor": {
+				"blog:create",
+				"blog:read:own",
+				"blog:update:own",
+			},
+			"viewer": {},
+		},
+	}
+}
+
+// LoadPolicy reads a JSON policy file of the form
+// {"roles": {"admin": ["*"], "editor": ["blog:create"]}}.
+func LoadPolicy(path string) (*Policy, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read authz policy: %w", err)
+	}
+
+	var policy Policy
+	if err := json.Unmarshal(raw, &policy); err != nil {
+		return nil, fmt.Errorf("parse authz policy %s: %w", path, err)
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid authz policy %s: %w", path, err)
+	}
+
+	return &policy, nil
+}
+
+func (p *Policy) Validate() error {
+	if len(p.Roles) == 0 {
+		return fmt.Errorf("no roles defined")
+	}
+
+	for role, permissions := range p.Roles {
+		if strings.TrimSpace(role) == "" {
+			return fmt.Errorf("empty role name")
+		}
+		for _, permission := range permissions {
+			if permission == "" || strings.ContainsAny(permission, " \t") {
+				return fmt.Errorf("role %q: invalid permission %q", role, permission)
+			}
+			segments := strings.Split(permission, ":")
+			for i, segment := range segments {
+				if segment == "" || (segment == Wildcard && i != len(segments)-1) {
+					return fmt.Errorf("role %q: invalid permission %q", role, permission)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// HasRole reports whether role is defined by the policy.
+func (p *Policy) HasRole(role string) bool {
+	_, ok := p.Roles[role]
+	return ok
+}
+
+// Grants reports whether role has permission, directly or through a wildcard.
+func (p *Policy) Grants(role, permission string) bool {
+	for _, granted := range p.Roles[role] {
+		if matchPermission(granted, permission) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchPermission(granted, permission string) bool {
+	if granted == Wildcard || granted == permission {
+		return true
+	}
+
+	prefix, ok := strings.CutSuffix(granted, ":"+Wildcard)
+	return ok && strings.HasPrefix(permission, prefix+":")
+}
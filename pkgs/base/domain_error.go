@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -15,6 +16,9 @@ type DomainError struct {
 	Field      string      `json:"field,omitempty"`
 	Detail     interface{} `json:"details,omitempty"`
 	Err        error       `json:"-"`
+	// RetryAfter tells the client when the request may be retried, sent as
+	// the Retry-After header.
+	RetryAfter time.Duration `json:"-"`
 }
 
 func (e *DomainError) Error() string {
@@ -52,6 +56,7 @@ func (e *DomainError) Wrap(err error) *DomainError {
 		Field:      e.Field,
 		Detail:     e.Detail,
 		Err:        err,
+		RetryAfter: e.RetryAfter,
 	}
 }
 
@@ -227,14 +232,19 @@ func extractFieldFromConstraintError(errMsg string) string {
 type ErrorCode string
 
 const (
-	ErrorCodeValidation   ErrorCode = "VALIDATION_ERROR"
-	ErrorCodeNotFound     ErrorCode = "NOT_FOUND"
-	ErrorCodeUnauthorized ErrorCode = "UNAUTHORIZED"
-	ErrorCodeForbidden    ErrorCode = "FORBIDDEN"
-	ErrorCodeConflict     ErrorCode = "CONFLICT"
-	ErrorCodeInternal     ErrorCode = "INTERNAL_ERROR"
-	ErrorCodeInvalidInput ErrorCode = "INVALID_INPUT"
-	ErrorCodeBusinessRule ErrorCode = "BAD_REQUEST"
+	ErrorCodeValidation      ErrorCode = "VALIDATION_ERROR"
+	ErrorCodeNotFound        ErrorCode = "NOT_FOUND"
+	ErrorCodeUnauthorized    ErrorCode = "UNAUTHORIZED"
+	ErrorCodeForbidden       ErrorCode = "FORBIDDEN"
+	ErrorCodeConflict        ErrorCode = "CONFLICT"
+	ErrorCodeInternal        ErrorCode = "INTERNAL_ERROR"
+	ErrorCodeInvalidInput    ErrorCode = "INVALID_INPUT"
+	ErrorCodeBusinessRule    ErrorCode = "BAD_REQUEST"
+	ErrorCodeTooManyRequests ErrorCode = "TOO_MANY_REQUESTS"
+	// ErrorCodePreconditionFailed and ErrorCodePreconditionRequired answer
+	// conditional requests whose If-Match is stale or missing.
+	ErrorCodePreconditionFailed   ErrorCode = "PRECONDITION_FAILED"
+	ErrorCodePreconditionRequired ErrorCode = "PRECONDITION_REQUIRED"
 )
 
 func NewValidationError(message string) *DomainError {
@@ -300,3 +310,29 @@ func NewBusinessRuleError(message string) *DomainError {
 		422,
 	)
 }
+
+func NewTooManyRequestsError(message string, retryAfter time.Duration) *DomainError {
+	err := NewDomainError(
+		message,
+		string(ErrorCodeTooManyRequests),
+		429,
+	)
+	err.RetryAfter = retryAfter
+	return err
+}
+
+func NewPreconditionFailedError(message string) *DomainError {
+	return NewDomainError(
+		message,
+		string(ErrorCodePreconditionFailed),
+		412,
+	)
+}
+
+func NewPreconditionRequiredError(message string) *DomainError {
+	return NewDomainError(
+		message,
+		string(ErrorCodePreconditionRequired),
+		428,
+	)
+}
@@ -0,0 +1,21 @@
+package base
+
+// DomainEvent is a fact about an aggregate that other services may react to.
+// Aggregates record events; the repository saves them to the outbox in the
+// same transaction as the aggregate, and the worker publishes them.
+type DomainEvent interface {
+	// EventName is the event type and routing key, e.g. "blog.published".
+	EventName() string
+}
+
+// RecordEvent queues an event to be saved with the aggregate.
+func (m *BaseModel) RecordEvent(event DomainEvent) {
+	m.events = append(m.events, event)
+}
+
+// PullEvents returns the recorded events and forgets them.
+func (m *BaseModel) PullEvents() []DomainEvent {
+	events := m.events
+	m.events = nil
+	return events
+}
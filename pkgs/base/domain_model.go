@@ -6,21 +6,41 @@ import (
 	"github.com/google/uuid"
 )
 
+// Errors of optimistic concurrency control on versioned entities.
+var (
+	ErrVersionMismatch  = NewPreconditionFailedError("resource has changed since it was read")
+	ErrConcurrentUpdate = NewConflictError("resource was modified concurrently")
+)
+
+// BaseModel is embedded by every entity. Version starts at 1 and is bumped by
+// every conditional update.
 type BaseModel struct {
 	ID        uuid.UUID  `json:"id"`
+	Version   int64      `json:"version"`
 	CreatedAt *time.Time `json:"createdAt"`
 	UpdatedAt *time.Time `json:"updatedAt"`
 	DeletedAt *time.Time `json:"deletedAt,omitempty"`
 	CreatedBy *string    `json:"createdBy,omitempty"`
 	UpdatedBy *string    `json:"updatedBy,omitempty"`
 	DeletedBy *string    `json:"deletedBy,omitempty"`
+
+	events []DomainEvent
 }
 
 func GenerateBaseModel() *BaseModel {
 	now := time.Now()
 	return &BaseModel{
 		ID:        uuid.New(),
+		Version:   1,
 		CreatedAt: &now,
 		UpdatedAt: &now,
 	}
 }
+
+// MatchVersion checks the version a client last read; nil matches any.
+func (m *BaseModel) MatchVersion(expected *int64) error {
+	if expected != nil && *expected != m.Version {
+		return ErrVersionMismatch
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+// Package cachetest provides an in-memory cache for tests of code written
+// against redis_component.ICacheService.
+package cachetest
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	redis_component "github.com/dukk308/beetool.dev-go-starter/pkgs/components/cache_comp"
+)
+
+// MemoryCache implements the Redis commands of ICacheService used by the
+// storages, in memory and with the same values and TTL results as Redis. The
+// other methods panic.
+type MemoryCache struct {
+	redis_component.ICacheService
+
+	mu       sync.Mutex
+	values   map[string]string
+	sets     map[string]map[string]struct{}
+	expireAt map[string]time.Time
+}
+
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{
+		values:   map[string]string{},
+		sets:     map[string]map[string]struct{}{},
+		expireAt: map[string]time.Time{},
+	}
+}
+
+// expire drops key when its TTL has passed. It is called with mu held.
+func (c *MemoryCache) expire(key string) {
+	if at, ok := c.expireAt[key]; ok && !time.Now().Before(at) {
+		delete(c.values, key)
+		delete(c.sets, key)
+		delete(c.expireAt, key)
+	}
+}
+
+func (c *MemoryCache) exists(key string) bool {
+	c.expire(key)
+	_, isValue := c.values[key]
+	_, isSet := c.sets[key]
+	return isValue || isSet
+}
+
+func (c *MemoryCache) Get(ctx context.Context, key string) (*string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.expire(key)
+	value, ok := c.values[key]
+	if !ok {
+		return nil, nil
+	}
+	return &value, nil
+}
+
+func (c *MemoryCache) GetDel(ctx context.Context, key string) (*string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.expire(key)
+	value, ok := c.values[key]
+	if !ok {
+		return nil, nil
+	}
+	delete(c.values, key)
+	delete(c.expireAt, key)
+	return &value, nil
+}
+
+func (c *MemoryCache) SetEx(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	var encoded string
+	switch v := value.(type) {
+	case string:
+		encoded = v
+	case []byte:
+		encoded = string(v)
+	default:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		encoded = string(data)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.values[key] = encoded
+	c.expireAt[key] = time.Now().Add(expiration)
+	return nil
+}
+
+func (c *MemoryCache) Delete(ctx context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		delete(c.values, key)
+		delete(c.sets, key)
+		delete(c.expireAt, key)
+	}
+	return nil
+}
+
+func (c *MemoryCache) IsExist(ctx context.Context, key string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.exists(key), nil
+}
+
+func (c *MemoryCache) Incr(ctx context.Context, key string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.expire(key)
+	var n int64
+	if value, ok := c.values[key]; ok {
+		var err error
+		if n, err = strconv.ParseInt(value, 10, 64); err != nil {
+			return 0, err
+		}
+	}
+	n++
+	c.values[key] = strconv.FormatInt(n, 10)
+	return n, nil
+}
+
+func (c *MemoryCache) Expire(ctx context.Context, key string, expiration time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.exists(key) {
+		c.expireAt[key] = time.Now().Add(expiration)
+	}
+	return nil
+}
+
+// TTL returns -2 for a missing key and -1 for a key without expiry, like
+// Redis.
+func (c *MemoryCache) TTL(ctx context.Context, key string) (time.Duration, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.exists(key) {
+		return -2, nil
+	}
+	at, ok := c.expireAt[key]
+	if !ok {
+		return -1, nil
+	}
+	return time.Until(at), nil
+}
+
+func (c *MemoryCache) SAdd(ctx context.Context, key string, members ...interface{}) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.expire(key)
+	set, ok := c.sets[key]
+	if !ok {
+		set = map[string]struct{}{}
+		c.sets[key] = set
+	}
+	var added int64
+	for _, member := range members {
+		name := member.(string)
+		if _, ok := set[name]; !ok {
+			set[name] = struct{}{}
+			added++
+		}
+	}
+	return added, nil
+}
+
+func (c *MemoryCache) SRem(ctx context.Context, key string, members ...interface{}) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.expire(key)
+	var removed int64
+	for _, member := range members {
+		name := member.(string)
+		if _, ok := c.sets[key][name]; ok {
+			delete(c.sets[key], name)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+func (c *MemoryCache) SMembers(ctx context.Context, key string) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.expire(key)
+	members := make([]string, 0, len(c.sets[key]))
+	for member := range c.sets[key] {
+		members = append(members, member)
+	}
+	return members, nil
+}
@@ -3,6 +3,7 @@ package redis_component
 import (
 	"context"
 
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/global_config"
 	"github.com/dukk308/beetool.dev-go-starter/pkgs/logger"
 	"go.uber.org/fx"
 )
@@ -24,6 +25,10 @@ var (
 	)
 )
 
+func ProvideRedisConfig(globalConfig *global_config.GlobalConfig) *RedisConfig {
+	return LoadRedisConfig(globalConfig.ServiceName)
+}
+
 func ProvideCacheService(redisComponent *RedisComponent) ICacheService {
 	return redisComponent.GetClient()
 }
@@ -81,6 +81,21 @@ func (r *RedisClient) Get(ctx context.Context, key string) (*string, error) {
 	return &val, nil
 }
 
+// GetDel returns the value of key and deletes it atomically, nil when missing.
+func (r *RedisClient) GetDel(ctx context.Context, key string) (*string, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	val, err := r.c.GetDel(ctx, r.Key(key)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &val, nil
+}
+
 func (r *RedisClient) marshal(value interface{}) (string, error) {
 	var strValue string
 
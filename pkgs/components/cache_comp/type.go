@@ -11,6 +11,7 @@ import (
 type ICacheService interface {
 	Get(context context.Context, key string) (*string, error)
 	MustGet(context context.Context, key string) (*string, error)
+	GetDel(context context.Context, key string) (*string, error)
 	Set(context context.Context, key string, value interface{}) error
 	Delete(context context.Context, key ...string) error
 	Close() error
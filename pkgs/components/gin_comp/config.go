@@ -5,5 +5,8 @@ type GinConfig struct {
 	Mode string
 	Prefix string
 	EnableTracer bool
+	// TrustedProxies are the addresses or CIDRs whose X-Forwarded-For and
+	// X-Real-IP headers are believed by ClientIP. None are trusted when empty.
+	TrustedProxies []string
     ServiceName string
 }
@@ -2,22 +2,25 @@ package gin_comp
 
 import (
 	"flag"
+	"strings"
 
 	"github.com/dukk308/beetool.dev-go-starter/pkgs/global_config"
 )
 
 var (
-	ginPortVal      string
-	ginModeVal      string
-	ginPrefixVal    string
-	enableTracerVal bool
+	ginPortVal        string
+	ginModeVal        string
+	ginPrefixVal      string
+	enableTracerVal   bool
+	trustedProxiesVal string
 )
 
 var (
-	ginPort      = &ginPortVal
-	ginMode      = &ginModeVal
-	ginPrefix    = &ginPrefixVal
-	enableTracer = &enableTracerVal
+	ginPort        = &ginPortVal
+	ginMode        = &ginModeVal
+	ginPrefix      = &ginPrefixVal
+	enableTracer   = &enableTracerVal
+	trustedProxies = &trustedProxiesVal
 )
 
 func init() {
@@ -33,14 +36,28 @@ func init() {
 	if flag.Lookup("enable-tracer") == nil {
 		flag.BoolVar(&enableTracerVal, "enable-tracer", false, "enable tracer. Default false")
 	}
+	if flag.Lookup("gin-trusted-proxies") == nil {
+		flag.StringVar(&trustedProxiesVal, "gin-trusted-proxies", "", "Comma-separated proxy addresses or CIDRs allowed to set the client IP with X-Forwarded-For, e.g. 10.0.0.0/8. None by default")
+	}
 }
 
 func LoadGinConfig(global_config *global_config.GlobalConfig) *GinConfig {
 	return &GinConfig{
-		Port:         *ginPort,
-		Mode:         *ginMode,
-		Prefix:       *ginPrefix,
-		EnableTracer: *enableTracer,
-		ServiceName:  global_config.ServiceName,
+		Port:           *ginPort,
+		Mode:           *ginMode,
+		Prefix:         *ginPrefix,
+		EnableTracer:   *enableTracer,
+		TrustedProxies: splitList(*trustedProxies),
+		ServiceName:    global_config.ServiceName,
+	}
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
 	}
+	return items
 }
@@ -17,7 +17,7 @@ type GinEngine struct {
 	group  *gin.RouterGroup
 }
 
-func NewGinComp(logger logger.Logger, config *GinConfig) *GinEngine {
+func NewGinComp(logger logger.Logger, config *GinConfig) (*GinEngine, error) {
 	engine := &GinEngine{
 		logger: logger,
 		config: config,
@@ -34,13 +34,18 @@ func NewGinComp(logger logger.Logger, config *GinConfig) *GinEngine {
 	}
 
 	engine.router = gin.New()
+	// gin trusts every proxy by default, which lets any client pick the IP
+	// that sign-in throttling and sessions record.
+	if err := engine.router.SetTrustedProxies(config.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("invalid gin-trusted-proxies: %w", err)
+	}
 	engine.group = engine.router.Group(config.Prefix)
 
 	if config.EnableTracer {
 		engine.withInstrumentation()
 	}
 
-	return engine
+	return engine, nil
 }
 
 func (gs *GinEngine) GetConfig() *GinConfig {
@@ -0,0 +1,46 @@
+package gin_comp
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	ErrIfMatchRequired = base.NewPreconditionRequiredError("If-Match header is required")
+	ErrIfMatchInvalid  = base.NewPreconditionFailedError("If-Match must be a single ETag or *")
+)
+
+// SetETag sends the entity version as a strong ETag.
+func SetETag(c *gin.Context, version int64) {
+	c.Header("ETag", `"`+strconv.FormatInt(version, 10)+`"`)
+}
+
+// IfMatchVersion reads the version a conditional request expects from
+// If-Match. It returns nil for "*", which matches any version.
+func IfMatchVersion(c *gin.Context) (*int64, error) {
+	value := strings.TrimSpace(c.GetHeader("If-Match"))
+	if value == "" {
+		return nil, ErrIfMatchRequired
+	}
+	if value == "*" {
+		return nil, nil
+	}
+
+	// Versions compare strongly, so weak ETags never match.
+	unquoted, ok := strings.CutPrefix(value, `"`)
+	if !ok {
+		return nil, ErrIfMatchInvalid
+	}
+	unquoted, ok = strings.CutSuffix(unquoted, `"`)
+	if !ok {
+		return nil, ErrIfMatchInvalid
+	}
+	version, err := strconv.ParseInt(unquoted, 10, 64)
+	if err != nil {
+		return nil, ErrIfMatchInvalid
+	}
+	return &version, nil
+}
@@ -1,7 +1,9 @@
 package gin_comp
 
 import (
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/dukk308/beetool.dev-go-starter/internal/common"
 	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
@@ -15,6 +17,9 @@ func ResponseError(c *gin.Context, err error) {
 	if base.IsDomainError(err) {
 		domainErr := base.ToDomainError(err)
 		statusCode := getStatusCodeForError(domainErr.Code)
+		if domainErr.RetryAfter > 0 {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(domainErr.RetryAfter.Seconds()))))
+		}
 		c.JSON(statusCode, domainErr)
 		return
 	}
@@ -54,6 +59,12 @@ func getStatusCodeForError(code string) int {
 		return http.StatusConflict
 	case string(base.ErrorCodeBusinessRule):
 		return http.StatusUnprocessableEntity
+	case string(base.ErrorCodeTooManyRequests):
+		return http.StatusTooManyRequests
+	case string(base.ErrorCodePreconditionFailed):
+		return http.StatusPreconditionFailed
+	case string(base.ErrorCodePreconditionRequired):
+		return http.StatusPreconditionRequired
 	default:
 		return http.StatusInternalServerError
 	}
@@ -0,0 +1,55 @@
+package gin_comp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/global_config"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/logger"
+	log_cfg "github.com/dukk308/beetool.dev-go-starter/pkgs/logger/config"
+	"github.com/gin-gonic/gin"
+)
+
+// clientIP returns the client IP gin sees for a request from remoteAddr that
+// claims to be forwarded for 203.0.113.9.
+func clientIP(t *testing.T, trustedProxies []string, remoteAddr string) string {
+	t.Helper()
+
+	log := logger.NewZapLogger(&log_cfg.LogOptions{}, &global_config.GlobalConfig{LogLevel: "fatal"})
+	engine, err := NewGinComp(log, &GinConfig{Mode: "release", TrustedProxies: trustedProxies})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ip string
+	engine.GetRouter().GET("/ip", func(c *gin.Context) { ip = c.ClientIP() })
+	req := httptest.NewRequest(http.MethodGet, "/ip", nil)
+	req.RemoteAddr = remoteAddr
+	req.Header.Set("X-Forwarded-For", "203.0.113.9")
+	engine.GetRouter().ServeHTTP(httptest.NewRecorder(), req)
+	return ip
+}
+
+func TestClientIPIgnoresForwardedForWithoutTrustedProxies(t *testing.T) {
+	if ip := clientIP(t, nil, "192.0.2.1:1234"); ip != "192.0.2.1" {
+		t.Fatalf("client IP = %s, want the peer address", ip)
+	}
+}
+
+func TestClientIPBelievesTrustedProxies(t *testing.T) {
+	trusted := []string{"10.0.0.0/8"}
+	if ip := clientIP(t, trusted, "10.1.2.3:1234"); ip != "203.0.113.9" {
+		t.Fatalf("client IP behind a trusted proxy = %s, want the forwarded address", ip)
+	}
+	if ip := clientIP(t, trusted, "192.0.2.1:1234"); ip != "192.0.2.1" {
+		t.Fatalf("client IP behind an untrusted peer = %s, want the peer address", ip)
+	}
+}
+
+func TestNewGinCompRejectsInvalidTrustedProxies(t *testing.T) {
+	log := logger.NewZapLogger(&log_cfg.LogOptions{}, &global_config.GlobalConfig{LogLevel: "fatal"})
+	if _, err := NewGinComp(log, &GinConfig{Mode: "release", TrustedProxies: []string{"not-an-ip"}}); err == nil {
+		t.Fatal("invalid trusted proxy accepted")
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"reflect"
 
-	"github.com/dukk308/beetool.dev-go-starter/pkgs/constants"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/types"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
 )
 
 func registerAuditHook(db *gorm.DB) {
@@ -18,15 +20,12 @@ func getUserIDFromContext(ctx context.Context) *string {
 	if ctx == nil {
 		return nil
 	}
-	v := ctx.Value(constants.ContextKeyUserID)
-	if v == nil {
+	user, ok := types.UserFromContext(ctx)
+	if !ok || user.GetID() == "" {
 		return nil
 	}
-	s, ok := v.(string)
-	if !ok || s == "" {
-		return nil
-	}
-	return &s
+	id := user.GetID()
+	return &id
 }
 
 func setAuditField(dest interface{}, fieldName string, value *string) {
@@ -84,9 +83,68 @@ func auditBeforeDelete(db *gorm.DB) {
 	}
 	dest := db.Statement.Dest
 	setAuditField(dest, "DeletedBy", userID)
-	if db.Statement.Schema != nil {
-		if f, ok := db.Statement.Schema.FieldsByDBName["deleted_by"]; ok && f != nil {
-			db.Statement.SetColumn("deleted_by", *userID)
+	if db.Statement.Schema == nil {
+		return
+	}
+	deletedBy, ok := db.Statement.Schema.FieldsByDBName["deleted_by"]
+	if !ok || deletedBy == nil {
+		return
+	}
+	if deletedAt := softDeleteField(db.Statement.Schema); deletedAt != nil && !db.Statement.Unscoped {
+		buildSoftDelete(db, deletedAt, deletedBy, *userID)
+	}
+}
+
+func softDeleteField(s *schema.Schema) *schema.Field {
+	f, ok := s.FieldsByDBName["deleted_at"]
+	if !ok || f.FieldType != reflect.TypeOf(gorm.DeletedAt{}) {
+		return nil
+	}
+	return f
+}
+
+// buildSoftDelete builds the soft delete UPDATE itself, because gorm's soft
+// delete clause only sets deleted_at. The delete callback then runs this
+// statement instead of building its own.
+func buildSoftDelete(db *gorm.DB, deletedAt, deletedBy *schema.Field, userID string) {
+	stmt := db.Statement
+	if stmt.SQL.Len() > 0 {
+		return
+	}
+
+	now := db.NowFunc()
+	stmt.AddClause(clause.Set{
+		{Column: clause.Column{Name: deletedAt.DBName}, Value: now},
+		{Column: clause.Column{Name: deletedBy.DBName}, Value: userID},
+	})
+	stmt.SetColumn(deletedAt.DBName, now, true)
+	stmt.SetColumn(deletedBy.DBName, userID, true)
+
+	// Deleting a loaded record targets its primary key, as gorm does.
+	if conds := primaryKeyConditions(stmt); len(conds) > 0 {
+		stmt.AddClause(clause.Where{Exprs: conds})
+	}
+
+	gorm.SoftDeleteQueryClause{Field: deletedAt}.ModifyStatement(stmt)
+	stmt.AddClauseIfNotExists(clause.Update{})
+	stmt.Build(db.Callback().Update().Clauses...)
+}
+
+// primaryKeyConditions matches the loaded records of the statement by primary
+// key, the way gorm targets them on update and delete.
+func primaryKeyConditions(stmt *gorm.Statement) []clause.Expression {
+	var conds []clause.Expression
+	_, queryValues := schema.GetIdentityFieldValuesMap(stmt.Context, stmt.ReflectValue, stmt.Schema.PrimaryFields)
+	column, values := schema.ToQueryValues(stmt.Table, stmt.Schema.PrimaryFieldDBNames, queryValues)
+	if len(values) > 0 {
+		conds = append(conds, clause.IN{Column: column, Values: values})
+	}
+	if stmt.ReflectValue.CanAddr() && stmt.Dest != stmt.Model && stmt.Model != nil {
+		_, queryValues = schema.GetIdentityFieldValuesMap(stmt.Context, reflect.ValueOf(stmt.Model), stmt.Schema.PrimaryFields)
+		column, values = schema.ToQueryValues(stmt.Table, stmt.Schema.PrimaryFieldDBNames, queryValues)
+		if len(values) > 0 {
+			conds = append(conds, clause.IN{Column: column, Values: values})
 		}
 	}
+	return conds
 }
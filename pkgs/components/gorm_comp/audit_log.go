@@ -0,0 +1,330 @@
+package gorm_comp
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/utils/request_id"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/plugin/dbresolver"
+)
+
+// Auditable is implemented by models whose changes are recorded in
+// audit_logs. AuditEntity names the entity in the log, e.g. "blog".
+type Auditable interface {
+	AuditEntity() string
+}
+
+// AuditRedacted is implemented by auditable models with secret columns. The
+// log records that such a column changed, never its value.
+type AuditRedacted interface {
+	AuditRedactedColumns() []string
+}
+
+const (
+	AuditActionCreate  = "create"
+	AuditActionUpdate  = "update"
+	AuditActionDelete  = "delete"
+	AuditActionRestore = "restore"
+	AuditActionPurge   = "purge"
+)
+
+const (
+	auditSnapshotKey   = "audit:snapshot"
+	auditRedactedValue = "[redacted]"
+)
+
+// auditIgnoredColumns change on every write and repeat the actor and time of
+// the log entry itself.
+var auditIgnoredColumns = map[string]bool{
+	"updated_at": true,
+	"updated_by": true,
+}
+
+// AuditLog is one recorded change of an auditable row. Changes maps each
+// changed column to its before and after value.
+type AuditLog struct {
+	ID        string    `gorm:"column:id;primaryKey"`
+	ActorID   *string   `gorm:"column:actor_id;index:idx_audit_logs_actor_id,priority:1"`
+	Entity    string    `gorm:"column:entity;not null;index:idx_audit_logs_entity,priority:1"`
+	EntityID  string    `gorm:"column:entity_id;not null;index:idx_audit_logs_entity,priority:2"`
+	Action    string    `gorm:"column:action;not null"`
+	Changes   string    `gorm:"column:changes;type:jsonb;not null"`
+	RequestID *string   `gorm:"column:request_id"`
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamp without time zone;not null;index:idx_audit_logs_actor_id,priority:2;index:idx_audit_logs_entity,priority:3"`
+}
+
+func (l *AuditLog) TableName() string {
+	return "audit_logs"
+}
+
+type AuditChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// registerAuditTrail writes an audit log entry for every row of an auditable
+// model that is created, updated or deleted. Entries are written in the
+// statement's transaction, so a failed entry rolls the change back.
+func registerAuditTrail(db *gorm.DB) {
+	db.Callback().Create().After("gorm:create").Register("audit:trail_create", auditTrailAfterCreate)
+	db.Callback().Update().Before("gorm:update").Register("audit:trail_snapshot_update", auditTrailSnapshot)
+	db.Callback().Update().After("gorm:update").Register("audit:trail_update", auditTrailAfterUpdate)
+	db.Callback().Delete().Before("gorm:delete").Register("audit:trail_snapshot_delete", auditTrailSnapshot)
+	db.Callback().Delete().After("gorm:delete").Register("audit:trail_delete", auditTrailAfterDelete)
+}
+
+type auditTarget struct {
+	entity     string
+	primaryKey string
+	redacted   map[string]bool
+}
+
+func auditTargetOf(stmt *gorm.Statement) *auditTarget {
+	if stmt.Schema == nil || stmt.Schema.PrioritizedPrimaryField == nil {
+		return nil
+	}
+	model := reflect.New(stmt.Schema.ModelType).Interface()
+	auditable, ok := model.(Auditable)
+	if !ok {
+		return nil
+	}
+
+	target := &auditTarget{
+		entity:     auditable.AuditEntity(),
+		primaryKey: stmt.Schema.PrioritizedPrimaryField.DBName,
+		redacted:   map[string]bool{},
+	}
+	if redacted, ok := model.(AuditRedacted); ok {
+		for _, column := range redacted.AuditRedactedColumns() {
+			target.redacted[column] = true
+		}
+	}
+	return target
+}
+
+// auditSession reads and writes on the statement's connection, inside its
+// transaction and never on a replica.
+func auditSession(db *gorm.DB) *gorm.DB {
+	return db.Session(&gorm.Session{NewDB: true, SkipHooks: true}).Clauses(dbresolver.Write)
+}
+
+// auditTrailSnapshot loads the rows an update or delete is about to change.
+func auditTrailSnapshot(db *gorm.DB) {
+	if db.Error != nil || auditTargetOf(db.Statement) == nil {
+		return
+	}
+	stmt := db.Statement
+
+	var conds []clause.Expression
+	if c, ok := stmt.Clauses["WHERE"]; ok {
+		if where, ok := c.Expression.(clause.Where); ok {
+			conds = append(conds, where.Exprs...)
+		}
+	}
+	conds = append(conds, primaryKeyConditions(stmt)...)
+	if len(conds) == 0 {
+		return
+	}
+
+	query := auditSession(db).Table(stmt.Table)
+	if stmt.Unscoped {
+		query = query.Unscoped()
+	}
+	var rows []map[string]any
+	if err := query.Clauses(clause.Where{Exprs: conds}).Find(&rows).Error; err != nil {
+		db.AddError(fmt.Errorf("audit snapshot: %w", err))
+		return
+	}
+	db.InstanceSet(auditSnapshotKey, rows)
+}
+
+func auditTrailAfterCreate(db *gorm.DB) {
+	target := auditTargetOf(db.Statement)
+	if db.Error != nil || db.RowsAffected == 0 || target == nil {
+		return
+	}
+	after, err := auditLoad(db, target, primaryKeyValues(db.Statement))
+	if err != nil {
+		db.AddError(err)
+		return
+	}
+
+	logs := make([]*AuditLog, 0, len(after))
+	for id, row := range after {
+		if log := newAuditLog(db, target, id, AuditActionCreate, nil, row); log != nil {
+			logs = append(logs, log)
+		}
+	}
+	writeAuditLogs(db, logs)
+}
+
+func auditTrailAfterUpdate(db *gorm.DB) {
+	auditTrailAfterChange(db, AuditActionUpdate)
+}
+
+func auditTrailAfterDelete(db *gorm.DB) {
+	auditTrailAfterChange(db, AuditActionDelete)
+}
+
+// auditTrailAfterChange compares the snapshot with the rows as they are now.
+// Restores and soft deletes done through an update are recorded as such, and
+// a row that is gone after a delete was purged if the model supports the
+// trash.
+func auditTrailAfterChange(db *gorm.DB, action string) {
+	target := auditTargetOf(db.Statement)
+	if db.Error != nil || db.RowsAffected == 0 || target == nil {
+		return
+	}
+	value, ok := db.InstanceGet(auditSnapshotKey)
+	if !ok {
+		return
+	}
+	before := value.([]map[string]any)
+	if len(before) == 0 {
+		return
+	}
+
+	ids := make([]any, len(before))
+	for i, row := range before {
+		ids[i] = row[target.primaryKey]
+	}
+	after, err := auditLoad(db, target, ids)
+	if err != nil {
+		db.AddError(err)
+		return
+	}
+
+	softDelete := softDeleteField(db.Statement.Schema) != nil
+	logs := make([]*AuditLog, 0, len(before))
+	for _, row := range before {
+		id := fmt.Sprint(row[target.primaryKey])
+		current := after[id]
+
+		rowAction := action
+		switch {
+		case current == nil && softDelete:
+			rowAction = AuditActionPurge
+		case current != nil && row["deleted_at"] != nil && current["deleted_at"] == nil:
+			rowAction = AuditActionRestore
+		case current != nil && row["deleted_at"] == nil && current["deleted_at"] != nil:
+			rowAction = AuditActionDelete
+		}
+
+		if log := newAuditLog(db, target, id, rowAction, row, current); log != nil {
+			logs = append(logs, log)
+		}
+	}
+	writeAuditLogs(db, logs)
+}
+
+// primaryKeyValues collects the primary keys of the statement's records.
+func primaryKeyValues(stmt *gorm.Statement) []any {
+	var values []any
+	field := stmt.Schema.PrioritizedPrimaryField
+	switch stmt.ReflectValue.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < stmt.ReflectValue.Len(); i++ {
+			if value, zero := field.ValueOf(stmt.Context, reflect.Indirect(stmt.ReflectValue.Index(i))); !zero {
+				values = append(values, value)
+			}
+		}
+	case reflect.Struct:
+		if value, zero := field.ValueOf(stmt.Context, stmt.ReflectValue); !zero {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// auditLoad reads rows by primary key, deleted or not, keyed by their id.
+func auditLoad(db *gorm.DB, target *auditTarget, ids []any) (map[string]map[string]any, error) {
+	rows := make(map[string]map[string]any, len(ids))
+	if len(ids) == 0 {
+		return rows, nil
+	}
+
+	var found []map[string]any
+	err := auditSession(db).
+		Table(db.Statement.Table).
+		Unscoped().
+		Where(clause.IN{Column: clause.Column{Name: target.primaryKey}, Values: ids}).
+		Find(&found).Error
+	if err != nil {
+		return nil, fmt.Errorf("audit load: %w", err)
+	}
+	for _, row := range found {
+		rows[fmt.Sprint(row[target.primaryKey])] = row
+	}
+	return rows, nil
+}
+
+// newAuditLog builds the entry for one row, or nil when nothing but
+// bookkeeping columns changed.
+func newAuditLog(db *gorm.DB, target *auditTarget, entityID, action string, before, after map[string]any) *AuditLog {
+	changes := map[string]AuditChange{}
+	for _, row := range []map[string]any{before, after} {
+		for column := range row {
+			if auditIgnoredColumns[column] {
+				continue
+			}
+			if _, ok := changes[column]; ok {
+				continue
+			}
+			b, a := before[column], after[column]
+			if reflect.DeepEqual(b, a) {
+				continue
+			}
+			if target.redacted[column] {
+				b, a = redactAuditValue(b), redactAuditValue(a)
+			}
+			changes[column] = AuditChange{Before: b, After: a}
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	encoded, err := json.Marshal(changes)
+	if err != nil {
+		db.AddError(fmt.Errorf("encode audit changes: %w", err))
+		return nil
+	}
+
+	ctx := db.Statement.Context
+	log := &AuditLog{
+		ID:        uuid.NewString(),
+		ActorID:   getUserIDFromContext(ctx),
+		Entity:    target.entity,
+		EntityID:  entityID,
+		Action:    action,
+		Changes:   string(encoded),
+		CreatedAt: db.NowFunc(),
+	}
+	if ctx != nil {
+		if reqID, ok := request_id.Value(ctx); ok && reqID != "" {
+			log.RequestID = &reqID
+		}
+	}
+	return log
+}
+
+func redactAuditValue(value any) any {
+	if value == nil {
+		return nil
+	}
+	return auditRedactedValue
+}
+
+func writeAuditLogs(db *gorm.DB, logs []*AuditLog) {
+	if len(logs) == 0 {
+		return
+	}
+	if err := auditSession(db).Create(&logs).Error; err != nil {
+		db.AddError(fmt.Errorf("write audit log: %w", err))
+	}
+}
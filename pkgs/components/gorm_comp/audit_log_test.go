@@ -0,0 +1,160 @@
+package gorm_comp_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gorm_comp"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gorm_comp/gormtest"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/types"
+	"gorm.io/gorm"
+)
+
+type auditedItem struct {
+	gorm_comp.SQLModel
+	Name   string `gorm:"column:name"`
+	Secret string `gorm:"column:secret"`
+}
+
+func (i *auditedItem) TableName() string {
+	return "audited_items"
+}
+
+func (i *auditedItem) AuditEntity() string {
+	return "item"
+}
+
+func (i *auditedItem) AuditRedactedColumns() []string {
+	return []string{"secret"}
+}
+
+func auditLogs(t *testing.T, db *gorm.DB, id string) []gorm_comp.AuditLog {
+	t.Helper()
+
+	var logs []gorm_comp.AuditLog
+	if err := db.Where("entity_id = ?", id).Order("created_at").Find(&logs).Error; err != nil {
+		t.Fatal(err)
+	}
+	return logs
+}
+
+func auditChanges(t *testing.T, log gorm_comp.AuditLog) map[string]gorm_comp.AuditChange {
+	t.Helper()
+
+	var changes map[string]gorm_comp.AuditChange
+	if err := json.Unmarshal([]byte(log.Changes), &changes); err != nil {
+		t.Fatal(err)
+	}
+	return changes
+}
+
+func TestAuditTrailRecordsChangedColumnsOnly(t *testing.T) {
+	db := gormtest.Open(t, &auditedItem{}, &gorm_comp.AuditLog{})
+	ctx := types.WithUser(context.Background(), &types.UserAuthenticated{ID: "actor"})
+
+	item := &auditedItem{SQLModel: gorm_comp.SQLModel{ID: "item-1"}, Name: "before", Secret: "s1"}
+	if err := db.WithContext(ctx).Create(item).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.WithContext(ctx).Model(item).Update("name", "after").Error; err != nil {
+		t.Fatal(err)
+	}
+	// Writing the same value is not a change.
+	if err := db.WithContext(ctx).Model(item).Update("name", "after").Error; err != nil {
+		t.Fatal(err)
+	}
+
+	logs := auditLogs(t, db, "item-1")
+	if len(logs) != 2 {
+		t.Fatalf("got %d audit logs, want create and update", len(logs))
+	}
+
+	create := logs[0]
+	if create.Action != gorm_comp.AuditActionCreate || create.Entity != "item" {
+		t.Errorf("first log is %s of %s, want create of item", create.Action, create.Entity)
+	}
+	if create.ActorID == nil || *create.ActorID != "actor" {
+		t.Errorf("actor = %v, want actor", create.ActorID)
+	}
+	if got := auditChanges(t, create)["name"]; got.Before != nil || got.After != "before" {
+		t.Errorf("created name = %+v, want nil to before", got)
+	}
+
+	update := logs[1]
+	changes := auditChanges(t, update)
+	if update.Action != gorm_comp.AuditActionUpdate {
+		t.Errorf("second log is %s, want update", update.Action)
+	}
+	if len(changes) != 1 || changes["name"].Before != "before" || changes["name"].After != "after" {
+		t.Errorf("update changes = %+v, want only name from before to after", changes)
+	}
+}
+
+func TestAuditTrailRedactsSecretColumns(t *testing.T) {
+	db := gormtest.Open(t, &auditedItem{}, &gorm_comp.AuditLog{})
+	ctx := context.Background()
+
+	item := &auditedItem{SQLModel: gorm_comp.SQLModel{ID: "item-1"}, Name: "name", Secret: "s1"}
+	if err := db.WithContext(ctx).Create(item).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.WithContext(ctx).Model(item).Update("secret", "s2").Error; err != nil {
+		t.Fatal(err)
+	}
+
+	logs := auditLogs(t, db, "item-1")
+	if len(logs) != 2 {
+		t.Fatalf("got %d audit logs, want create and update", len(logs))
+	}
+	if got := auditChanges(t, logs[0])["secret"]; got.Before != nil || got.After != "[redacted]" {
+		t.Errorf("created secret = %+v, want nil to [redacted]", got)
+	}
+	if got := auditChanges(t, logs[1])["secret"]; got.Before != "[redacted]" || got.After != "[redacted]" {
+		t.Errorf("updated secret = %+v, want [redacted] to [redacted]", got)
+	}
+}
+
+func TestAuditTrailRecordsDeleteRestoreAndPurge(t *testing.T) {
+	db := gormtest.Open(t, &auditedItem{}, &gorm_comp.AuditLog{})
+	ctx := types.WithUser(context.Background(), &types.UserAuthenticated{ID: "actor"})
+
+	item := &auditedItem{SQLModel: gorm_comp.SQLModel{ID: "item-1"}, Name: "name"}
+	if err := db.WithContext(ctx).Create(item).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.WithContext(ctx).Delete(&auditedItem{}, "id = ?", "item-1").Error; err != nil {
+		t.Fatal(err)
+	}
+	if restored, err := gorm_comp.Restore(ctx, db, &auditedItem{}, "item-1"); err != nil || !restored {
+		t.Fatalf("restore = %v, %v", restored, err)
+	}
+	if err := db.WithContext(ctx).Delete(&auditedItem{}, "id = ?", "item-1").Error; err != nil {
+		t.Fatal(err)
+	}
+	purged, err := gorm_comp.NewTrashPurger(db, &auditedItem{}).Purge(ctx, time.Now().Add(time.Hour))
+	if err != nil || purged != 1 {
+		t.Fatalf("purge = %d, %v", purged, err)
+	}
+
+	var actions []string
+	for _, log := range auditLogs(t, db, "item-1") {
+		actions = append(actions, log.Action)
+	}
+	want := []string{
+		gorm_comp.AuditActionCreate,
+		gorm_comp.AuditActionDelete,
+		gorm_comp.AuditActionRestore,
+		gorm_comp.AuditActionDelete,
+		gorm_comp.AuditActionPurge,
+	}
+	if len(actions) != len(want) {
+		t.Fatalf("actions = %v, want %v", actions, want)
+	}
+	for i := range want {
+		if actions[i] != want[i] {
+			t.Fatalf("actions = %v, want %v", actions, want)
+		}
+	}
+}
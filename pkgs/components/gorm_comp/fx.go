@@ -17,4 +17,10 @@ var GormComponentFx = fx.Options(
 	fx.Provide(ProvideGormOpt),
 	fx.Provide(ProvideGormDB),
 	fx.Provide(NewGormDB),
+	fx.Provide(
+		fx.Annotate(
+			NewTxManager,
+			fx.As(new(ITxManager)),
+		),
+	),
 )
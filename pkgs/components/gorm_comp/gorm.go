@@ -311,6 +311,8 @@ func (gdb *GormDB) getDBConn(t GormDBType) (dbConn *gorm.DB, err error) {
 	}
 
 	registerAuditHook(db)
+	registerAuditTrail(db)
+	registerTxCallbacks(db)
 
 	// Configure connection pool settings on primary connection
 	// These will apply when DBResolver is not used
@@ -0,0 +1,60 @@
+// Package gormtest opens SQLite databases for tests, with the callbacks of
+// gorm_comp registered like in the app.
+package gormtest
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gorm_comp"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/global_config"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/logger"
+	log_cfg "github.com/dukk308/beetool.dev-go-starter/pkgs/logger/config"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// Open creates a database in a temporary directory and migrates models. It
+// has a single connection, so statements outside a transaction wait for it.
+func Open(t testing.TB, models ...any) *gorm.DB {
+	t.Helper()
+
+	log := logger.NewZapLogger(&log_cfg.LogOptions{}, &global_config.GlobalConfig{LogLevel: "fatal"})
+	db := gorm_comp.NewGormDB(gorm_comp.NewGormDBParams{
+		Config: &gorm_comp.GormOpt{
+			Dsn:                   filepath.Join(t.TempDir(), "test.db") + "?_busy_timeout=5000",
+			DbType:                "sqlite",
+			MaxOpenConnections:    1,
+			MaxIdleConnections:    1,
+			MaxConnectionIdleTime: 60,
+		},
+		Logger: log,
+	}).GetDB()
+
+	for _, model := range models {
+		if err := sqliteTimestamps(db, model); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := db.AutoMigrate(models...); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// sqliteTimestamps declares the Postgres "timestamp without time zone"
+// columns of model as datetime, the type the SQLite driver reads times from.
+func sqliteTimestamps(db *gorm.DB, model any) error {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		return err
+	}
+	for _, field := range stmt.Schema.Fields {
+		if strings.HasPrefix(field.TagSettings["TYPE"], "timestamp") {
+			field.DataType = schema.Time
+			delete(field.TagSettings, "TYPE")
+		}
+	}
+	return nil
+}
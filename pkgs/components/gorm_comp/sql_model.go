@@ -2,14 +2,37 @@ package gorm_comp
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
+// SQLModel is embedded by every table. DeletedAt makes deletes soft: queries
+// skip deleted rows unless Unscoped, and the audit hook records DeletedBy.
+// Version is checked and bumped by UpdateVersioned.
 type SQLModel struct {
-	ID        string    `gorm:"column:id;primaryKey"`
-	CreatedAt *time.Time `gorm:"column:created_at;type:timestamp without time zone;default:CURRENT_TIMESTAMP"`
-	UpdatedAt *time.Time `gorm:"column:updated_at;type:timestamp without time zone;default:CURRENT_TIMESTAMP"`
-	DeletedAt *time.Time `gorm:"column:deleted_at;type:timestamp without time zone"`
-	CreatedBy *string    `gorm:"column:created_by"`
-	UpdatedBy *string    `gorm:"column:updated_by"`
-	DeletedBy *string    `gorm:"column:deleted_by"`
+	ID        string         `gorm:"column:id;primaryKey"`
+	Version   int64          `gorm:"column:version;not null;default:1"`
+	CreatedAt *time.Time     `gorm:"column:created_at;type:timestamp without time zone;default:CURRENT_TIMESTAMP"`
+	UpdatedAt *time.Time     `gorm:"column:updated_at;type:timestamp without time zone;default:CURRENT_TIMESTAMP"`
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;type:timestamp without time zone;index"`
+	CreatedBy *string        `gorm:"column:created_by"`
+	UpdatedBy *string        `gorm:"column:updated_by"`
+	DeletedBy *string        `gorm:"column:deleted_by"`
+}
+
+// DeletedAtTime converts a soft delete column to the domain representation.
+func DeletedAtTime(deletedAt gorm.DeletedAt) *time.Time {
+	if !deletedAt.Valid {
+		return nil
+	}
+	t := deletedAt.Time
+	return &t
+}
+
+// ToDeletedAt converts a domain deletion time to a soft delete column.
+func ToDeletedAt(t *time.Time) gorm.DeletedAt {
+	if t == nil {
+		return gorm.DeletedAt{}
+	}
+	return gorm.DeletedAt{Time: *t, Valid: true}
 }
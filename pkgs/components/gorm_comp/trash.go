@@ -0,0 +1,101 @@
+package gorm_comp
+
+import (
+	"context"
+	"time"
+
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/logger"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+// TrashPurgerGroup collects the purgers of every soft-deleted table.
+const TrashPurgerGroup = `group:"trash_purgers"`
+
+// Trashed scopes a query to soft-deleted rows only.
+func Trashed(db *gorm.DB) *gorm.DB {
+	return db.Unscoped().Where("deleted_at IS NOT NULL")
+}
+
+// Restore clears the soft delete of the row with id. It reports false when no
+// deleted row has that id.
+func Restore(ctx context.Context, db *gorm.DB, model any, id string) (bool, error) {
+	result := Trashed(db.WithContext(ctx).Model(model)).
+		Where("id = ?", id).
+		Updates(map[string]any{"deleted_at": nil, "deleted_by": nil})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// TrashPurger permanently deletes rows of one table that were soft-deleted
+// before a cutoff.
+type TrashPurger struct {
+	db    *gorm.DB
+	model any
+}
+
+func NewTrashPurger(db *gorm.DB, model any) *TrashPurger {
+	return &TrashPurger{
+		db:    db,
+		model: model,
+	}
+}
+
+// Table names the purged table for logs.
+func (p *TrashPurger) Table() string {
+	stmt := &gorm.Statement{DB: p.db}
+	if err := stmt.Parse(p.model); err != nil {
+		return "unknown"
+	}
+	return stmt.Table
+}
+
+func (p *TrashPurger) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	result := Trashed(p.db.WithContext(ctx)).
+		Where("deleted_at < ?", deletedBefore).
+		Delete(p.model)
+	return result.RowsAffected, result.Error
+}
+
+// ProvideTrashPurger registers model's table with the trash purge job.
+func ProvideTrashPurger(model any) fx.Option {
+	return fx.Provide(
+		fx.Annotate(
+			func(db *gorm.DB) *TrashPurger {
+				return NewTrashPurger(db, model)
+			},
+			fx.ResultTags(TrashPurgerGroup),
+		),
+	)
+}
+
+// PurgeTrash permanently deletes rows that have been in the trash for longer
+// than retention. Every table is attempted; the first error is returned.
+func PurgeTrash(ctx context.Context, purgers []*TrashPurger, retention time.Duration, log logger.Logger) error {
+	deletedBefore := time.Now().Add(-retention)
+
+	var firstErr error
+	for _, purger := range purgers {
+		purged, err := purger.Purge(ctx, deletedBefore)
+		if err != nil {
+			log.Errorw("failed to purge trash", logger.Fields{
+				"table": purger.Table(),
+				"error": err.Error(),
+			})
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+
+		log.Infow("purged trash", logger.Fields{
+			"table":          purger.Table(),
+			"rows":           purged,
+			"deleted_before": deletedBefore,
+		})
+	}
+
+	return firstErr
+}
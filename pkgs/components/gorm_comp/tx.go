@@ -0,0 +1,71 @@
+package gorm_comp
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+type txContextKey struct{}
+
+// ITxManager runs application commands in a database transaction. Repositories
+// need no changes: every statement run with a context from WithinTx joins the
+// transaction.
+type ITxManager interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type TxManager struct {
+	db *gorm.DB
+}
+
+func NewTxManager(db *gorm.DB) *TxManager {
+	return &TxManager{
+		db: db,
+	}
+}
+
+// WithinTx commits when fn returns nil and rolls back otherwise. Called again
+// with a context already in a transaction, it opens a savepoint, so a failed
+// nested call only undoes its own writes. Transactions always run on the
+// primary, never on a replica.
+func (m *TxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	db := m.db.WithContext(ctx).Clauses(dbresolver.Write)
+	if tx, ok := TxFromContext(ctx); ok {
+		db = tx.WithContext(ctx)
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txContextKey{}, tx))
+	})
+}
+
+// TxFromContext returns the transaction opened by WithinTx, if any.
+func TxFromContext(ctx context.Context) (*gorm.DB, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	tx, ok := ctx.Value(txContextKey{}).(*gorm.DB)
+	return tx, ok
+}
+
+// registerTxCallbacks makes statements join the transaction in their context.
+// They run first, before gorm opens its own default transaction and before
+// dbresolver picks a connection, which both leave a transaction alone.
+func registerTxCallbacks(db *gorm.DB) {
+	db.Callback().Create().Before("*").Register("tx:join_create", joinContextTx)
+	db.Callback().Query().Before("*").Register("tx:join_query", joinContextTx)
+	db.Callback().Update().Before("*").Register("tx:join_update", joinContextTx)
+	db.Callback().Delete().Before("*").Register("tx:join_delete", joinContextTx)
+	db.Callback().Row().Before("*").Register("tx:join_row", joinContextTx)
+	db.Callback().Raw().Before("*").Register("tx:join_raw", joinContextTx)
+}
+
+func joinContextTx(db *gorm.DB) {
+	tx, ok := TxFromContext(db.Statement.Context)
+	if !ok {
+		return
+	}
+	db.Statement.ConnPool = tx.Statement.ConnPool
+}
@@ -0,0 +1,128 @@
+package gorm_comp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gorm_comp"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gorm_comp/gormtest"
+	"gorm.io/gorm"
+)
+
+var errRollback = errors.New("roll back")
+
+func createItem(ctx context.Context, db *gorm.DB, id string) error {
+	return db.WithContext(ctx).Create(&versionedItem{SQLModel: gorm_comp.SQLModel{ID: id}}).Error
+}
+
+// storedItems lists the IDs of the committed items.
+func storedItems(t *testing.T, db *gorm.DB) []string {
+	t.Helper()
+
+	var ids []string
+	if err := db.Model(&versionedItem{}).Order("id").Pluck("id", &ids).Error; err != nil {
+		t.Fatal(err)
+	}
+	return ids
+}
+
+func assertItems(t *testing.T, got []string, want ...string) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("items = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("items = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestWithinTxRollsBackEveryStatementOnError(t *testing.T) {
+	db := gormtest.Open(t, &versionedItem{})
+	txManager := gorm_comp.NewTxManager(db)
+
+	err := txManager.WithinTx(context.Background(), func(ctx context.Context) error {
+		if err := createItem(ctx, db, "a"); err != nil {
+			return err
+		}
+		// Statements with the context join the transaction and see its writes.
+		var count int64
+		if err := db.WithContext(ctx).Model(&versionedItem{}).Count(&count).Error; err != nil {
+			return err
+		}
+		if count != 1 {
+			t.Errorf("count in the transaction = %d, want 1", count)
+		}
+		return errRollback
+	})
+	if !errors.Is(err, errRollback) {
+		t.Fatalf("err = %v, want errRollback", err)
+	}
+	assertItems(t, storedItems(t, db))
+}
+
+func TestWithinTxNestedFailureOnlyUndoesItsSavepoint(t *testing.T) {
+	db := gormtest.Open(t, &versionedItem{})
+	txManager := gorm_comp.NewTxManager(db)
+
+	err := txManager.WithinTx(context.Background(), func(ctx context.Context) error {
+		if err := createItem(ctx, db, "outer"); err != nil {
+			return err
+		}
+		nested := txManager.WithinTx(ctx, func(ctx context.Context) error {
+			if err := createItem(ctx, db, "nested"); err != nil {
+				return err
+			}
+			return errRollback
+		})
+		if !errors.Is(nested, errRollback) {
+			t.Errorf("nested err = %v, want errRollback", nested)
+		}
+		return createItem(ctx, db, "after")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertItems(t, storedItems(t, db), "after", "outer")
+}
+
+func TestWithinTxOuterFailureUndoesCommittedSavepoints(t *testing.T) {
+	db := gormtest.Open(t, &versionedItem{})
+	txManager := gorm_comp.NewTxManager(db)
+
+	err := txManager.WithinTx(context.Background(), func(ctx context.Context) error {
+		if err := createItem(ctx, db, "outer"); err != nil {
+			return err
+		}
+		if err := txManager.WithinTx(ctx, func(ctx context.Context) error {
+			return createItem(ctx, db, "nested")
+		}); err != nil {
+			return err
+		}
+		return errRollback
+	})
+	if !errors.Is(err, errRollback) {
+		t.Fatalf("err = %v, want errRollback", err)
+	}
+	assertItems(t, storedItems(t, db))
+}
+
+func TestTxFromContext(t *testing.T) {
+	db := gormtest.Open(t, &versionedItem{})
+
+	if _, ok := gorm_comp.TxFromContext(context.Background()); ok {
+		t.Fatal("transaction found outside WithinTx")
+	}
+	err := gorm_comp.NewTxManager(db).WithinTx(context.Background(), func(ctx context.Context) error {
+		if _, ok := gorm_comp.TxFromContext(ctx); !ok {
+			t.Error("no transaction inside WithinTx")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
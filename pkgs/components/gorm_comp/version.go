@@ -0,0 +1,44 @@
+package gorm_comp
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// ErrStaleVersion is returned by UpdateVersioned when the row no longer has
+// the version the model was read at.
+var ErrStaleVersion = errors.New("stale row version")
+
+type versioned interface {
+	versionField() *int64
+}
+
+func (m *SQLModel) versionField() *int64 {
+	return &m.Version
+}
+
+// UpdateVersioned saves every column of model like Save, but only while the
+// row still has model's version, and increments it. Creation and deletion
+// columns are left alone. On ErrStaleVersion model keeps its old version.
+func UpdateVersioned(ctx context.Context, db *gorm.DB, model versioned) error {
+	version := model.versionField()
+	expected := *version
+	*version = expected + 1
+
+	result := db.WithContext(ctx).
+		Model(model).
+		Where("version = ?", expected).
+		Select("*").
+		Omit("created_at", "created_by", "deleted_at", "deleted_by").
+		Updates(model)
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = ErrStaleVersion
+	}
+	if result.Error != nil {
+		*version = expected
+		return result.Error
+	}
+	return nil
+}
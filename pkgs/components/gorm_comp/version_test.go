@@ -0,0 +1,108 @@
+package gorm_comp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gorm_comp"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gorm_comp/gormtest"
+	"gorm.io/gorm"
+)
+
+type versionedItem struct {
+	gorm_comp.SQLModel
+	Name string `gorm:"column:name"`
+}
+
+func (i *versionedItem) TableName() string {
+	return "versioned_items"
+}
+
+func loadItem(t *testing.T, db *gorm.DB, id string) *versionedItem {
+	t.Helper()
+
+	var item versionedItem
+	if err := db.First(&item, "id = ?", id).Error; err != nil {
+		t.Fatal(err)
+	}
+	return &item
+}
+
+func TestUpdateVersionedBumpsTheVersion(t *testing.T) {
+	db := gormtest.Open(t, &versionedItem{})
+	ctx := context.Background()
+	if err := db.Create(&versionedItem{SQLModel: gorm_comp.SQLModel{ID: "item-1"}, Name: "before"}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	item := loadItem(t, db, "item-1")
+	createdAt := *item.CreatedAt
+	item.Name = "after"
+	item.CreatedAt = nil
+	if err := gorm_comp.UpdateVersioned(ctx, db, item); err != nil {
+		t.Fatal(err)
+	}
+	if item.Version != 2 {
+		t.Fatalf("model version = %d, want 2", item.Version)
+	}
+
+	stored := loadItem(t, db, "item-1")
+	if stored.Name != "after" || stored.Version != 2 {
+		t.Fatalf("stored %q at version %d, want after at 2", stored.Name, stored.Version)
+	}
+	if stored.CreatedAt == nil || !stored.CreatedAt.Equal(createdAt) {
+		t.Fatalf("created_at = %v, want %v", stored.CreatedAt, createdAt)
+	}
+}
+
+func TestUpdateVersionedRejectsAStaleVersion(t *testing.T) {
+	db := gormtest.Open(t, &versionedItem{})
+	ctx := context.Background()
+	if err := db.Create(&versionedItem{SQLModel: gorm_comp.SQLModel{ID: "item-1"}, Name: "before"}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	// Two writers read the same version; the second one to save loses.
+	first, second := loadItem(t, db, "item-1"), loadItem(t, db, "item-1")
+	first.Name = "first"
+	if err := gorm_comp.UpdateVersioned(ctx, db, first); err != nil {
+		t.Fatal(err)
+	}
+	second.Name = "second"
+	if err := gorm_comp.UpdateVersioned(ctx, db, second); !errors.Is(err, gorm_comp.ErrStaleVersion) {
+		t.Fatalf("err = %v, want ErrStaleVersion", err)
+	}
+	if second.Version != 1 {
+		t.Fatalf("stale model version = %d, want it kept at 1", second.Version)
+	}
+
+	stored := loadItem(t, db, "item-1")
+	if stored.Name != "first" || stored.Version != 2 {
+		t.Fatalf("stored %q at version %d, want first at 2", stored.Name, stored.Version)
+	}
+
+	// Reloading the row gives the writer the current version to retry with.
+	retry := loadItem(t, db, "item-1")
+	retry.Name = "second"
+	if err := gorm_comp.UpdateVersioned(ctx, db, retry); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUpdateVersionedRejectsADeletedRow(t *testing.T) {
+	db := gormtest.Open(t, &versionedItem{})
+	ctx := context.Background()
+	if err := db.Create(&versionedItem{SQLModel: gorm_comp.SQLModel{ID: "item-1"}, Name: "before"}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	item := loadItem(t, db, "item-1")
+	if err := db.Delete(&versionedItem{}, "id = ?", "item-1").Error; err != nil {
+		t.Fatal(err)
+	}
+	item.Name = "after"
+	if err := gorm_comp.UpdateVersioned(ctx, db, item); !errors.Is(err, gorm_comp.ErrStaleVersion) {
+		t.Fatalf("err = %v, want ErrStaleVersion", err)
+	}
+}
@@ -0,0 +1,12 @@
+package mailer_comp
+
+const (
+	DriverLog  = "log"
+	DriverFile = "file"
+)
+
+type MailerConfig struct {
+	Driver  string
+	From    string
+	FileDir string
+}
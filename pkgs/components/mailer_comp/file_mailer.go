@@ -0,0 +1,44 @@
+package mailer_comp
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FileMailer writes every email as an .eml file, which mail clients can open.
+type FileMailer struct {
+	from string
+	dir  string
+}
+
+func NewFileMailer(config *MailerConfig) (IMailer, error) {
+	if err := os.MkdirAll(config.FileDir, 0o750); err != nil {
+		return nil, fmt.Errorf("create mailer directory %s: %w", config.FileDir, err)
+	}
+
+	return &FileMailer{
+		from: config.From,
+		dir:  config.FileDir,
+	}, nil
+}
+
+func (m *FileMailer) Send(ctx context.Context, message *Message) error {
+	now := time.Now()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", m.from)
+	fmt.Fprintf(&b, "To: %s\r\n", message.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", message.Subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	b.WriteString(message.Body)
+
+	name := fmt.Sprintf("%s-%s.eml", now.Format("20060102T150405"), uuid.NewString())
+	return os.WriteFile(filepath.Join(m.dir, name), []byte(b.String()), 0o600)
+}
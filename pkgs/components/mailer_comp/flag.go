@@ -0,0 +1,37 @@
+package mailer_comp
+
+import (
+	"flag"
+)
+
+var (
+	mailerDriverVal  string
+	mailerFromVal    string
+	mailerFileDirVal string
+)
+
+var (
+	MailerDriver  = &mailerDriverVal
+	MailerFrom    = &mailerFromVal
+	MailerFileDir = &mailerFileDirVal
+)
+
+func init() {
+	if flag.Lookup("mailer-driver") == nil {
+		flag.StringVar(&mailerDriverVal, "mailer-driver", DriverLog, "Mailer driver (log, file)")
+	}
+	if flag.Lookup("mailer-from") == nil {
+		flag.StringVar(&mailerFromVal, "mailer-from", "no-reply@localhost", "Sender address of outgoing emails")
+	}
+	if flag.Lookup("mailer-file-dir") == nil {
+		flag.StringVar(&mailerFileDirVal, "mailer-file-dir", "tmp/mails", "Directory the file mailer writes emails to")
+	}
+}
+
+func LoadMailerConfig() *MailerConfig {
+	return &MailerConfig{
+		Driver:  *MailerDriver,
+		From:    *MailerFrom,
+		FileDir: *MailerFileDir,
+	}
+}
@@ -0,0 +1,28 @@
+package mailer_comp
+
+import (
+	"fmt"
+
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/logger"
+	"go.uber.org/fx"
+)
+
+func ProvideMailerConfig() *MailerConfig {
+	return LoadMailerConfig()
+}
+
+func ProvideMailer(config *MailerConfig, log logger.Logger) (IMailer, error) {
+	switch config.Driver {
+	case DriverLog:
+		return NewLogMailer(config, log), nil
+	case DriverFile:
+		return NewFileMailer(config)
+	default:
+		return nil, fmt.Errorf("unknown mailer driver %q", config.Driver)
+	}
+}
+
+var MailerComponentFx = fx.Module("mailer",
+	fx.Provide(ProvideMailerConfig),
+	fx.Provide(ProvideMailer),
+)
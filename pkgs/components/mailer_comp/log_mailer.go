@@ -0,0 +1,30 @@
+package mailer_comp
+
+import (
+	"context"
+
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/logger"
+)
+
+// LogMailer writes emails to the application log instead of sending them.
+type LogMailer struct {
+	from string
+	log  logger.Logger
+}
+
+func NewLogMailer(config *MailerConfig, log logger.Logger) IMailer {
+	return &LogMailer{
+		from: config.From,
+		log:  log,
+	}
+}
+
+func (m *LogMailer) Send(ctx context.Context, message *Message) error {
+	m.log.Infow("[MAILER] email sent", logger.Fields{
+		"from":    m.from,
+		"to":      message.To,
+		"subject": message.Subject,
+		"body":    message.Body,
+	})
+	return nil
+}
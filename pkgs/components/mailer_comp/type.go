@@ -0,0 +1,15 @@
+package mailer_comp
+
+import "context"
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// IMailer delivers transactional emails. Drivers for real providers only need
+// to implement Send.
+type IMailer interface {
+	Send(ctx context.Context, message *Message) error
+}
@@ -2,6 +2,7 @@ package otel_comp
 
 import (
 	"flag"
+	"time"
 )
 
 var (
@@ -25,3 +26,28 @@ type TracerConfig struct {
 	Exporter string
 	Collector string
 }
+
+var (
+	metricsExporter  = flag.String("metrics-exporter", "otlp-grpc", "Metrics exporter type (noop, memory, stdout, otlp-grpc)")
+	metricsCollector = flag.String("metrics-collector", "", "OTLP collector endpoint (host:port) for metrics, the tracer collector when empty")
+	metricsInterval  = flag.Duration("metrics-interval", 30*time.Second, "How often metrics are exported")
+)
+
+func LoadMeterConfig() *MeterConfig {
+	collector := *metricsCollector
+	if collector == "" {
+		collector = *tracerCollector
+	}
+
+	return &MeterConfig{
+		Exporter:  *metricsExporter,
+		Collector: collector,
+		Interval:  *metricsInterval,
+	}
+}
+
+type MeterConfig struct {
+	Exporter  string
+	Collector string
+	Interval  time.Duration
+}
@@ -0,0 +1,119 @@
+package otel_comp
+
+import (
+	"context"
+
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.uber.org/fx"
+)
+
+// MeterModule sets the global meter provider, which instruments created with
+// otel.Meter, such as the RabbitMQ publish metrics, record to.
+var MeterModule = fx.Module(
+	"meter",
+	fx.Provide(
+		NewMeterConfig,
+		NewFxMeterProvider,
+	),
+	fx.Invoke(func(*metric.MeterProvider) {}),
+)
+
+func NewMeterConfig() *MeterConfig {
+	return LoadMeterConfig()
+}
+
+type FxMeterParam struct {
+	fx.In
+	LifeCycle   fx.Lifecycle
+	ServiceName string `name:"serviceName"`
+	MeterConfig *MeterConfig
+	Logger      logger.Logger
+}
+
+func NewFxMeterProvider(p FxMeterParam) (*metric.MeterProvider, error) {
+	exporter := FetchExporter(p.MeterConfig.Exporter)
+
+	meterProvider, err := CreateMeterProvider(context.Background(), p.ServiceName, exporter, p.MeterConfig)
+	if err != nil {
+		p.Logger.Errorw("error creating meter provider", logger.Fields{"error": err.Error()})
+		return nil, err
+	}
+	otel.SetMeterProvider(meterProvider)
+
+	p.Logger.Infow("Meter provider initialized", logger.Fields{
+		"service":   p.ServiceName,
+		"exporter":  exporter.String(),
+		"collector": p.MeterConfig.Collector,
+		"interval":  p.MeterConfig.Interval.String(),
+	})
+
+	p.LifeCycle.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			// Shutdown exports what was recorded since the last interval.
+			if err := meterProvider.Shutdown(ctx); err != nil {
+				p.Logger.Errorw("error while shutting down meter provider", logger.Fields{"error": err.Error()})
+				return err
+			}
+			return nil
+		},
+	})
+
+	return meterProvider, nil
+}
+
+// CreateMeterProvider builds a meter provider that exports to exporter every
+// config.Interval. The noop exporter records nothing.
+func CreateMeterProvider(ctx context.Context, name string, exporter Exporter, config *MeterConfig) (*metric.MeterProvider, error) {
+	res, err := resource.New(
+		ctx,
+		resource.WithAttributes(
+			semconv.ServiceNameKey.String(name),
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	options := []metric.Option{metric.WithResource(res)}
+
+	reader, err := createMetricReader(ctx, exporter, config)
+	if err != nil {
+		return nil, err
+	}
+	if reader != nil {
+		options = append(options, metric.WithReader(reader))
+	}
+
+	return metric.NewMeterProvider(options...), nil
+}
+
+func createMetricReader(ctx context.Context, exporter Exporter, config *MeterConfig) (metric.Reader, error) {
+	switch exporter {
+	case Memory:
+		return metric.NewManualReader(), nil
+	case Stdout:
+		stdoutExporter, err := stdoutmetric.New(stdoutmetric.WithPrettyPrint())
+		if err != nil {
+			return nil, err
+		}
+		return metric.NewPeriodicReader(stdoutExporter, metric.WithInterval(config.Interval)), nil
+	case OtlpGrpc:
+		otlpGrpcExporter, err := otlpmetricgrpc.New(
+			ctx,
+			otlpmetricgrpc.WithEndpoint(config.Collector),
+			otlpmetricgrpc.WithInsecure(),
+		)
+		if err != nil {
+			return nil, err
+		}
+		return metric.NewPeriodicReader(otlpGrpcExporter, metric.WithInterval(config.Interval)), nil
+	default:
+		return nil, nil
+	}
+}
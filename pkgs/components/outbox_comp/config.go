@@ -0,0 +1,20 @@
+package outbox_comp
+
+import "time"
+
+type OutboxConfig struct {
+	// Exchange is the topic exchange events are published to, routed by
+	// event name.
+	Exchange       string
+	PollInterval   time.Duration
+	BatchSize      int
+	MaxAttempts    int
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+	// ConfirmTimeout bounds how long a round waits for the broker to confirm
+	// its publishes. Unconfirmed events are retried.
+	ConfirmTimeout time.Duration
+	// Retention is how long published and unrouted events are kept; zero
+	// keeps them forever.
+	Retention time.Duration
+}
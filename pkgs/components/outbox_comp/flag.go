@@ -0,0 +1,68 @@
+package outbox_comp
+
+import (
+	"flag"
+	"time"
+)
+
+var (
+	outboxExchangeVal       string
+	outboxPollIntervalVal   time.Duration
+	outboxBatchSizeVal      int
+	outboxMaxAttemptsVal    int
+	outboxRetryBaseDelayVal time.Duration
+	outboxRetryMaxDelayVal  time.Duration
+	outboxConfirmTimeoutVal time.Duration
+	outboxRetentionVal      time.Duration
+)
+
+var (
+	OutboxExchange       = &outboxExchangeVal
+	OutboxPollInterval   = &outboxPollIntervalVal
+	OutboxBatchSize      = &outboxBatchSizeVal
+	OutboxMaxAttempts    = &outboxMaxAttemptsVal
+	OutboxRetryBaseDelay = &outboxRetryBaseDelayVal
+	OutboxRetryMaxDelay  = &outboxRetryMaxDelayVal
+	OutboxConfirmTimeout = &outboxConfirmTimeoutVal
+	OutboxRetention      = &outboxRetentionVal
+)
+
+func init() {
+	if flag.Lookup("outbox-exchange") == nil {
+		flag.StringVar(&outboxExchangeVal, "outbox-exchange", "domain_events", "Topic exchange the outbox relay publishes domain events to")
+	}
+	if flag.Lookup("outbox-poll-interval") == nil {
+		flag.DurationVar(&outboxPollIntervalVal, "outbox-poll-interval", time.Second, "How often the outbox relay looks for pending events")
+	}
+	if flag.Lookup("outbox-batch-size") == nil {
+		flag.IntVar(&outboxBatchSizeVal, "outbox-batch-size", 100, "Events the outbox relay publishes per round")
+	}
+	if flag.Lookup("outbox-max-attempts") == nil {
+		flag.IntVar(&outboxMaxAttemptsVal, "outbox-max-attempts", 10, "Failed publishes before an event is dead-lettered")
+	}
+	if flag.Lookup("outbox-retry-base-delay") == nil {
+		flag.DurationVar(&outboxRetryBaseDelayVal, "outbox-retry-base-delay", time.Second, "First retry delay of a failed publish, doubled on every further failure")
+	}
+	if flag.Lookup("outbox-retry-max-delay") == nil {
+		flag.DurationVar(&outboxRetryMaxDelayVal, "outbox-retry-max-delay", 5*time.Minute, "Longest retry delay of a failed publish")
+	}
+	if flag.Lookup("outbox-confirm-timeout") == nil {
+		flag.DurationVar(&outboxConfirmTimeoutVal, "outbox-confirm-timeout", 30*time.Second, "How long the outbox relay waits for the broker to confirm a round of publishes")
+	}
+	if flag.Lookup("outbox-retention") == nil {
+		flag.DurationVar(&outboxRetentionVal, "outbox-retention", 7*24*time.Hour, "How long published outbox events are kept, 0 keeps them forever")
+	}
+}
+
+func LoadOutboxConfig() *OutboxConfig {
+	return &OutboxConfig{
+		Exchange:       *OutboxExchange,
+		PollInterval:   *OutboxPollInterval,
+		BatchSize:      *OutboxBatchSize,
+		MaxAttempts:    *OutboxMaxAttempts,
+		RetryBaseDelay: *OutboxRetryBaseDelay,
+		RetryMaxDelay:  *OutboxRetryMaxDelay,
+		ConfirmTimeout: *OutboxConfirmTimeout,
+		Retention:      *OutboxRetention,
+	}
+}
@@ -0,0 +1,43 @@
+package outbox_comp
+
+import (
+	"context"
+
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/logger"
+	"go.uber.org/fx"
+)
+
+func ProvideOutboxConfig() *OutboxConfig {
+	return LoadOutboxConfig()
+}
+
+var OutboxComponentFx = fx.Module("outbox",
+	fx.Provide(ProvideOutboxConfig),
+	fx.Provide(
+		fx.Annotate(
+			NewOutbox,
+			fx.As(new(IOutbox)),
+		),
+	),
+)
+
+// OutboxRelayFx runs the relay while the app runs. It needs OutboxComponentFx
+// and the RabbitMQ component.
+var OutboxRelayFx = fx.Module("outbox_relay",
+	fx.Provide(NewRelay),
+	fx.Invoke(registerRelayHooks),
+)
+
+func registerRelayHooks(lc fx.Lifecycle, relay *Relay, log logger.Logger) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			log.Info("Outbox relay started")
+			return relay.Start()
+		},
+		OnStop: func(ctx context.Context) error {
+			relay.Stop()
+			log.Info("Outbox relay stopped")
+			return nil
+		},
+	})
+}
@@ -0,0 +1,39 @@
+package outbox_comp
+
+import "time"
+
+const (
+	StatusPending   = "pending"
+	StatusPublished = "published"
+	// StatusDead marks an event that failed MaxAttempts times. It stays in
+	// the table with its last error for inspection and is no longer retried.
+	StatusDead = "dead"
+	// StatusUnrouted marks an event the broker had no queue bound for: no
+	// consumer subscribes to it. It is not retried, and swept like published
+	// events.
+	StatusUnrouted = "unrouted"
+)
+
+// OutboxMessage is one domain event waiting to be, or already, published.
+// Seq orders the events of an aggregate. TraceContext is the W3C trace
+// context of the request that saved the event, as a JSON object.
+type OutboxMessage struct {
+	ID            string     `gorm:"column:id;primaryKey"`
+	Seq           int64      `gorm:"column:seq;autoIncrement;not null;uniqueIndex:uni_outbox_seq;index:idx_outbox_aggregate,priority:3;<-:false"`
+	AggregateType string     `gorm:"column:aggregate_type;type:varchar(100);not null;index:idx_outbox_aggregate,priority:1"`
+	AggregateID   string     `gorm:"column:aggregate_id;type:text;not null;index:idx_outbox_aggregate,priority:2"`
+	EventType     string     `gorm:"column:event_type;type:varchar(255);not null"`
+	Payload       string     `gorm:"column:payload;type:jsonb;not null"`
+	RequestID     *string    `gorm:"column:request_id"`
+	TraceContext  *string    `gorm:"column:trace_context;type:jsonb"`
+	Status        string     `gorm:"column:status;type:varchar(20);not null;default:pending;index:idx_outbox_status_next_attempt,priority:1"`
+	Attempts      int        `gorm:"column:attempts;not null;default:0"`
+	NextAttemptAt time.Time  `gorm:"column:next_attempt_at;type:timestamp without time zone;not null;index:idx_outbox_status_next_attempt,priority:2"`
+	LastError     *string    `gorm:"column:last_error;type:text"`
+	OccurredAt    time.Time  `gorm:"column:occurred_at;type:timestamp without time zone;not null"`
+	PublishedAt   *time.Time `gorm:"column:published_at;type:timestamp without time zone"`
+}
+
+func (m *OutboxMessage) TableName() string {
+	return "outbox"
+}
@@ -0,0 +1,88 @@
+package outbox_comp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/utils/request_id"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"gorm.io/gorm"
+)
+
+// IOutbox saves domain events for the relay to publish. Append joins the
+// transaction in ctx, so events commit or roll back with the aggregate.
+type IOutbox interface {
+	Append(ctx context.Context, aggregateType, aggregateID string, events []base.DomainEvent) error
+}
+
+type Outbox struct {
+	db *gorm.DB
+}
+
+func NewOutbox(db *gorm.DB) *Outbox {
+	return &Outbox{
+		db: db,
+	}
+}
+
+func (o *Outbox) Append(ctx context.Context, aggregateType, aggregateID string, events []base.DomainEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	var requestID *string
+	if id, ok := request_id.Value(ctx); ok && id != "" {
+		requestID = &id
+	}
+
+	traceContext, err := encodeTraceContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	messages := make([]*OutboxMessage, len(events))
+	for i, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("encode %s event: %w", event.EventName(), err)
+		}
+		messages[i] = &OutboxMessage{
+			ID:            uuid.NewString(),
+			AggregateType: aggregateType,
+			AggregateID:   aggregateID,
+			EventType:     event.EventName(),
+			Payload:       string(payload),
+			RequestID:     requestID,
+			TraceContext:  traceContext,
+			Status:        StatusPending,
+			NextAttemptAt: now,
+			OccurredAt:    now,
+		}
+	}
+
+	return o.db.WithContext(ctx).Create(&messages).Error
+}
+
+// encodeTraceContext returns the trace context of ctx as JSON, so the relay
+// publishes the event in the trace of the request that saved it. It is nil
+// outside a trace.
+func encodeTraceContext(ctx context.Context) (*string, error) {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return nil, nil
+	}
+
+	encoded, err := json.Marshal(carrier)
+	if err != nil {
+		return nil, fmt.Errorf("encode trace context: %w", err)
+	}
+	traceContext := string(encoded)
+	return &traceContext, nil
+}
@@ -0,0 +1,294 @@
+package outbox_comp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/rabbitmq_comp"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/logger"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// relayLockKey is the Postgres advisory lock held by the relay publishing a
+// round, so that only one worker relays at a time and per aggregate order
+// holds across workers.
+const relayLockKey = 7_301_202_118
+
+// eventVersion is the envelope version of domain events, which are not
+// versioned yet.
+const eventVersion = 1
+
+// sweepInterval is how often the relay deletes the events past retention.
+const sweepInterval = time.Hour
+
+// Relay publishes pending outbox events to RabbitMQ. Events of one aggregate
+// are published in the order they were saved: only the oldest pending event
+// of an aggregate is picked, so a failing event holds back the ones after it
+// until it is published or dead-lettered.
+type Relay struct {
+	db     *gorm.DB
+	client rabbitmq_comp.IRabbitMQClient
+	config *OutboxConfig
+	log    logger.Logger
+
+	stop chan struct{}
+	done sync.WaitGroup
+}
+
+func NewRelay(db *gorm.DB, client rabbitmq_comp.IRabbitMQClient, config *OutboxConfig, log logger.Logger) *Relay {
+	return &Relay{
+		db:     db,
+		client: client,
+		config: config,
+		log:    log,
+		stop:   make(chan struct{}),
+	}
+}
+
+func (r *Relay) Start() error {
+	if err := r.declareExchange(); err != nil {
+		r.log.Errorw("failed to declare outbox exchange", logger.Fields{
+			"exchange": r.config.Exchange,
+			"error":    err.Error(),
+		})
+	}
+
+	r.done.Add(1)
+	go r.run()
+	return nil
+}
+
+// Stop waits for the round in progress to finish.
+func (r *Relay) Stop() {
+	close(r.stop)
+	r.done.Wait()
+}
+
+func (r *Relay) declareExchange() error {
+	return r.client.DeclareTopology(rabbitmq_comp.TopologySpec{
+		Exchanges: []rabbitmq_comp.ExchangeSpec{{Name: r.config.Exchange}},
+	}.Declare)
+}
+
+func (r *Relay) run() {
+	defer r.done.Done()
+
+	ticker := time.NewTicker(r.config.PollInterval)
+	defer ticker.Stop()
+
+	var swept time.Time
+	for {
+		if time.Since(swept) >= sweepInterval {
+			swept = time.Now()
+			if _, err := r.Sweep(context.Background()); err != nil {
+				r.log.Errorw("outbox sweep failed", logger.Fields{"error": err.Error()})
+			}
+		}
+
+		// Keep going while rounds are full, there is a backlog.
+		for {
+			picked, err := r.RelayOnce(context.Background())
+			if err != nil {
+				r.log.Errorw("outbox relay round failed", logger.Fields{"error": err.Error()})
+				break
+			}
+			if picked < r.config.BatchSize {
+				break
+			}
+			select {
+			case <-r.stop:
+				return
+			default:
+			}
+		}
+
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayOnce publishes one round of due events and returns how many were
+// picked.
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+	// Rounds are skipped while RabbitMQ reconnects, rather than spending the
+	// events' attempts.
+	if !r.client.IsConnected() {
+		return 0, nil
+	}
+
+	var picked int
+	err := r.db.WithContext(ctx).Clauses(dbresolver.Write).Transaction(func(tx *gorm.DB) error {
+		if tx.Dialector.Name() == "postgres" {
+			var locked bool
+			if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", relayLockKey).Scan(&locked).Error; err != nil {
+				return err
+			}
+			if !locked {
+				return nil
+			}
+		}
+
+		messages, err := r.due(tx)
+		if err != nil {
+			return err
+		}
+		picked = len(messages)
+
+		// The round holds the transaction and the lock, so a broker that
+		// stalls confirms must not keep it waiting for ever.
+		publishCtx := ctx
+		if r.config.ConfirmTimeout > 0 {
+			var cancel context.CancelFunc
+			publishCtx, cancel = context.WithTimeout(ctx, r.config.ConfirmTimeout)
+			defer cancel()
+		}
+
+		// Publish the whole round before waiting for the broker, so that
+		// confirms are awaited once per round rather than once per event.
+		confirmations := make([]*rabbitmq_comp.Confirmation, len(messages))
+		publishErrs := make([]error, len(messages))
+		for i, message := range messages {
+			confirmations[i], publishErrs[i] = r.publish(publishCtx, message)
+		}
+		for i, message := range messages {
+			publishErr := publishErrs[i]
+			if publishErr == nil {
+				publishErr = confirmations[i].Wait(publishCtx)
+			}
+			if err := r.record(tx, message, publishErr); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return picked, err
+}
+
+// Sweep deletes the published and unrouted events older than Retention and
+// returns how many it deleted. Dead events are kept for inspection.
+func (r *Relay) Sweep(ctx context.Context) (int64, error) {
+	if r.config.Retention <= 0 {
+		return 0, nil
+	}
+
+	result := r.db.WithContext(ctx).Clauses(dbresolver.Write).
+		Where("status IN ? AND occurred_at < ?", []string{StatusPublished, StatusUnrouted}, time.Now().Add(-r.config.Retention)).
+		Delete(&OutboxMessage{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	if result.RowsAffected > 0 {
+		r.log.Infow("outbox events swept", logger.Fields{"deleted": result.RowsAffected})
+	}
+	return result.RowsAffected, nil
+}
+
+// due picks the oldest pending event of each aggregate, if it is due.
+func (r *Relay) due(tx *gorm.DB) ([]*OutboxMessage, error) {
+	var messages []*OutboxMessage
+	err := tx.
+		Where("status = ? AND next_attempt_at <= ?", StatusPending, time.Now()).
+		Where(`NOT EXISTS (
+			SELECT 1 FROM outbox earlier
+			WHERE earlier.aggregate_type = outbox.aggregate_type
+				AND earlier.aggregate_id = outbox.aggregate_id
+				AND earlier.status = ?
+				AND earlier.seq < outbox.seq
+		)`, StatusPending).
+		Order("seq").
+		Limit(r.config.BatchSize).
+		Find(&messages).Error
+	return messages, err
+}
+
+// record saves the outcome of publishing one event. A failed publish is
+// retried with exponential backoff, and dead-lettered after MaxAttempts. An
+// event no queue is bound for is not a failure: domain events are published
+// whether or not anyone subscribes to them.
+func (r *Relay) record(tx *gorm.DB, message *OutboxMessage, publishErr error) error {
+	now := time.Now()
+	attempts := message.Attempts + 1
+
+	updates := map[string]any{"attempts": attempts}
+	switch {
+	case publishErr == nil:
+		updates["status"] = StatusPublished
+		updates["published_at"] = now
+		updates["last_error"] = nil
+	case errors.Is(publishErr, rabbitmq_comp.ErrUnroutable):
+		updates["status"] = StatusUnrouted
+		updates["last_error"] = publishErr.Error()
+		r.log.Infow("outbox event has no subscribers", logger.Fields{
+			"id":         message.ID,
+			"event_type": message.EventType,
+		})
+	case attempts >= r.config.MaxAttempts:
+		updates["status"] = StatusDead
+		updates["last_error"] = publishErr.Error()
+		r.log.Errorw("outbox event dead-lettered", logger.Fields{
+			"id":             message.ID,
+			"event_type":     message.EventType,
+			"aggregate_type": message.AggregateType,
+			"aggregate_id":   message.AggregateID,
+			"attempts":       attempts,
+			"error":          publishErr.Error(),
+		})
+	default:
+		updates["next_attempt_at"] = now.Add(r.backoff(attempts))
+		updates["last_error"] = publishErr.Error()
+		r.log.Errorw("failed to publish outbox event", logger.Fields{
+			"id":         message.ID,
+			"event_type": message.EventType,
+			"attempts":   attempts,
+			"error":      publishErr.Error(),
+		})
+	}
+
+	return tx.Model(&OutboxMessage{}).Where("id = ?", message.ID).Updates(updates).Error
+}
+
+// publish sends an event as mandatory in confirm mode: it only counts as
+// published once the broker acked it, and is recorded as unrouted when no
+// queue took it. The event is wrapped in an envelope with the outbox row ID
+// and the trace context it was saved in.
+func (r *Relay) publish(ctx context.Context, message *OutboxMessage) (*rabbitmq_comp.Confirmation, error) {
+	envelope := &rabbitmq_comp.Envelope[json.RawMessage]{
+		ID:         message.ID,
+		Type:       message.EventType,
+		Version:    eventVersion,
+		OccurredAt: message.OccurredAt.UTC(),
+		Payload:    json.RawMessage(message.Payload),
+	}
+	if message.TraceContext != nil {
+		if err := json.Unmarshal([]byte(*message.TraceContext), &envelope.TraceContext); err != nil {
+			return nil, fmt.Errorf("decode trace context: %w", err)
+		}
+	}
+	msg, err := envelope.Publishing(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	msg.Headers["aggregate_type"] = message.AggregateType
+	msg.Headers["aggregate_id"] = message.AggregateID
+	if message.RequestID != nil {
+		msg.Headers["request_id"] = *message.RequestID
+	}
+	return r.client.PublishDeferred(ctx, r.config.Exchange, message.EventType, msg)
+}
+
+func (r *Relay) backoff(attempts int) time.Duration {
+	delay := r.config.RetryBaseDelay
+	for i := 1; i < attempts && delay < r.config.RetryMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, r.config.RetryMaxDelay)
+}
@@ -0,0 +1,345 @@
+package outbox_comp
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gorm_comp/gormtest"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/rabbitmq_comp"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/global_config"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/logger"
+	log_cfg "github.com/dukk308/beetool.dev-go-starter/pkgs/logger/config"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+type testEvent struct {
+	Name string `json:"name"`
+}
+
+func (e testEvent) EventName() string {
+	return "test." + e.Name
+}
+
+// fakeClient answers every publish right away, with the error fail returns
+// for it.
+type fakeClient struct {
+	rabbitmq_comp.IRabbitMQClient
+
+	mu           sync.Mutex
+	disconnected bool
+	// stalled publishes are never confirmed.
+	stalled   bool
+	fail      func(msg amqp.Publishing) error
+	published []amqp.Publishing
+}
+
+func (c *fakeClient) IsConnected() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return !c.disconnected
+}
+
+func (c *fakeClient) PublishDeferred(ctx context.Context, exchange, key string, msg amqp.Publishing) (*rabbitmq_comp.Confirmation, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.stalled {
+		return &rabbitmq_comp.Confirmation{}, nil
+	}
+
+	var err error
+	if c.fail != nil {
+		err = c.fail(msg)
+	}
+	if err == nil {
+		c.published = append(c.published, msg)
+	}
+	return rabbitmq_comp.ResolvedConfirmation(err), nil
+}
+
+// publishedTypes lists the event types published so far, in order.
+func (c *fakeClient) publishedTypes() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	types := make([]string, len(c.published))
+	for i, msg := range c.published {
+		types[i] = msg.Type
+	}
+	return types
+}
+
+func newTestRelay(t *testing.T, client *fakeClient) (*Relay, *Outbox, *gorm.DB) {
+	t.Helper()
+
+	db := gormtest.Open(t, &OutboxMessage{})
+	log := logger.NewZapLogger(&log_cfg.LogOptions{}, &global_config.GlobalConfig{LogLevel: "fatal"})
+	relay := NewRelay(db, client, &OutboxConfig{
+		Exchange:    "domain_events",
+		BatchSize:   100,
+		MaxAttempts: 3,
+		// Failed events are due again right away.
+		RetryBaseDelay: 0,
+		RetryMaxDelay:  0,
+		ConfirmTimeout: time.Second,
+		Retention:      time.Hour,
+	}, log)
+	return relay, NewOutbox(db), db
+}
+
+func appendEvents(t *testing.T, outbox *Outbox, aggregateID string, names ...string) {
+	t.Helper()
+
+	events := make([]base.DomainEvent, len(names))
+	for i, name := range names {
+		events[i] = testEvent{Name: name}
+	}
+	if err := outbox.Append(context.Background(), "test", aggregateID, events); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func relayRounds(t *testing.T, relay *Relay, rounds int) {
+	t.Helper()
+
+	for i := 0; i < rounds; i++ {
+		if _, err := relay.RelayOnce(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func statusOf(t *testing.T, db *gorm.DB, eventType string) string {
+	t.Helper()
+
+	var message OutboxMessage
+	if err := db.Where("event_type = ?", eventType).First(&message).Error; err != nil {
+		t.Fatal(err)
+	}
+	return message.Status
+}
+
+func assertTypes(t *testing.T, got []string, want ...string) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("published %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("published %v, want %v", got, want)
+		}
+	}
+}
+
+func TestRelayPublishesEachAggregateInOrder(t *testing.T) {
+	failures := 1
+	client := &fakeClient{fail: func(msg amqp.Publishing) error {
+		if msg.Type == "test.a1" && failures > 0 {
+			failures--
+			return errors.New("connection reset")
+		}
+		return nil
+	}}
+	relay, outbox, _ := newTestRelay(t, client)
+	appendEvents(t, outbox, "a", "a1", "a2", "a3")
+	appendEvents(t, outbox, "b", "b1")
+
+	// a1 fails and holds back a2 and a3; b1 is not held back.
+	relayRounds(t, relay, 1)
+	assertTypes(t, client.publishedTypes(), "test.b1")
+
+	relayRounds(t, relay, 3)
+	assertTypes(t, client.publishedTypes(), "test.b1", "test.a1", "test.a2", "test.a3")
+}
+
+func TestRelayDeadLettersAfterMaxAttempts(t *testing.T) {
+	client := &fakeClient{fail: func(msg amqp.Publishing) error {
+		if msg.Type == "test.a1" {
+			return errors.New("connection reset")
+		}
+		return nil
+	}}
+	relay, outbox, db := newTestRelay(t, client)
+	appendEvents(t, outbox, "a", "a1", "a2")
+
+	relayRounds(t, relay, 3)
+	if status := statusOf(t, db, "test.a1"); status != StatusDead {
+		t.Fatalf("a1 is %s, want dead", status)
+	}
+	assertTypes(t, client.publishedTypes())
+
+	// The dead event no longer holds back its aggregate.
+	relayRounds(t, relay, 1)
+	assertTypes(t, client.publishedTypes(), "test.a2")
+}
+
+func TestRelayMarksUnroutableEventsWithoutRetrying(t *testing.T) {
+	client := &fakeClient{fail: func(msg amqp.Publishing) error {
+		if msg.Type == "test.a1" {
+			return rabbitmq_comp.ErrUnroutable
+		}
+		return nil
+	}}
+	relay, outbox, db := newTestRelay(t, client)
+	appendEvents(t, outbox, "a", "a1", "a2")
+
+	relayRounds(t, relay, 1)
+	if status := statusOf(t, db, "test.a1"); status != StatusUnrouted {
+		t.Fatalf("a1 is %s, want unrouted", status)
+	}
+
+	relayRounds(t, relay, 1)
+	assertTypes(t, client.publishedTypes(), "test.a2")
+	if status := statusOf(t, db, "test.a2"); status != StatusPublished {
+		t.Fatalf("a2 is %s, want published", status)
+	}
+}
+
+func TestRelaySkipsRoundsWhileDisconnected(t *testing.T) {
+	client := &fakeClient{disconnected: true}
+	relay, outbox, db := newTestRelay(t, client)
+	appendEvents(t, outbox, "a", "a1")
+
+	relayRounds(t, relay, 5)
+	var message OutboxMessage
+	if err := db.First(&message).Error; err != nil {
+		t.Fatal(err)
+	}
+	if message.Status != StatusPending || message.Attempts != 0 {
+		t.Fatalf("event is %s after %d attempts, want pending and untouched", message.Status, message.Attempts)
+	}
+}
+
+func TestRelayPublishesEnvelopes(t *testing.T) {
+	client := &fakeClient{}
+	relay, outbox, db := newTestRelay(t, client)
+	appendEvents(t, outbox, "a", "a1")
+	relayRounds(t, relay, 1)
+
+	var message OutboxMessage
+	if err := db.First(&message).Error; err != nil {
+		t.Fatal(err)
+	}
+	published := client.published[0]
+	envelope, err := rabbitmq_comp.DecodeEnvelope[testEvent](published.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if envelope.ID != message.ID || published.MessageId != message.ID {
+		t.Errorf("envelope %q and message %q IDs, want the outbox row ID %q", envelope.ID, published.MessageId, message.ID)
+	}
+	if envelope.Type != "test.a1" || envelope.Version != 1 || envelope.Payload.Name != "a1" {
+		t.Errorf("envelope = %+v", envelope)
+	}
+	if published.Headers["aggregate_type"] != "test" || published.Headers["aggregate_id"] != "a" {
+		t.Errorf("headers = %v", published.Headers)
+	}
+}
+
+func TestRelayPublishesInTheTraceTheEventWasSavedIn(t *testing.T) {
+	propagator := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { otel.SetTextMapPropagator(propagator) })
+
+	client := &fakeClient{}
+	relay, outbox, _ := newTestRelay(t, client)
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+	if err := outbox.Append(ctx, "test", "a", []base.DomainEvent{testEvent{Name: "a1"}}); err != nil {
+		t.Fatal(err)
+	}
+	relayRounds(t, relay, 1)
+
+	const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	published := client.published[0]
+	envelope, err := rabbitmq_comp.DecodeEnvelope[testEvent](published.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := envelope.TraceContext["traceparent"]; got != traceparent {
+		t.Errorf("envelope traceparent = %q, want %q", got, traceparent)
+	}
+	if got := published.Headers["traceparent"]; got != traceparent {
+		t.Errorf("traceparent header = %v, want %q", got, traceparent)
+	}
+	if got := trace.SpanContextFromContext(envelope.Context(context.Background())).TraceID(); got != traceID {
+		t.Errorf("consumer trace ID = %s, want %s", got, traceID)
+	}
+}
+
+func TestRelayRoundGivesUpOnStalledConfirms(t *testing.T) {
+	client := &fakeClient{stalled: true}
+	relay, outbox, db := newTestRelay(t, client)
+	relay.config.ConfirmTimeout = 50 * time.Millisecond
+	appendEvents(t, outbox, "a", "a1")
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		relayRounds(t, relay, 1)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("round still waiting for the broker")
+	}
+
+	var message OutboxMessage
+	if err := db.First(&message).Error; err != nil {
+		t.Fatal(err)
+	}
+	if message.Status != StatusPending || message.Attempts != 1 || message.LastError == nil {
+		t.Fatalf("event is %s after %d attempts, want pending to retry the unconfirmed publish", message.Status, message.Attempts)
+	}
+}
+
+func TestRelaySweepDeletesOldFinishedEvents(t *testing.T) {
+	relay, outbox, db := newTestRelay(t, &fakeClient{})
+	appendEvents(t, outbox, "a", "published", "unrouted", "dead", "pending")
+	appendEvents(t, outbox, "b", "recent")
+
+	old := time.Now().Add(-2 * time.Hour)
+	for eventType, status := range map[string]string{
+		"test.published": StatusPublished,
+		"test.unrouted":  StatusUnrouted,
+		"test.dead":      StatusDead,
+		"test.pending":   StatusPending,
+	} {
+		err := db.Model(&OutboxMessage{}).Where("event_type = ?", eventType).
+			Updates(map[string]any{"status": status, "occurred_at": old}).Error
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := db.Model(&OutboxMessage{}).Where("event_type = ?", "test.recent").Update("status", StatusPublished).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	deleted, err := relay.Sweep(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deleted != 2 {
+		t.Fatalf("swept %d events, want 2", deleted)
+	}
+	var left []string
+	if err := db.Model(&OutboxMessage{}).Order("seq").Pluck("event_type", &left).Error; err != nil {
+		t.Fatal(err)
+	}
+	assertTypes(t, left, "test.dead", "test.pending", "test.recent")
+}
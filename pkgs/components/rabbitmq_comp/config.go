@@ -1,7 +1,26 @@
 package rabbitmq_comp
 
+import "time"
+
+type PublishMode string
+
+const (
+	// PublishModeBuffer makes publishes wait for the connection while
+	// reconnecting, up to PublishBufferSize publishes for PublishTimeout.
+	PublishModeBuffer PublishMode = "buffer"
+	// PublishModeFailFast makes publishes fail with ErrNotConnected while
+	// reconnecting.
+	PublishModeFailFast PublishMode = "fail-fast"
+)
+
 type RabbitMQConfig struct {
-	URL           string
-	PrefetchCount int
-	PrefetchSize  int
+	URL                string
+	PrefetchCount      int
+	PrefetchSize       int
+	ReconnectBaseDelay time.Duration
+	ReconnectMaxDelay  time.Duration
+	PublisherPoolSize  int
+	PublishMode        PublishMode
+	PublishBufferSize  int
+	PublishTimeout     time.Duration
 }
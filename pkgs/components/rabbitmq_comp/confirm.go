@@ -0,0 +1,230 @@
+package rabbitmq_comp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/logger"
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// publishIDHeader carries the ID a returned message is matched to its publish
+// by.
+const publishIDHeader = "publish_id"
+
+var (
+	ErrUnroutable    = errors.New("rabbitmq returned the message as unroutable")
+	ErrPublishNacked = errors.New("rabbitmq nacked the publish")
+	ErrConfirmLost   = errors.New("rabbitmq channel closed before the publish was confirmed")
+)
+
+// Confirmation is the broker's answer to one confirmed publish.
+type Confirmation struct {
+	started time.Time
+	done    chan struct{}
+	err     error
+	onDone  func(c *Confirmation)
+}
+
+func newConfirmation(onDone func(c *Confirmation)) *Confirmation {
+	return &Confirmation{
+		started: time.Now(),
+		done:    make(chan struct{}),
+		onDone:  onDone,
+	}
+}
+
+// ResolvedConfirmation returns a confirmation already answered with err, for
+// IRabbitMQClient implementations without a broker, such as test fakes.
+func ResolvedConfirmation(err error) *Confirmation {
+	c := newConfirmation(nil)
+	c.resolve(err)
+	return c
+}
+
+// Done is closed once the broker has answered.
+func (c *Confirmation) Done() <-chan struct{} {
+	return c.done
+}
+
+// Wait blocks until the broker acks or nacks the publish, or ctx is done. An
+// unroutable message fails with ErrUnroutable even though the broker acks it.
+func (c *Confirmation) Wait(ctx context.Context) error {
+	select {
+	case <-c.done:
+		return c.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *Confirmation) resolve(err error) {
+	c.err = err
+	close(c.done)
+	if c.onDone != nil {
+		c.onDone(c)
+	}
+}
+
+// confirmChannel is a publishing channel in confirm mode. It matches the
+// broker's acks, nacks and returns to the publishes waiting for them.
+type confirmChannel struct {
+	ch  *amqp.Channel
+	log logger.Logger
+
+	mu       sync.Mutex
+	pending  map[uint64]*pendingPublish
+	returned map[string]amqp.Return
+}
+
+type pendingPublish struct {
+	publishID    string
+	confirmation *Confirmation
+}
+
+func newConfirmChannel(conn *amqp.Connection, log logger.Logger) (*confirmChannel, error) {
+	ch, err := conn.Channel()
+	if err != nil {
+		return nil, err
+	}
+	if err := ch.Confirm(false); err != nil {
+		_ = ch.Close()
+		return nil, err
+	}
+
+	c := &confirmChannel{
+		ch:       ch,
+		log:      log,
+		pending:  map[uint64]*pendingPublish{},
+		returned: map[string]amqp.Return{},
+	}
+	confirms := ch.NotifyPublish(make(chan amqp.Confirmation, 64))
+	returns := ch.NotifyReturn(make(chan amqp.Return, 64))
+	go c.listen(confirms, returns)
+	return c, nil
+}
+
+// publish sends msg and, when confirmation is not nil, resolves it with the
+// broker's answer. The channel must not be used by anyone else meanwhile.
+func (c *confirmChannel) publish(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing, confirmation *Confirmation) error {
+	tag := c.ch.GetNextPublishSeqNo()
+	if confirmation != nil {
+		publishID := uuid.NewString()
+		headers := make(amqp.Table, len(msg.Headers)+1)
+		for k, v := range msg.Headers {
+			headers[k] = v
+		}
+		headers[publishIDHeader] = publishID
+		msg.Headers = headers
+
+		c.mu.Lock()
+		c.pending[tag] = &pendingPublish{publishID: publishID, confirmation: confirmation}
+		c.mu.Unlock()
+	}
+
+	if err := c.ch.PublishWithContext(ctx, exchange, key, mandatory, immediate, msg); err != nil {
+		if confirmation != nil {
+			c.mu.Lock()
+			delete(c.pending, tag)
+			c.mu.Unlock()
+		}
+		return err
+	}
+	return nil
+}
+
+// listen runs until the channel closes. The broker sends a message's return
+// before its ack, so pending returns are taken in before every confirmation.
+func (c *confirmChannel) listen(confirms <-chan amqp.Confirmation, returns <-chan amqp.Return) {
+	for {
+		select {
+		case ret, ok := <-returns:
+			if !ok {
+				returns = nil
+				continue
+			}
+			c.recordReturn(ret)
+		case confirm, ok := <-confirms:
+			if !ok {
+				c.failPending()
+				return
+			}
+			c.drainReturns(returns)
+			c.resolve(confirm)
+		}
+	}
+}
+
+func (c *confirmChannel) drainReturns(returns <-chan amqp.Return) {
+	for returns != nil {
+		select {
+		case ret, ok := <-returns:
+			if !ok {
+				return
+			}
+			c.recordReturn(ret)
+		default:
+			return
+		}
+	}
+}
+
+func (c *confirmChannel) recordReturn(ret amqp.Return) {
+	publishID, _ := ret.Headers[publishIDHeader].(string)
+	if publishID == "" {
+		// Not a confirmed publish: nobody waits for it, so say it was lost.
+		c.log.Errorw("unroutable RabbitMQ message returned", logger.Fields{
+			"exchange":    ret.Exchange,
+			"routing_key": ret.RoutingKey,
+			"message_id":  ret.MessageId,
+			"reply_text":  ret.ReplyText,
+		})
+		return
+	}
+
+	c.mu.Lock()
+	c.returned[publishID] = ret
+	c.mu.Unlock()
+}
+
+func (c *confirmChannel) resolve(confirm amqp.Confirmation) {
+	c.mu.Lock()
+	pending, ok := c.pending[confirm.DeliveryTag]
+	delete(c.pending, confirm.DeliveryTag)
+	var ret amqp.Return
+	var returned bool
+	if ok {
+		ret, returned = c.returned[pending.publishID]
+		delete(c.returned, pending.publishID)
+	}
+	c.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	switch {
+	case !confirm.Ack:
+		pending.confirmation.resolve(ErrPublishNacked)
+	case returned:
+		pending.confirmation.resolve(fmt.Errorf("%w: exchange %q, routing key %q: %s",
+			ErrUnroutable, ret.Exchange, ret.RoutingKey, ret.ReplyText))
+	default:
+		pending.confirmation.resolve(nil)
+	}
+}
+
+func (c *confirmChannel) failPending() {
+	c.mu.Lock()
+	pending := c.pending
+	c.pending = map[uint64]*pendingPublish{}
+	c.returned = map[string]amqp.Return{}
+	c.mu.Unlock()
+
+	for _, p := range pending {
+		p.confirmation.resolve(ErrConfirmLost)
+	}
+}
@@ -0,0 +1,183 @@
+package rabbitmq_comp
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/logger"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// connection keeps one AMQP connection open, redialing with exponential
+// backoff whenever it closes. onConnect runs on every new connection before
+// it is handed out, and onDisconnect after it is lost.
+type connection struct {
+	name         string
+	config       *RabbitMQConfig
+	log          logger.Logger
+	onConnect    func(conn *amqp.Connection) error
+	onDisconnect func()
+	// connectMu is held from onConnect until the connection is handed out.
+	connectMu *sync.Mutex
+
+	mu    sync.RWMutex
+	conn  *amqp.Connection
+	ready chan struct{} // closed while conn is set
+
+	started bool
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+func newConnection(name string, config *RabbitMQConfig, log logger.Logger, connectMu *sync.Mutex) *connection {
+	return &connection{
+		name:         name,
+		config:       config,
+		log:          log,
+		onConnect:    func(*amqp.Connection) error { return nil },
+		onDisconnect: func() {},
+		connectMu:    connectMu,
+		ready:        make(chan struct{}),
+		stop:         make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+}
+
+func (c *connection) start() {
+	c.mu.Lock()
+	c.started = true
+	c.mu.Unlock()
+	go c.run()
+}
+
+// close closes the connection and stops redialing.
+func (c *connection) close() {
+	close(c.stop)
+
+	c.mu.RLock()
+	started := c.started
+	c.mu.RUnlock()
+	if started {
+		<-c.done
+	}
+}
+
+func (c *connection) run() {
+	defer close(c.done)
+
+	delay := c.config.ReconnectBaseDelay
+	for attempt := 1; ; attempt++ {
+		conn, closed, err := c.connect()
+		if err != nil {
+			c.log.Errorw("failed to connect to RabbitMQ", logger.Fields{
+				"connection": c.name,
+				"attempt":    attempt,
+				"retry_in":   delay.String(),
+				"error":      err.Error(),
+			})
+			select {
+			case <-c.stop:
+				return
+			case <-time.After(delay):
+			}
+			delay = min(delay*2, c.config.ReconnectMaxDelay)
+			continue
+		}
+
+		c.log.Infow("connected to RabbitMQ", logger.Fields{"connection": c.name})
+		attempt, delay = 0, c.config.ReconnectBaseDelay
+
+		select {
+		case <-c.stop:
+			c.unset()
+			_ = conn.Close()
+			return
+		case amqpErr := <-closed:
+			c.unset()
+			fields := logger.Fields{"connection": c.name}
+			if amqpErr != nil {
+				fields["error"] = amqpErr.Error()
+			}
+			c.log.Errorw("RabbitMQ connection lost, reconnecting", fields)
+		}
+	}
+}
+
+func (c *connection) dial() (*amqp.Connection, error) {
+	properties := amqp.NewConnectionProperties()
+	properties.SetClientConnectionName(c.name)
+
+	conn, err := amqp.DialConfig(c.config.URL, amqp.Config{
+		Heartbeat:  10 * time.Second,
+		Locale:     "en_US",
+		Properties: properties,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return conn, nil
+}
+
+// connect dials and hands out a connection once onConnect has run on it.
+// connectMu is held meanwhile, so that whatever onConnect sets up cannot
+// change between onConnect and the connection becoming current.
+func (c *connection) connect() (*amqp.Connection, <-chan *amqp.Error, error) {
+	conn, err := c.dial()
+	if err != nil {
+		return nil, nil, err
+	}
+	closed := conn.NotifyClose(make(chan *amqp.Error, 1))
+
+	c.connectMu.Lock()
+	defer c.connectMu.Unlock()
+	if err := c.onConnect(conn); err != nil {
+		_ = conn.Close()
+		return nil, nil, err
+	}
+	c.set(conn)
+	return conn, closed, nil
+}
+
+func (c *connection) set(conn *amqp.Connection) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.conn = conn
+	close(c.ready)
+}
+
+func (c *connection) unset() {
+	c.mu.Lock()
+	c.conn = nil
+	c.ready = make(chan struct{})
+	c.mu.Unlock()
+
+	c.onDisconnect()
+}
+
+// current returns the open connection, or nil while reconnecting.
+func (c *connection) current() *amqp.Connection {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.conn
+}
+
+// wait returns the open connection, waiting for it while reconnecting.
+func (c *connection) wait(ctx context.Context) (*amqp.Connection, error) {
+	for {
+		c.mu.RLock()
+		conn, ready := c.conn, c.ready
+		c.mu.RUnlock()
+		if conn != nil {
+			return conn, nil
+		}
+
+		select {
+		case <-ready:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-c.stop:
+			return nil, ErrClientClosed
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package rabbitmq_comp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/logger"
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+func (c *RabbitMQComponent) Consume(ctx context.Context, opts ConsumeOptions) (<-chan amqp.Delivery, error) {
+	if opts.Queue == "" {
+		return nil, errors.New("consume needs a queue")
+	}
+	if opts.Consumer == "" {
+		opts.Consumer = fmt.Sprintf("%s-%s", opts.Queue, uuid.NewString())
+	}
+	if opts.Prefetch == 0 {
+		opts.Prefetch = c.config.PrefetchCount
+	}
+
+	select {
+	case <-c.stop:
+		return nil, ErrClientClosed
+	default:
+	}
+
+	out := make(chan amqp.Delivery)
+	c.consumers.Add(1)
+	go c.consume(ctx, opts, out)
+	return out, nil
+}
+
+// consume subscribes on its own channel of the consuming connection, and
+// subscribes again after the channel or connection is lost.
+func (c *RabbitMQComponent) consume(ctx context.Context, opts ConsumeOptions, out chan<- amqp.Delivery) {
+	defer c.consumers.Done()
+	defer close(out)
+
+	delay := c.config.ReconnectBaseDelay
+	for {
+		conn, err := c.consumer.wait(ctx)
+		if err != nil {
+			return
+		}
+
+		ch, deliveries, err := c.subscribe(conn, opts)
+		if err != nil {
+			c.log.Errorw("failed to consume RabbitMQ queue", logger.Fields{
+				"queue":    opts.Queue,
+				"retry_in": delay.String(),
+				"error":    err.Error(),
+			})
+			select {
+			case <-ctx.Done():
+				return
+			case <-c.stop:
+				return
+			case <-time.After(delay):
+			}
+			delay = min(delay*2, c.config.ReconnectMaxDelay)
+			continue
+		}
+		delay = c.config.ReconnectBaseDelay
+
+		closed := ch.NotifyClose(make(chan *amqp.Error, 1))
+		var settled *settlement
+		if !opts.AutoAck {
+			settled = newSettlement(ch)
+		}
+		c.forward(ctx, ch, opts.Consumer, deliveries, out, settled)
+		c.awaitSettlement(settled, closed)
+		_ = ch.Close()
+		if ctx.Err() != nil {
+			return
+		}
+		select {
+		case <-c.stop:
+			return
+		default:
+		}
+		c.log.Infow("RabbitMQ consumer channel closed, subscribing again", logger.Fields{"queue": opts.Queue})
+	}
+}
+
+func (c *RabbitMQComponent) subscribe(conn *amqp.Connection, opts ConsumeOptions) (*amqp.Channel, <-chan amqp.Delivery, error) {
+	ch, err := conn.Channel()
+	if err != nil {
+		return nil, nil, err
+	}
+	if opts.Prefetch > 0 || c.config.PrefetchSize > 0 {
+		if err := ch.Qos(opts.Prefetch, c.config.PrefetchSize, false); err != nil {
+			_ = ch.Close()
+			return nil, nil, err
+		}
+	}
+	deliveries, err := ch.Consume(opts.Queue, opts.Consumer, opts.AutoAck, opts.Exclusive, opts.NoLocal, false, opts.Args)
+	if err != nil {
+		_ = ch.Close()
+		return nil, nil, err
+	}
+	return ch, deliveries, nil
+}
+
+// forward passes deliveries on until the channel closes. Once ctx is done the
+// consumer is cancelled, and the deliveries already received are still
+// passed on. Deliveries passed on are tracked by settled, when not nil.
+func (c *RabbitMQComponent) forward(ctx context.Context, ch *amqp.Channel, tag string, deliveries <-chan amqp.Delivery, out chan<- amqp.Delivery, settled *settlement) {
+	done := ctx.Done()
+	for {
+		select {
+		case msg, ok := <-deliveries:
+			if !ok {
+				return
+			}
+			if settled != nil {
+				msg = settled.track(msg)
+			}
+			select {
+			case out <- msg:
+			case <-c.stop:
+				return
+			}
+		case <-done:
+			done = nil
+			if err := ch.Cancel(tag, false); err != nil {
+				return
+			}
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+// awaitSettlement waits until the deliveries passed on from a channel are
+// acked, nacked or rejected, since closing the channel would requeue the ones
+// still being handled. It gives up once the channel is lost or the component
+// stops, which happens after the worker's drain deadline.
+func (c *RabbitMQComponent) awaitSettlement(settled *settlement, closed <-chan *amqp.Error) {
+	if settled == nil {
+		return
+	}
+	for settled.pending() > 0 {
+		select {
+		case <-settled.changed:
+		case <-closed:
+			return
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+// settlement is the acknowledger of the deliveries of one channel. It counts
+// the deliveries passed on but not settled yet.
+type settlement struct {
+	acknowledger amqp.Acknowledger
+	changed      chan struct{}
+
+	mu          sync.Mutex
+	outstanding map[uint64]struct{}
+}
+
+func newSettlement(acknowledger amqp.Acknowledger) *settlement {
+	return &settlement{
+		acknowledger: acknowledger,
+		changed:      make(chan struct{}, 1),
+		outstanding:  map[uint64]struct{}{},
+	}
+}
+
+func (s *settlement) track(msg amqp.Delivery) amqp.Delivery {
+	s.mu.Lock()
+	s.outstanding[msg.DeliveryTag] = struct{}{}
+	s.mu.Unlock()
+
+	msg.Acknowledger = s
+	return msg
+}
+
+func (s *settlement) pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.outstanding)
+}
+
+func (s *settlement) Ack(tag uint64, multiple bool) error {
+	defer s.settle(tag, multiple)
+	return s.acknowledger.Ack(tag, multiple)
+}
+
+func (s *settlement) Nack(tag uint64, multiple bool, requeue bool) error {
+	defer s.settle(tag, multiple)
+	return s.acknowledger.Nack(tag, multiple, requeue)
+}
+
+func (s *settlement) Reject(tag uint64, requeue bool) error {
+	defer s.settle(tag, false)
+	return s.acknowledger.Reject(tag, requeue)
+}
+
+// settle forgets tag, and every earlier tag when multiple, whether or not
+// the broker got the acknowledgement: a failed one means the channel is gone.
+func (s *settlement) settle(tag uint64, multiple bool) {
+	s.mu.Lock()
+	if multiple {
+		for outstanding := range s.outstanding {
+			if outstanding <= tag {
+				delete(s.outstanding, outstanding)
+			}
+		}
+	} else {
+		delete(s.outstanding, tag)
+	}
+	s.mu.Unlock()
+
+	select {
+	case s.changed <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,159 @@
+package rabbitmq_comp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/logger"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/utils/request_id"
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// Message is a payload with a messaging contract. Its type names the message,
+// e.g. "blog.published", and is the routing key it is published with; its
+// version is bumped on breaking changes to the payload.
+type Message interface {
+	MessageType() string
+	MessageVersion() int
+}
+
+// Envelope is the JSON body of every message published with Publish.
+type Envelope[T any] struct {
+	ID         string    `json:"id"`
+	Type       string    `json:"type"`
+	Version    int       `json:"version"`
+	OccurredAt time.Time `json:"occurred_at"`
+	// TraceContext holds the W3C trace context of the publisher, e.g.
+	// "traceparent".
+	TraceContext map[string]string `json:"trace_context,omitempty"`
+	Payload      T                 `json:"payload"`
+}
+
+// NewEnvelope wraps payload, carrying the trace context of ctx.
+func NewEnvelope[T Message](ctx context.Context, payload T) *Envelope[T] {
+	traceContext := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, traceContext)
+	if len(traceContext) == 0 {
+		traceContext = nil
+	}
+
+	return &Envelope[T]{
+		ID:           uuid.NewString(),
+		Type:         payload.MessageType(),
+		Version:      payload.MessageVersion(),
+		OccurredAt:   time.Now().UTC(),
+		TraceContext: traceContext,
+		Payload:      payload,
+	}
+}
+
+// DecodeEnvelope reads an envelope from a message body.
+func DecodeEnvelope[T any](body []byte) (*Envelope[T], error) {
+	var envelope Envelope[T]
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, fmt.Errorf("decode message envelope: %w", err)
+	}
+	if envelope.ID == "" || envelope.Type == "" {
+		return nil, errors.New("decode message envelope: missing id or type")
+	}
+	return &envelope, nil
+}
+
+// Context returns ctx continuing the trace the envelope was published in.
+func (e *Envelope[T]) Context(ctx context.Context) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(e.TraceContext))
+}
+
+// Publishing returns the persistent AMQP message carrying the envelope. The
+// trace context is also set as headers, for consumers that do not decode the
+// body.
+func (e *Envelope[T]) Publishing(ctx context.Context) (amqp.Publishing, error) {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return amqp.Publishing{}, fmt.Errorf("encode message envelope: %w", err)
+	}
+
+	headers := amqp.Table{}
+	for k, v := range e.TraceContext {
+		headers[k] = v
+	}
+	if id, ok := request_id.Value(ctx); ok && id != "" {
+		headers["request_id"] = id
+	}
+
+	return amqp.Publishing{
+		ContentType:  "application/json",
+		DeliveryMode: amqp.Persistent,
+		MessageId:    e.ID,
+		Type:         e.Type,
+		Timestamp:    e.OccurredAt,
+		Headers:      headers,
+		Body:         body,
+	}, nil
+}
+
+// Publish wraps payload in an envelope and publishes it to exchange in
+// confirm mode, with the message type as routing key.
+func Publish[T Message](ctx context.Context, client IRabbitMQClient, exchange string, payload T) error {
+	envelope := NewEnvelope(ctx, payload)
+	msg, err := envelope.Publishing(ctx)
+	if err != nil {
+		return err
+	}
+	return client.PublishConfirmed(ctx, exchange, envelope.Type, msg)
+}
+
+// Consume hands the envelopes of the messages of opts.Queue to handle, one at
+// a time, until ctx is done. A nil error acks the message; an error requeues
+// it once, and rejects it when it fails again. A message that is not a valid
+// envelope is rejected right away. Handlers run with the trace context of the
+// envelope and its request_id header, and are not cancelled with ctx, so the
+// message in progress is finished.
+func Consume[T any](ctx context.Context, client IRabbitMQClient, log logger.Logger, opts ConsumeOptions, handle func(ctx context.Context, envelope *Envelope[T]) error) error {
+	deliveries, err := client.Consume(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	handlerCtx := context.WithoutCancel(ctx)
+	for msg := range deliveries {
+		fields := logger.Fields{
+			"queue":      opts.Queue,
+			"message_id": msg.MessageId,
+		}
+
+		envelope, err := DecodeEnvelope[T](msg.Body)
+		if err != nil {
+			fields["error"] = err.Error()
+			log.Errorw("rejected RabbitMQ message", fields)
+			if !opts.AutoAck {
+				_ = msg.Reject(false)
+			}
+			continue
+		}
+
+		msgCtx := envelope.Context(handlerCtx)
+		if id, ok := msg.Headers["request_id"].(string); ok && id != "" {
+			msgCtx = request_id.WithValue(msgCtx, id)
+		}
+		if err := handle(msgCtx, envelope); err != nil {
+			fields["type"] = envelope.Type
+			fields["error"] = err.Error()
+			log.Errorw("RabbitMQ message handler failed", fields)
+			if !opts.AutoAck {
+				_ = msg.Nack(false, !msg.Redelivered)
+			}
+			continue
+		}
+		if !opts.AutoAck {
+			_ = msg.Ack(false)
+		}
+	}
+	return nil
+}
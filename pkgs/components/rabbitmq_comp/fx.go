@@ -2,6 +2,7 @@ package rabbitmq_comp
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/dukk308/beetool.dev-go-starter/pkgs/logger"
 	"go.uber.org/fx"
@@ -21,6 +22,7 @@ var (
 	)
 
 	rabbitMQInvokes = fx.Options(
+		fx.Invoke(declareContributedTopology),
 		fx.Invoke(registerRabbitMQHooks),
 	)
 )
@@ -29,6 +31,24 @@ func ProvideRabbitMQClient(comp *RabbitMQComponent) IRabbitMQClient {
 	return comp.GetClient()
 }
 
+type topologyParams struct {
+	fx.In
+
+	Comp  *RabbitMQComponent
+	Specs []TopologySpec `group:"rabbitmq_topologies"`
+}
+
+// declareContributedTopology merges the specs of all modules, so that
+// conflicting declarations fail the app on startup. The merged spec is
+// declared once connected, and again after every reconnection.
+func declareContributedTopology(p topologyParams) error {
+	spec, err := MergeTopologies(p.Specs...)
+	if err != nil {
+		return fmt.Errorf("rabbitmq topology: %w", err)
+	}
+	return p.Comp.DeclareTopology(spec.Declare)
+}
+
 func registerRabbitMQHooks(
 	lc fx.Lifecycle,
 	comp *RabbitMQComponent,
@@ -36,10 +56,7 @@ func registerRabbitMQHooks(
 ) {
 	lc.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
-			if comp.GetClient() == nil {
-				return nil
-			}
-			log.Info("RabbitMQ connection verified")
+			comp.Start()
 			return nil
 		},
 		OnStop: func(ctx context.Context) error {
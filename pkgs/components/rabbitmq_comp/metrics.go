@@ -0,0 +1,69 @@
+package rabbitmq_comp
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const meterName = "rabbitmq"
+
+// publishMetrics records publishes with the global OpenTelemetry meter
+// provider; they are dropped until one is set.
+type publishMetrics struct {
+	duration metric.Float64Histogram
+	failures metric.Int64Counter
+}
+
+func newPublishMetrics() *publishMetrics {
+	meter := otel.Meter(meterName)
+	// Creating instruments only fails on invalid names; the no-op instruments
+	// returned alongside the error are still usable.
+	duration, _ := meter.Float64Histogram("rabbitmq.publish.duration",
+		metric.WithDescription("Time from publishing a message until the broker confirmed it, or until it was sent when not confirmed"),
+		metric.WithUnit("s"),
+	)
+	failures, _ := meter.Int64Counter("rabbitmq.publish.failures",
+		metric.WithDescription("Publishes that failed, by reason"),
+	)
+	return &publishMetrics{
+		duration: duration,
+		failures: failures,
+	}
+}
+
+func (m *publishMetrics) record(exchange string, confirmed bool, started time.Time, err error) {
+	attrs := metric.WithAttributes(
+		attribute.String("exchange", exchange),
+		attribute.Bool("confirmed", confirmed),
+		attribute.String("outcome", publishOutcome(err)),
+	)
+	ctx := context.Background()
+	m.duration.Record(ctx, time.Since(started).Seconds(), attrs)
+	if err != nil {
+		m.failures.Add(ctx, 1, attrs)
+	}
+}
+
+func publishOutcome(err error) string {
+	switch {
+	case err == nil:
+		return "ok"
+	case errors.Is(err, ErrUnroutable):
+		return "unroutable"
+	case errors.Is(err, ErrPublishNacked):
+		return "nacked"
+	case errors.Is(err, ErrConfirmLost):
+		return "confirm_lost"
+	case errors.Is(err, ErrPublishBufferFull):
+		return "buffer_full"
+	case errors.Is(err, ErrNotConnected), errors.Is(err, ErrClientClosed):
+		return "not_connected"
+	default:
+		return "error"
+	}
+}
@@ -0,0 +1,171 @@
+package rabbitmq_comp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/logger"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// channelPool lends the publishing channels of one connection, all in
+// confirm mode. It is replaced on every reconnection.
+type channelPool struct {
+	conn     *amqp.Connection
+	log      logger.Logger
+	channels chan *confirmChannel
+	closed   chan struct{}
+}
+
+func newChannelPool(conn *amqp.Connection, size int, log logger.Logger) (*channelPool, error) {
+	pool := &channelPool{
+		conn:     conn,
+		log:      log,
+		channels: make(chan *confirmChannel, size),
+		closed:   make(chan struct{}),
+	}
+	for i := 0; i < size; i++ {
+		ch, err := newConfirmChannel(conn, log)
+		if err != nil {
+			pool.close()
+			return nil, fmt.Errorf("open publisher channel: %w", err)
+		}
+		pool.channels <- ch
+	}
+	return pool, nil
+}
+
+func (p *channelPool) get(ctx context.Context) (*confirmChannel, error) {
+	select {
+	case ch := <-p.channels:
+		return ch, nil
+	case <-p.closed:
+		return nil, errChannelPoolDrained
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// put gives a channel back. A channel closed by a failed publish is replaced
+// by a new one.
+func (p *channelPool) put(ch *confirmChannel) {
+	if ch.ch.IsClosed() {
+		replacement, err := newConfirmChannel(p.conn, p.log)
+		if err != nil {
+			if !p.conn.IsClosed() {
+				p.log.Errorw("failed to replace publisher channel", logger.Fields{"error": err.Error()})
+			}
+			return
+		}
+		ch = replacement
+	}
+	p.channels <- ch
+}
+
+// close stops lending channels. The channels themselves close with the
+// connection.
+func (p *channelPool) close() {
+	close(p.closed)
+	for {
+		select {
+		case ch := <-p.channels:
+			_ = ch.ch.Close()
+		default:
+			return
+		}
+	}
+}
+
+func (c *RabbitMQComponent) Publish(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+	started := time.Now()
+	err := c.withChannel(ctx, func(ch *confirmChannel) error {
+		return ch.publish(ctx, exchange, key, mandatory, immediate, msg, nil)
+	})
+	c.metrics.record(exchange, false, started, err)
+	return err
+}
+
+func (c *RabbitMQComponent) PublishDeferred(ctx context.Context, exchange, key string, msg amqp.Publishing) (*Confirmation, error) {
+	confirmation := newConfirmation(func(confirmation *Confirmation) {
+		c.metrics.record(exchange, true, confirmation.started, confirmation.err)
+	})
+	err := c.withChannel(ctx, func(ch *confirmChannel) error {
+		return ch.publish(ctx, exchange, key, true, false, msg, confirmation)
+	})
+	if err != nil {
+		c.metrics.record(exchange, true, confirmation.started, err)
+		return nil, err
+	}
+	return confirmation, nil
+}
+
+func (c *RabbitMQComponent) PublishConfirmed(ctx context.Context, exchange, key string, msg amqp.Publishing) error {
+	confirmation, err := c.PublishDeferred(ctx, exchange, key, msg)
+	if err != nil {
+		return err
+	}
+	return confirmation.Wait(ctx)
+}
+
+// withChannel runs publish on a pooled channel.
+func (c *RabbitMQComponent) withChannel(ctx context.Context, publish func(ch *confirmChannel) error) error {
+	for {
+		pool, err := c.publisherPool(ctx)
+		if err != nil {
+			return err
+		}
+
+		ch, err := pool.get(ctx)
+		if errors.Is(err, errChannelPoolDrained) {
+			// The connection was lost while waiting for a channel.
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		err = publish(ch)
+		pool.put(ch)
+		return err
+	}
+}
+
+// publisherPool returns the channel pool of the publishing connection. While
+// reconnecting it fails fast or, in buffer mode, waits for the connection.
+func (c *RabbitMQComponent) publisherPool(ctx context.Context) (*channelPool, error) {
+	if pool := c.currentPool(); pool != nil {
+		return pool, nil
+	}
+	if c.config.PublishMode == PublishModeFailFast {
+		return nil, ErrNotConnected
+	}
+
+	select {
+	case c.buffered <- struct{}{}:
+		defer func() { <-c.buffered }()
+	default:
+		return nil, ErrPublishBufferFull
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.config.PublishTimeout)
+	defer cancel()
+	for {
+		if _, err := c.publisher.wait(ctx); err != nil {
+			if errors.Is(err, ErrClientClosed) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%w: %w", ErrNotConnected, err)
+		}
+		if pool := c.currentPool(); pool != nil {
+			return pool, nil
+		}
+	}
+}
+
+func (c *RabbitMQComponent) currentPool() *channelPool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.pool
+}
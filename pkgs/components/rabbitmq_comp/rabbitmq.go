@@ -1,97 +1,157 @@
 package rabbitmq_comp
 
 import (
-	"context"
 	"sync"
 
 	"github.com/dukk308/beetool.dev-go-starter/pkgs/logger"
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
+// RabbitMQComponent publishes and consumes over two connections, so that
+// flow control on publishing never stalls consumers. Both are redialed when
+// lost; the topology is declared again and the consumers subscribe again.
 type RabbitMQComponent struct {
-	config     *RabbitMQConfig
-	conn       *amqp.Connection
-	channel    *amqp.Channel
-	client     IRabbitMQClient
-	mu         sync.Mutex
-}
+	config    *RabbitMQConfig
+	log       logger.Logger
+	publisher *connection
+	consumer  *connection
+	metrics   *publishMetrics
+
+	mu       sync.RWMutex
+	pool     *channelPool
+	topology []Topology
+	// declareMu serializes DeclareTopology with connecting, so that a
+	// declaration is either in the list a new connection declares or run on
+	// the connection once it is current.
+	declareMu sync.Mutex
 
-type rabbitMQClient struct {
-	conn    *amqp.Connection
-	channel *amqp.Channel
-	config  *RabbitMQConfig
+	// buffered holds a slot for each publish waiting for the connection.
+	buffered  chan struct{}
+	consumers sync.WaitGroup
+	stop      chan struct{}
+	stopOnce  sync.Once
 }
 
 func NewRabbitMQComponent(config *RabbitMQConfig, log logger.Logger) *RabbitMQComponent {
-	c := &RabbitMQComponent{config: config}
-	if err := c.connect(log); err != nil {
-		log.Errorf("Failed to connect to RabbitMQ: %v", err)
-		return c
+	c := &RabbitMQComponent{
+		config:   config,
+		log:      log,
+		metrics:  newPublishMetrics(),
+		buffered: make(chan struct{}, config.PublishBufferSize),
+		stop:     make(chan struct{}),
 	}
-	c.client = &rabbitMQClient{conn: c.conn, channel: c.channel, config: config}
-	return c
-}
+	c.publisher = newConnection("publisher", config, log, &c.declareMu)
+	c.consumer = newConnection("consumer", config, log, &c.declareMu)
 
-func (c *RabbitMQComponent) connect(log logger.Logger) error {
-	conn, err := amqp.Dial(c.config.URL)
-	if err != nil {
-		return err
-	}
-	c.conn = conn
-	ch, err := conn.Channel()
-	if err != nil {
-		_ = conn.Close()
-		return err
-	}
-	if c.config.PrefetchCount > 0 || c.config.PrefetchSize > 0 {
-		if err := ch.Qos(c.config.PrefetchCount, c.config.PrefetchSize, false); err != nil {
-			_ = ch.Close()
-			_ = conn.Close()
+	c.publisher.onConnect = func(conn *amqp.Connection) error {
+		c.declareTopology(conn, c.topologies())
+		pool, err := newChannelPool(conn, config.PublisherPoolSize, log)
+		if err != nil {
 			return err
 		}
+		c.mu.Lock()
+		c.pool = pool
+		c.mu.Unlock()
+		return nil
 	}
-	c.channel = ch
-	log.Info("Connected to RabbitMQ")
-	return nil
+	c.publisher.onDisconnect = func() {
+		c.mu.Lock()
+		pool := c.pool
+		c.pool = nil
+		c.mu.Unlock()
+		if pool != nil {
+			pool.close()
+		}
+	}
+	c.consumer.onConnect = func(conn *amqp.Connection) error {
+		c.declareTopology(conn, c.topologies())
+		return nil
+	}
+
+	return c
+}
+
+// Start dials both connections in the background; the app starts even when
+// the broker is down.
+func (c *RabbitMQComponent) Start() {
+	c.publisher.start()
+	c.consumer.start()
 }
 
 func (c *RabbitMQComponent) GetClient() IRabbitMQClient {
-	return c.client
+	return c
 }
 
-func (c *RabbitMQComponent) Stop() error {
+func (c *RabbitMQComponent) IsConnected() bool {
+	return c.currentPool() != nil
+}
+
+func (c *RabbitMQComponent) DeclareTopology(declare Topology) error {
+	c.declareMu.Lock()
+	defer c.declareMu.Unlock()
+
 	c.mu.Lock()
-	defer c.mu.Unlock()
-	var err error
-	if c.channel != nil {
-		if e := c.channel.Close(); e != nil {
-			err = e
-		}
-		c.channel = nil
+	c.topology = append(c.topology, declare)
+	c.mu.Unlock()
+
+	conn := c.consumer.current()
+	if conn == nil {
+		conn = c.publisher.current()
 	}
-	if c.conn != nil {
-		if e := c.conn.Close(); e != nil {
-			if err == nil {
-				err = e
-			}
+	if conn == nil {
+		return nil
+	}
+	return runTopology(conn, declare)
+}
+
+func (c *RabbitMQComponent) topologies() []Topology {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append([]Topology(nil), c.topology...)
+}
+
+// declareTopology declares the topology on a new connection. A failed
+// declaration is logged and does not fail the connection, so that one bad
+// declaration cannot keep the component offline.
+func (c *RabbitMQComponent) declareTopology(conn *amqp.Connection, topology []Topology) {
+	for _, declare := range topology {
+		if err := runTopology(conn, declare); err != nil {
+			c.log.Errorw("failed to declare RabbitMQ topology", logger.Fields{"error": err.Error()})
 		}
-		c.conn = nil
 	}
-	return err
 }
 
-func (r *rabbitMQClient) Publish(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
-	return r.channel.PublishWithContext(ctx, exchange, key, mandatory, immediate, msg)
+// runTopology declares on a channel of its own, since a failed declaration
+// closes the channel.
+func runTopology(conn *amqp.Connection, declare Topology) error {
+	ch, err := conn.Channel()
+	if err != nil {
+		return err
+	}
+	defer ch.Close()
+	return declare(ch)
 }
 
-func (r *rabbitMQClient) Consume(queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table) (<-chan amqp.Delivery, error) {
-	return r.channel.Consume(queue, consumer, autoAck, exclusive, noLocal, noWait, args)
+func (c *RabbitMQComponent) Channel() (*amqp.Channel, error) {
+	conn := c.consumer.current()
+	if conn == nil {
+		return nil, ErrNotConnected
+	}
+	return conn.Channel()
 }
 
-func (r *rabbitMQClient) Channel() (*amqp.Channel, error) {
-	return r.conn.Channel()
+func (c *RabbitMQComponent) Close() error {
+	return c.Stop()
 }
 
-func (r *rabbitMQClient) Close() error {
+// Stop closes both connections. Consumers are closed without waiting for
+// their deliveries to be handled; cancel their contexts first to drain them.
+func (c *RabbitMQComponent) Stop() error {
+	c.stopOnce.Do(func() {
+		close(c.stop)
+		c.publisher.close()
+		c.consumer.close()
+		c.consumers.Wait()
+	})
 	return nil
 }
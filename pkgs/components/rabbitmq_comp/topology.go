@@ -0,0 +1,173 @@
+package rabbitmq_comp
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.uber.org/fx"
+)
+
+const TopologyGroup = `group:"rabbitmq_topologies"`
+
+// TopologySpec describes exchanges, queues and bindings. Exchanges and queues
+// declared from a spec are durable. Feature modules contribute specs with
+// ProvideTopology; the component declares them on every connection.
+type TopologySpec struct {
+	Exchanges []ExchangeSpec
+	Queues    []QueueSpec
+	Bindings  []BindingSpec
+}
+
+type ExchangeSpec struct {
+	Name string
+	// Kind is the exchange type, amqp.ExchangeTopic when empty.
+	Kind       string
+	AutoDelete bool
+	// Internal exchanges only receive messages from other exchanges.
+	Internal bool
+	Args     amqp.Table
+}
+
+type QueueSpec struct {
+	Name string
+	// Quorum declares a replicated quorum queue instead of a classic one.
+	Quorum bool
+	// DeadLetterExchange receives the messages rejected without requeue or
+	// expired, with DeadLetterRoutingKey when set and their own otherwise.
+	DeadLetterExchange   string
+	DeadLetterRoutingKey string
+	// MessageTTL expires messages waiting in the queue longer, when set.
+	MessageTTL time.Duration
+	// Args are passed as is, for anything the fields above do not cover.
+	Args amqp.Table
+}
+
+// BindingSpec routes the messages of Exchange with a matching RoutingKey to
+// Queue.
+type BindingSpec struct {
+	Queue      string
+	Exchange   string
+	RoutingKey string
+	Args       amqp.Table
+}
+
+// ProvideTopology contributes spec to the topology the component declares.
+func ProvideTopology(spec TopologySpec) fx.Option {
+	return fx.Provide(
+		fx.Annotate(
+			func() TopologySpec { return spec },
+			fx.ResultTags(TopologyGroup),
+		),
+	)
+}
+
+// Declare declares the exchanges, then the queues, then the bindings. It is a
+// Topology, so a spec can also be passed to DeclareTopology directly.
+func (s TopologySpec) Declare(ch *amqp.Channel) error {
+	for _, exchange := range s.Exchanges {
+		if err := ch.ExchangeDeclare(exchange.Name, exchange.kind(), true, exchange.AutoDelete, exchange.Internal, false, exchange.Args); err != nil {
+			return fmt.Errorf("declare exchange %q: %w", exchange.Name, err)
+		}
+	}
+	for _, queue := range s.Queues {
+		if _, err := ch.QueueDeclare(queue.Name, true, false, false, false, queue.Arguments()); err != nil {
+			return fmt.Errorf("declare queue %q: %w", queue.Name, err)
+		}
+	}
+	for _, binding := range s.Bindings {
+		if err := ch.QueueBind(binding.Queue, binding.RoutingKey, binding.Exchange, false, binding.Args); err != nil {
+			return fmt.Errorf("bind queue %q to exchange %q with %q: %w", binding.Queue, binding.Exchange, binding.RoutingKey, err)
+		}
+	}
+	return nil
+}
+
+func (e ExchangeSpec) kind() string {
+	if e.Kind == "" {
+		return amqp.ExchangeTopic
+	}
+	return e.Kind
+}
+
+// Arguments returns the queue arguments the spec is declared with.
+func (q QueueSpec) Arguments() amqp.Table {
+	args := amqp.Table{}
+	for k, v := range q.Args {
+		args[k] = v
+	}
+	if q.Quorum {
+		args[amqp.QueueTypeArg] = amqp.QueueTypeQuorum
+	}
+	if q.DeadLetterExchange != "" {
+		args["x-dead-letter-exchange"] = q.DeadLetterExchange
+	}
+	if q.DeadLetterRoutingKey != "" {
+		args["x-dead-letter-routing-key"] = q.DeadLetterRoutingKey
+	}
+	if q.MessageTTL > 0 {
+		args[amqp.QueueMessageTTLArg] = q.MessageTTL.Milliseconds()
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	return args
+}
+
+// MergeTopologies combines specs into one. An exchange or queue may be
+// declared by several specs as long as they agree; conflicting declarations
+// fail here rather than on the broker, which would refuse the second one.
+func MergeTopologies(specs ...TopologySpec) (TopologySpec, error) {
+	var merged TopologySpec
+	exchanges := map[string]ExchangeSpec{}
+	queues := map[string]QueueSpec{}
+	bindings := map[string]BindingSpec{}
+
+	for _, spec := range specs {
+		for _, exchange := range spec.Exchanges {
+			if exchange.Name == "" {
+				return TopologySpec{}, errors.New("exchange without a name")
+			}
+			declared, ok := exchanges[exchange.Name]
+			if !ok {
+				exchanges[exchange.Name] = exchange
+				merged.Exchanges = append(merged.Exchanges, exchange)
+				continue
+			}
+			if declared.kind() != exchange.kind() || declared.AutoDelete != exchange.AutoDelete ||
+				declared.Internal != exchange.Internal || !reflect.DeepEqual(declared.Args, exchange.Args) {
+				return TopologySpec{}, fmt.Errorf("exchange %q is declared twice with different settings", exchange.Name)
+			}
+		}
+
+		for _, queue := range spec.Queues {
+			if queue.Name == "" {
+				return TopologySpec{}, errors.New("queue without a name")
+			}
+			declared, ok := queues[queue.Name]
+			if !ok {
+				queues[queue.Name] = queue
+				merged.Queues = append(merged.Queues, queue)
+				continue
+			}
+			if !reflect.DeepEqual(declared.Arguments(), queue.Arguments()) {
+				return TopologySpec{}, fmt.Errorf("queue %q is declared twice with different settings", queue.Name)
+			}
+		}
+
+		for _, binding := range spec.Bindings {
+			key := binding.Queue + "\x00" + binding.Exchange + "\x00" + binding.RoutingKey
+			if declared, ok := bindings[key]; ok {
+				if !reflect.DeepEqual(declared.Args, binding.Args) {
+					return TopologySpec{}, fmt.Errorf("queue %q is bound to exchange %q with %q twice with different arguments", binding.Queue, binding.Exchange, binding.RoutingKey)
+				}
+				continue
+			}
+			bindings[key] = binding
+			merged.Bindings = append(merged.Bindings, binding)
+		}
+	}
+	return merged, nil
+}
@@ -0,0 +1,109 @@
+package rabbitmq_comp
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+func TestMergeTopologiesDeduplicatesAgreeingDeclarations(t *testing.T) {
+	events := TopologySpec{
+		Exchanges: []ExchangeSpec{{Name: "domain_events"}},
+		Queues:    []QueueSpec{{Name: "mailer", Quorum: true, MessageTTL: time.Minute}},
+		Bindings:  []BindingSpec{{Queue: "mailer", Exchange: "domain_events", RoutingKey: "user.created"}},
+	}
+	// The same exchange and queue, spelled differently, and a new binding.
+	mailer := TopologySpec{
+		Exchanges: []ExchangeSpec{{Name: "domain_events", Kind: amqp.ExchangeTopic}},
+		Queues: []QueueSpec{{Name: "mailer", Args: amqp.Table{
+			amqp.QueueTypeArg:       amqp.QueueTypeQuorum,
+			amqp.QueueMessageTTLArg: int64(60000),
+		}}},
+		Bindings: []BindingSpec{
+			{Queue: "mailer", Exchange: "domain_events", RoutingKey: "user.created"},
+			{Queue: "mailer", Exchange: "domain_events", RoutingKey: "user.deleted"},
+		},
+	}
+
+	merged, err := MergeTopologies(events, mailer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(merged.Exchanges) != 1 || len(merged.Queues) != 1 || len(merged.Bindings) != 2 {
+		t.Fatalf("merged %d exchanges, %d queues and %d bindings, want 1, 1 and 2",
+			len(merged.Exchanges), len(merged.Queues), len(merged.Bindings))
+	}
+	// The first declaration wins, in the order the specs came in.
+	if merged.Queues[0].MessageTTL != time.Minute {
+		t.Errorf("merged queue = %+v, want the first declaration", merged.Queues[0])
+	}
+	if merged.Bindings[0].RoutingKey != "user.created" || merged.Bindings[1].RoutingKey != "user.deleted" {
+		t.Errorf("bindings = %+v, want user.created then user.deleted", merged.Bindings)
+	}
+}
+
+func TestMergeTopologiesRejectsConflictingDeclarations(t *testing.T) {
+	tests := []struct {
+		name  string
+		first TopologySpec
+		other TopologySpec
+		want  string
+	}{
+		{
+			name:  "exchange kind",
+			first: TopologySpec{Exchanges: []ExchangeSpec{{Name: "events"}}},
+			other: TopologySpec{Exchanges: []ExchangeSpec{{Name: "events", Kind: amqp.ExchangeFanout}}},
+			want:  `exchange "events"`,
+		},
+		{
+			name:  "exchange auto-delete",
+			first: TopologySpec{Exchanges: []ExchangeSpec{{Name: "events"}}},
+			other: TopologySpec{Exchanges: []ExchangeSpec{{Name: "events", AutoDelete: true}}},
+			want:  `exchange "events"`,
+		},
+		{
+			name:  "exchange arguments",
+			first: TopologySpec{Exchanges: []ExchangeSpec{{Name: "events", Args: amqp.Table{"alternate-exchange": "a"}}}},
+			other: TopologySpec{Exchanges: []ExchangeSpec{{Name: "events", Args: amqp.Table{"alternate-exchange": "b"}}}},
+			want:  `exchange "events"`,
+		},
+		{
+			name:  "queue type",
+			first: TopologySpec{Queues: []QueueSpec{{Name: "mailer", Quorum: true}}},
+			other: TopologySpec{Queues: []QueueSpec{{Name: "mailer"}}},
+			want:  `queue "mailer"`,
+		},
+		{
+			name:  "queue dead letter exchange",
+			first: TopologySpec{Queues: []QueueSpec{{Name: "mailer", DeadLetterExchange: "dlx"}}},
+			other: TopologySpec{Queues: []QueueSpec{{Name: "mailer", DeadLetterExchange: "other"}}},
+			want:  `queue "mailer"`,
+		},
+		{
+			name:  "binding arguments",
+			first: TopologySpec{Bindings: []BindingSpec{{Queue: "q", Exchange: "e", RoutingKey: "k"}}},
+			other: TopologySpec{Bindings: []BindingSpec{{Queue: "q", Exchange: "e", RoutingKey: "k", Args: amqp.Table{"x-match": "all"}}}},
+			want:  `queue "q" is bound to exchange "e"`,
+		},
+		{
+			name:  "unnamed exchange",
+			other: TopologySpec{Exchanges: []ExchangeSpec{{Kind: amqp.ExchangeDirect}}},
+			want:  "exchange without a name",
+		},
+		{
+			name:  "unnamed queue",
+			other: TopologySpec{Queues: []QueueSpec{{Quorum: true}}},
+			want:  "queue without a name",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := MergeTopologies(tt.first, tt.other)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %v, want one about %s", err, tt.want)
+			}
+		})
+	}
+}
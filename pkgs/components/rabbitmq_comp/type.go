@@ -2,13 +2,58 @@ package rabbitmq_comp
 
 import (
 	"context"
+	"errors"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
+var (
+	ErrNotConnected       = errors.New("rabbitmq is not connected")
+	ErrPublishBufferFull  = errors.New("rabbitmq publish buffer is full")
+	ErrClientClosed       = errors.New("rabbitmq client is closed")
+	errChannelPoolDrained = errors.New("rabbitmq channel pool is closed")
+)
+
+// Topology declares exchanges, queues and bindings on ch. It is run again
+// after every reconnection, so it must be idempotent.
+type Topology func(ch *amqp.Channel) error
+
+type ConsumeOptions struct {
+	Queue string
+	// Consumer is the consumer tag, generated when empty.
+	Consumer  string
+	AutoAck   bool
+	Exclusive bool
+	NoLocal   bool
+	Args      amqp.Table
+	// Prefetch limits the unacked deliveries of the consumer, the component
+	// prefetch count when zero.
+	Prefetch int
+}
+
 type IRabbitMQClient interface {
+	// Publish sends msg on a pooled channel without waiting for the broker.
+	// While reconnecting, all publishes wait or fail fast as configured by
+	// the publish mode.
 	Publish(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error
-	Consume(queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table) (<-chan amqp.Delivery, error)
+	// PublishDeferred publishes msg as mandatory and returns without waiting
+	// for the broker; wait on the confirmation for the outcome.
+	PublishDeferred(ctx context.Context, exchange, key string, msg amqp.Publishing) (*Confirmation, error)
+	// PublishConfirmed publishes msg as mandatory and waits until the broker
+	// acks it. It fails with ErrUnroutable when no queue is bound for the
+	// message, ErrPublishNacked when the broker nacks it and ErrConfirmLost
+	// when the connection is lost first.
+	PublishConfirmed(ctx context.Context, exchange, key string, msg amqp.Publishing) error
+	// Consume subscribes to a queue. The subscription is renewed after every
+	// reconnection; the returned channel is closed once ctx is done and the
+	// deliveries already received are passed on.
+	Consume(ctx context.Context, opts ConsumeOptions) (<-chan amqp.Delivery, error)
+	// DeclareTopology runs declare now if connected, and after every
+	// reconnection.
+	DeclareTopology(declare Topology) error
+	// Channel opens a channel the caller manages; it does not survive a
+	// reconnection.
 	Channel() (*amqp.Channel, error)
+	IsConnected() bool
 	Close() error
 }
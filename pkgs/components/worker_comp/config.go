@@ -0,0 +1,18 @@
+package worker_comp
+
+import "time"
+
+type WorkerConfig struct {
+	Exchange         string
+	Concurrency      int
+	QueueConcurrency map[string]int
+	DrainTimeout     time.Duration
+}
+
+// ConcurrencyFor returns how many messages of queue are handled at once.
+func (c *WorkerConfig) ConcurrencyFor(queue string) int {
+	if concurrency, ok := c.QueueConcurrency[queue]; ok {
+		return concurrency
+	}
+	return c.Concurrency
+}
@@ -0,0 +1,37 @@
+package worker_comp
+
+import "testing"
+
+func TestParseQueueConcurrency(t *testing.T) {
+	concurrency, err := parseQueueConcurrency(" emails=8, ,search = 1 ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(concurrency) != 2 || concurrency["emails"] != 8 || concurrency["search"] != 1 {
+		t.Fatalf("concurrency = %v, want emails=8 and search=1", concurrency)
+	}
+
+	empty, err := parseQueueConcurrency("")
+	if err != nil || len(empty) != 0 {
+		t.Fatalf("empty value = %v, %v, want no overrides", empty, err)
+	}
+}
+
+func TestParseQueueConcurrencyRejectsInvalidEntries(t *testing.T) {
+	for _, value := range []string{"emails", "emails=", "=4", "emails=four", "emails=0", "emails=-1", "emails=8,search"} {
+		if _, err := parseQueueConcurrency(value); err == nil {
+			t.Errorf("parseQueueConcurrency(%q) accepted an invalid entry", value)
+		}
+	}
+}
+
+func TestConcurrencyForFallsBackToTheDefault(t *testing.T) {
+	config := &WorkerConfig{Concurrency: 4, QueueConcurrency: map[string]int{"emails": 8}}
+
+	if got := config.ConcurrencyFor("emails"); got != 8 {
+		t.Errorf("emails concurrency = %d, want the override 8", got)
+	}
+	if got := config.ConcurrencyFor("search"); got != 4 {
+		t.Errorf("search concurrency = %d, want the default 4", got)
+	}
+}
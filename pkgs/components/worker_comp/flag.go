@@ -0,0 +1,72 @@
+package worker_comp
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	workerExchangeVal         string
+	workerConcurrencyVal      int
+	workerQueueConcurrencyVal string
+	workerDrainTimeoutVal     time.Duration
+)
+
+var (
+	WorkerExchange         = &workerExchangeVal
+	WorkerConcurrency      = &workerConcurrencyVal
+	WorkerQueueConcurrency = &workerQueueConcurrencyVal
+	WorkerDrainTimeout     = &workerDrainTimeoutVal
+)
+
+func init() {
+	if flag.Lookup("worker-exchange") == nil {
+		flag.StringVar(&workerExchangeVal, "worker-exchange", "domain_events", "Topic exchange the worker binds consumer queues to")
+	}
+	if flag.Lookup("worker-concurrency") == nil {
+		flag.IntVar(&workerConcurrencyVal, "worker-concurrency", 4, "Messages of one queue the worker handles at once")
+	}
+	if flag.Lookup("worker-queue-concurrency") == nil {
+		flag.StringVar(&workerQueueConcurrencyVal, "worker-queue-concurrency", "", "Comma-separated per-queue overrides of -worker-concurrency, e.g. emails=8,search=1")
+	}
+	if flag.Lookup("worker-drain-timeout") == nil {
+		flag.DurationVar(&workerDrainTimeoutVal, "worker-drain-timeout", 25*time.Second, "How long the worker waits on shutdown for messages in progress")
+	}
+}
+
+func LoadWorkerConfig() (*WorkerConfig, error) {
+	if workerConcurrencyVal < 1 {
+		return nil, fmt.Errorf("worker-concurrency must be at least 1, got %d", workerConcurrencyVal)
+	}
+
+	queueConcurrency, err := parseQueueConcurrency(workerQueueConcurrencyVal)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WorkerConfig{
+		Exchange:         workerExchangeVal,
+		Concurrency:      workerConcurrencyVal,
+		QueueConcurrency: queueConcurrency,
+		DrainTimeout:     workerDrainTimeoutVal,
+	}, nil
+}
+
+func parseQueueConcurrency(value string) (map[string]int, error) {
+	concurrency := map[string]int{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		queue, count, ok := strings.Cut(item, "=")
+		n, err := strconv.Atoi(strings.TrimSpace(count))
+		if !ok || strings.TrimSpace(queue) == "" || err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid worker-queue-concurrency entry %q, want queue=n with n at least 1", item)
+		}
+		concurrency[strings.TrimSpace(queue)] = n
+	}
+	return concurrency, nil
+}
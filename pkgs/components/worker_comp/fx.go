@@ -0,0 +1,41 @@
+package worker_comp
+
+import (
+	"context"
+
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/logger"
+	"go.uber.org/fx"
+)
+
+func ProvideWorkerConfig() (*WorkerConfig, error) {
+	return LoadWorkerConfig()
+}
+
+// WorkerComponentFx runs the consumers registered with ProvideConsumer while
+// the app runs. It needs the RabbitMQ component.
+var WorkerComponentFx = fx.Module("worker",
+	fx.Provide(ProvideWorkerConfig),
+	fx.Provide(NewRegistry),
+	fx.Provide(NewRuntime),
+	fx.Invoke(registerRuntimeHooks),
+)
+
+func registerRuntimeHooks(lc fx.Lifecycle, runtime *Runtime, log logger.Logger) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			if err := runtime.Start(); err != nil {
+				return err
+			}
+			log.Info("Worker runtime started")
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			if err := runtime.Stop(ctx); err != nil {
+				log.Errorf("worker runtime did not drain: %v", err)
+				return err
+			}
+			log.Info("Worker runtime drained")
+			return nil
+		},
+	})
+}
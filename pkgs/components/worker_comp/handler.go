@@ -0,0 +1,77 @@
+package worker_comp
+
+import (
+	"context"
+
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/rabbitmq_comp"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.uber.org/fx"
+)
+
+const ConsumerGroup = `group:"worker_consumers"`
+
+// Handler processes one message. A nil error acks it. An error requeues the
+// message once, right away; when it fails again it is rejected, and goes to
+// the queue's dead-letter exchange if it has one. The retry is told apart by
+// the broker's redelivered flag, which is also set on messages requeued by a
+// shutdown or a lost connection, so those get no retry after a failure.
+// Handlers that need delayed or repeated retries should use a dead-letter
+// queue with a message TTL.
+type Handler interface {
+	Handle(ctx context.Context, msg amqp.Delivery) error
+}
+
+type HandlerFunc func(ctx context.Context, msg amqp.Delivery) error
+
+func (f HandlerFunc) Handle(ctx context.Context, msg amqp.Delivery) error {
+	return f(ctx, msg)
+}
+
+// EnvelopeHandler decodes the envelope of each message for handle, see
+// rabbitmq_comp.Publish. A message that is not a valid envelope fails like
+// any other.
+func EnvelopeHandler[T any](handle func(ctx context.Context, envelope *rabbitmq_comp.Envelope[T]) error) HandlerFunc {
+	return func(ctx context.Context, msg amqp.Delivery) error {
+		envelope, err := rabbitmq_comp.DecodeEnvelope[T](msg.Body)
+		if err != nil {
+			return err
+		}
+		return handle(ctx, envelope)
+	}
+}
+
+// Consumer binds a handler to a durable queue, which receives the messages
+// published to the worker exchange with any of RoutingKeys, e.g.
+// "user.signed_up" or "blog.*".
+type Consumer struct {
+	Queue       rabbitmq_comp.QueueSpec
+	RoutingKeys []string
+	Handler     Handler
+}
+
+// ProvideConsumer registers a consumer of queue, which is declared with its
+// spec. newHandler is an fx constructor whose result implements Handler; its
+// dependencies are injected like any other constructor's.
+func ProvideConsumer(queue rabbitmq_comp.QueueSpec, routingKeys []string, newHandler any) fx.Option {
+	return fx.Module("consumer:"+queue.Name,
+		fx.Provide(
+			fx.Private,
+			fx.Annotate(
+				newHandler,
+				fx.As(new(Handler)),
+			),
+		),
+		fx.Provide(
+			fx.Annotate(
+				func(handler Handler) *Consumer {
+					return &Consumer{
+						Queue:       queue,
+						RoutingKeys: routingKeys,
+						Handler:     handler,
+					}
+				},
+				fx.ResultTags(ConsumerGroup),
+			),
+		),
+	)
+}
@@ -0,0 +1,62 @@
+package worker_comp
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"go.uber.org/fx"
+)
+
+// Registry holds the registered consumers, one per queue.
+type Registry struct {
+	consumers map[string]*Consumer
+}
+
+type RegistryParams struct {
+	fx.In
+	Consumers []*Consumer `group:"worker_consumers"`
+}
+
+func NewRegistry(p RegistryParams) (*Registry, error) {
+	registry := &Registry{
+		consumers: make(map[string]*Consumer, len(p.Consumers)),
+	}
+	for _, consumer := range p.Consumers {
+		if err := registry.Register(consumer); err != nil {
+			return nil, err
+		}
+	}
+	return registry, nil
+}
+
+func (r *Registry) Register(consumer *Consumer) error {
+	if consumer.Queue.Name == "" {
+		return errors.New("worker consumer has no queue")
+	}
+	if consumer.Handler == nil {
+		return fmt.Errorf("worker consumer of queue %q has no handler", consumer.Queue.Name)
+	}
+	if _, ok := r.consumers[consumer.Queue.Name]; ok {
+		return fmt.Errorf("queue %q already has a worker consumer", consumer.Queue.Name)
+	}
+	r.consumers[consumer.Queue.Name] = consumer
+	return nil
+}
+
+func (r *Registry) Lookup(queue string) (*Consumer, bool) {
+	consumer, ok := r.consumers[queue]
+	return consumer, ok
+}
+
+// Consumers returns the consumers ordered by queue.
+func (r *Registry) Consumers() []*Consumer {
+	consumers := make([]*Consumer, 0, len(r.consumers))
+	for _, consumer := range r.consumers {
+		consumers = append(consumers, consumer)
+	}
+	sort.Slice(consumers, func(i, j int) bool {
+		return consumers[i].Queue.Name < consumers[j].Queue.Name
+	})
+	return consumers
+}
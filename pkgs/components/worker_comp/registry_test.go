@@ -0,0 +1,51 @@
+package worker_comp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/rabbitmq_comp"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+var nopHandler = HandlerFunc(func(ctx context.Context, msg amqp.Delivery) error { return nil })
+
+func testConsumer(queue string) *Consumer {
+	return &Consumer{Queue: rabbitmq_comp.QueueSpec{Name: queue}, Handler: nopHandler}
+}
+
+func TestNewRegistryRejectsDuplicateQueues(t *testing.T) {
+	_, err := NewRegistry(RegistryParams{Consumers: []*Consumer{testConsumer("emails"), testConsumer("emails")}})
+	if err == nil {
+		t.Fatal("registered two consumers of one queue")
+	}
+}
+
+func TestRegistryRejectsIncompleteConsumers(t *testing.T) {
+	registry, err := NewRegistry(RegistryParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := registry.Register(testConsumer("")); err == nil {
+		t.Error("registered a consumer without a queue")
+	}
+	if err := registry.Register(&Consumer{Queue: rabbitmq_comp.QueueSpec{Name: "emails"}}); err == nil {
+		t.Error("registered a consumer without a handler")
+	}
+	if _, ok := registry.Lookup("emails"); ok {
+		t.Error("rejected consumer was registered")
+	}
+}
+
+func TestRegistryConsumersAreOrderedByQueue(t *testing.T) {
+	registry, err := NewRegistry(RegistryParams{Consumers: []*Consumer{testConsumer("search"), testConsumer("emails")}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	consumers := registry.Consumers()
+	if len(consumers) != 2 || consumers[0].Queue.Name != "emails" || consumers[1].Queue.Name != "search" {
+		t.Fatalf("consumers = %v, want emails then search", consumers)
+	}
+}
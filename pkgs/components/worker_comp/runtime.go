@@ -0,0 +1,225 @@
+package worker_comp
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/rabbitmq_comp"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/logger"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/utils/request_id"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "worker"
+
+// Runtime runs the registered consumers. Each queue is consumed with a
+// prefetch of its concurrency and as many goroutines handling its messages.
+// Subscriptions survive reconnections of the RabbitMQ component.
+type Runtime struct {
+	client   rabbitmq_comp.IRabbitMQClient
+	registry *Registry
+	config   *WorkerConfig
+	log      logger.Logger
+	tracer   trace.Tracer
+
+	// consuming is cancelled on shutdown to stop taking messages.
+	consuming     context.Context
+	stopConsuming context.CancelFunc
+	// ctx is the parent of every handler's context. It is cancelled when the
+	// drain on shutdown times out.
+	ctx      context.Context
+	cancel   context.CancelFunc
+	inFlight sync.WaitGroup
+}
+
+func NewRuntime(client rabbitmq_comp.IRabbitMQClient, registry *Registry, config *WorkerConfig, log logger.Logger) *Runtime {
+	consuming, stopConsuming := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Runtime{
+		client:        client,
+		registry:      registry,
+		config:        config,
+		log:           log,
+		tracer:        otel.Tracer(tracerName),
+		consuming:     consuming,
+		stopConsuming: stopConsuming,
+		ctx:           ctx,
+		cancel:        cancel,
+	}
+}
+
+func (r *Runtime) Start() error {
+	consumers := r.registry.Consumers()
+	if len(consumers) == 0 {
+		r.log.Info("No worker consumers registered")
+		return nil
+	}
+
+	for _, consumer := range consumers {
+		if err := r.startConsumer(consumer); err != nil {
+			r.stopConsuming()
+			return fmt.Errorf("start consumer of queue %q: %w", consumer.Queue.Name, err)
+		}
+	}
+	return nil
+}
+
+func (r *Runtime) startConsumer(consumer *Consumer) error {
+	concurrency := r.config.ConcurrencyFor(consumer.Queue.Name)
+
+	topology := rabbitmq_comp.TopologySpec{
+		Exchanges: []rabbitmq_comp.ExchangeSpec{{Name: r.config.Exchange}},
+		Queues:    []rabbitmq_comp.QueueSpec{consumer.Queue},
+	}
+	for _, key := range consumer.RoutingKeys {
+		topology.Bindings = append(topology.Bindings, rabbitmq_comp.BindingSpec{
+			Queue:      consumer.Queue.Name,
+			Exchange:   r.config.Exchange,
+			RoutingKey: key,
+		})
+	}
+	if err := r.client.DeclareTopology(topology.Declare); err != nil {
+		return err
+	}
+
+	deliveries, err := r.client.Consume(r.consuming, rabbitmq_comp.ConsumeOptions{
+		Queue:    consumer.Queue.Name,
+		Prefetch: concurrency,
+	})
+	if err != nil {
+		return err
+	}
+	for i := 0; i < concurrency; i++ {
+		r.inFlight.Add(1)
+		go func() {
+			defer r.inFlight.Done()
+			for msg := range deliveries {
+				r.handle(consumer, msg)
+			}
+		}()
+	}
+
+	r.log.Infow("worker consumer started", logger.Fields{
+		"queue":        consumer.Queue.Name,
+		"routing_keys": consumer.RoutingKeys,
+		"concurrency":  concurrency,
+	})
+	return nil
+}
+
+// Stop stops taking messages and waits for the ones in progress. Handlers
+// still running after DrainTimeout have their context cancelled; messages
+// not acked by then are requeued when the connection closes.
+func (r *Runtime) Stop(ctx context.Context) error {
+	r.stopConsuming()
+
+	drained := make(chan struct{})
+	go func() {
+		r.inFlight.Wait()
+		close(drained)
+	}()
+
+	timer := time.NewTimer(r.config.DrainTimeout)
+	defer timer.Stop()
+
+	var err error
+	select {
+	case <-drained:
+	case <-timer.C:
+		err = fmt.Errorf("worker drain timed out after %s", r.config.DrainTimeout)
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	r.cancel()
+	return err
+}
+
+func (r *Runtime) handle(consumer *Consumer, msg amqp.Delivery) {
+	ctx := otel.GetTextMapPropagator().Extract(r.ctx, headerCarrier(msg.Headers))
+	if id, ok := msg.Headers["request_id"].(string); ok && id != "" {
+		ctx = request_id.WithValue(ctx, id)
+	}
+
+	ctx, span := r.tracer.Start(ctx, consumer.Queue.Name+" process",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "rabbitmq"),
+			attribute.String("messaging.destination.name", msg.Exchange),
+			attribute.String("messaging.rabbitmq.destination.routing_key", msg.RoutingKey),
+			attribute.String("messaging.message.id", msg.MessageId),
+			attribute.String("messaging.consumer.queue", consumer.Queue.Name),
+		),
+	)
+	defer span.End()
+
+	started := time.Now()
+	err := invoke(ctx, consumer.Handler, msg)
+
+	log := r.log.WithContext(ctx)
+	fields := logger.Fields{
+		"queue":       consumer.Queue.Name,
+		"routing_key": msg.RoutingKey,
+		"message_id":  msg.MessageId,
+		"redelivered": msg.Redelivered,
+		"duration":    time.Since(started).String(),
+	}
+
+	if err == nil {
+		log.Debugw("worker message handled", fields)
+		if ackErr := msg.Ack(false); ackErr != nil {
+			fields["error"] = ackErr.Error()
+			log.Errorw("failed to ack worker message", fields)
+		}
+		return
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+	fields["error"] = err.Error()
+	log.Errorw("worker message handler failed", fields)
+
+	// Requeue once for transient failures; a second failure rejects the
+	// message. Redelivered is the only attempt count RabbitMQ keeps on a
+	// classic requeue, see Handler.
+	if nackErr := msg.Nack(false, !msg.Redelivered); nackErr != nil {
+		fields["error"] = nackErr.Error()
+		log.Errorw("failed to nack worker message", fields)
+	}
+}
+
+// invoke runs the handler, turning a panic into an error so that one bad
+// message does not stop the worker.
+func invoke(ctx context.Context, handler Handler, msg amqp.Delivery) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("handler panicked: %v", recovered)
+		}
+	}()
+	return handler.Handle(ctx, msg)
+}
+
+// headerCarrier reads and writes trace context in message headers.
+type headerCarrier amqp.Table
+
+func (c headerCarrier) Get(key string) string {
+	value, _ := c[key].(string)
+	return value
+}
+
+func (c headerCarrier) Set(key, value string) {
+	c[key] = value
+}
+
+func (c headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,188 @@
+package worker_comp
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/rabbitmq_comp"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/global_config"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/logger"
+	log_cfg "github.com/dukk308/beetool.dev-go-starter/pkgs/logger/config"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// fakeClient hands the deliveries sent on queue to the single consumer.
+type fakeClient struct {
+	rabbitmq_comp.IRabbitMQClient
+	queue chan amqp.Delivery
+}
+
+func (c *fakeClient) DeclareTopology(declare rabbitmq_comp.Topology) error {
+	return nil
+}
+
+func (c *fakeClient) Consume(ctx context.Context, opts rabbitmq_comp.ConsumeOptions) (<-chan amqp.Delivery, error) {
+	deliveries := make(chan amqp.Delivery)
+	go func() {
+		defer close(deliveries)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg := <-c.queue:
+				deliveries <- msg
+			}
+		}
+	}()
+	return deliveries, nil
+}
+
+// settlement records how each delivery was settled.
+type settlement struct {
+	mu      sync.Mutex
+	acked   int
+	requeue []bool
+	done    chan struct{}
+}
+
+func newSettlement() *settlement {
+	return &settlement{done: make(chan struct{}, 16)}
+}
+
+func (s *settlement) Ack(tag uint64, multiple bool) error {
+	s.mu.Lock()
+	s.acked++
+	s.mu.Unlock()
+	s.done <- struct{}{}
+	return nil
+}
+
+func (s *settlement) Nack(tag uint64, multiple, requeue bool) error {
+	s.mu.Lock()
+	s.requeue = append(s.requeue, requeue)
+	s.mu.Unlock()
+	s.done <- struct{}{}
+	return nil
+}
+
+func (s *settlement) Reject(tag uint64, requeue bool) error {
+	return s.Nack(tag, false, requeue)
+}
+
+func (s *settlement) wait(t *testing.T) {
+	t.Helper()
+
+	select {
+	case <-s.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("delivery was never settled")
+	}
+}
+
+func newTestRuntime(t *testing.T, handler Handler, drainTimeout time.Duration) (*Runtime, *fakeClient) {
+	t.Helper()
+
+	registry, err := NewRegistry(RegistryParams{Consumers: []*Consumer{{
+		Queue:   rabbitmq_comp.QueueSpec{Name: "emails"},
+		Handler: handler,
+	}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &fakeClient{queue: make(chan amqp.Delivery)}
+	log := logger.NewZapLogger(&log_cfg.LogOptions{}, &global_config.GlobalConfig{LogLevel: "fatal"})
+	runtime := NewRuntime(client, registry, &WorkerConfig{
+		Exchange:     "domain_events",
+		Concurrency:  1,
+		DrainTimeout: drainTimeout,
+	}, log)
+	if err := runtime.Start(); err != nil {
+		t.Fatal(err)
+	}
+	return runtime, client
+}
+
+func TestInvokeTurnsPanicsIntoErrors(t *testing.T) {
+	err := invoke(context.Background(), HandlerFunc(func(ctx context.Context, msg amqp.Delivery) error {
+		panic("boom")
+	}), amqp.Delivery{})
+	if err == nil {
+		t.Fatal("panic was not returned as an error")
+	}
+}
+
+func TestFailedMessagesAreRequeuedOnlyOnce(t *testing.T) {
+	runtime, client := newTestRuntime(t, HandlerFunc(func(ctx context.Context, msg amqp.Delivery) error {
+		panic("boom")
+	}), time.Second)
+	defer runtime.Stop(context.Background())
+
+	acks := newSettlement()
+	client.queue <- amqp.Delivery{Acknowledger: acks}
+	acks.wait(t)
+	client.queue <- amqp.Delivery{Acknowledger: acks, Redelivered: true}
+	acks.wait(t)
+
+	acks.mu.Lock()
+	defer acks.mu.Unlock()
+	if acks.acked != 0 || len(acks.requeue) != 2 || !acks.requeue[0] || acks.requeue[1] {
+		t.Fatalf("requeue = %v, want the first failure requeued and the redelivered one rejected", acks.requeue)
+	}
+}
+
+func TestStopWaitsForMessagesInProgress(t *testing.T) {
+	release := make(chan struct{})
+	runtime, client := newTestRuntime(t, HandlerFunc(func(ctx context.Context, msg amqp.Delivery) error {
+		<-release
+		return nil
+	}), 5*time.Second)
+
+	acks := newSettlement()
+	client.queue <- amqp.Delivery{Acknowledger: acks}
+	stopped := make(chan error, 1)
+	go func() { stopped <- runtime.Stop(context.Background()) }()
+
+	select {
+	case err := <-stopped:
+		t.Fatalf("Stop returned %v with a message in progress", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(release)
+	if err := <-stopped; err != nil {
+		t.Fatal(err)
+	}
+	if acks.acked != 1 {
+		t.Fatal("drained message was not acked")
+	}
+}
+
+func TestStopCancelsHandlersAfterTheDrainTimeout(t *testing.T) {
+	cancelled := make(chan error, 1)
+	runtime, client := newTestRuntime(t, HandlerFunc(func(ctx context.Context, msg amqp.Delivery) error {
+		<-ctx.Done()
+		cancelled <- ctx.Err()
+		return ctx.Err()
+	}), 50*time.Millisecond)
+
+	client.queue <- amqp.Delivery{Acknowledger: newSettlement()}
+	started := time.Now()
+	err := runtime.Stop(context.Background())
+	if err == nil {
+		t.Fatal("Stop did not report the drain timeout")
+	}
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Fatalf("Stop took %s with a 50ms drain timeout", elapsed)
+	}
+
+	select {
+	case err := <-cancelled:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("handler context ended with %v, want it cancelled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("handler context was not cancelled after the drain timeout")
+	}
+}
@@ -3,7 +3,6 @@ package constants
 const (
 	ContextKeyRequestID     = "RequestID"
 	ContextKeyError         = "Error"
-	ContextKeyUserID        = "UserID"
 	ContextKeyTenantID      = "TenantID"
 	ContextKeyUsername      = "Username"
 	ContextKeyEmail         = "Email"
@@ -7,6 +7,7 @@ import (
 	"github.com/dukk308/beetool.dev-go-starter/pkgs/constants"
 	"github.com/dukk308/beetool.dev-go-starter/pkgs/global_config"
 	log_cfg "github.com/dukk308/beetool.dev-go-starter/pkgs/logger/config"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/types"
 	"github.com/dukk308/beetool.dev-go-starter/pkgs/utils/request_id"
 	"github.com/uptrace/opentelemetry-go-extra/otelzap"
 	"go.opentelemetry.io/otel/trace"
@@ -98,9 +99,8 @@ func (l *zapLogger) WithContext(ctx context.Context) Logger {
 		fields = append(fields, zap.String("username", username))
 	}
 
-	userID, isHasUserID := ctx.Value(constants.ContextKeyUserID).(string)
-	if isHasUserID {
-		fields = append(fields, zap.String("user_id", userID))
+	if user, ok := types.UserFromContext(ctx); ok {
+		fields = append(fields, zap.String("user_id", user.GetID()))
 	}
 
 	if len(fields) > 0 {
@@ -127,9 +127,8 @@ func (l *zapLogger) WithUserInfo(ctx context.Context) Logger {
 		fields = append(fields, zap.String("username", username))
 	}
 
-	userID, isHasUserID := ctx.Value(constants.ContextKeyUserID).(string)
-	if isHasUserID {
-		fields = append(fields, zap.String("user_id", userID))
+	if user, ok := types.UserFromContext(ctx); ok {
+		fields = append(fields, zap.String("user_id", user.GetID()))
 	}
 
 	if len(fields) > 0 {
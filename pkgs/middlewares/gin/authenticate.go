@@ -1,50 +1,38 @@
 package middleware
 
 import (
-	"context"
-	"strings"
+	"fmt"
 
 	auth_domain "github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
 	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gin_comp"
-	"github.com/dukk308/beetool.dev-go-starter/pkgs/constants"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/logger"
 	"github.com/dukk308/beetool.dev-go-starter/pkgs/types"
 	"github.com/gin-gonic/gin"
 )
 
-func Authenticate(tokenService auth_domain.ITokenService) gin.HandlerFunc {
+// Authenticate stores the request principal in the context, where
+// types.UserFromContext finds it for handlers, authorization checks, the
+// request logger and the GORM audit hook. API keys limited to scopes are
+// checked against the request method.
+func Authenticate(authenticator auth_domain.IAuthenticator) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			gin_comp.ResponseError(c, auth_domain.ErrInvalidToken)
-			c.Abort()
-			return
-		}
-
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			gin_comp.ResponseError(c, auth_domain.ErrInvalidToken)
+		ctx := c.Request.Context()
+		user, err := authenticator.Authenticate(ctx, c.GetHeader("Authorization"))
+		if err != nil {
+			gin_comp.ResponseError(c, err)
 			c.Abort()
 			return
 		}
 
-		token := parts[1]
-		claims, err := tokenService.ValidateToken(token)
-		if err != nil {
-			gin_comp.ResponseError(c, err)
+		if scope := auth_domain.RequiredAPIKeyScope(c.Request.Method); !user.HasScope(scope) {
+			gin_comp.ResponseError(c, base.NewForbiddenError(fmt.Sprintf("api key lacks the %q scope", scope)))
 			c.Abort()
 			return
 		}
 
-		ctx := context.WithValue(
-			c.Request.Context(),
-			constants.ContextKeyUserInfo,
-			&types.UserAuthenticated{
-				ID:    claims.UserID,
-				Email: claims.Email,
-				Role:  claims.Role,
-			},
-		)
-
+		ctx = types.WithUser(ctx, user)
+		ctx = logger.ToUserContext(ctx, logger.FromContext(ctx))
 		c.Request = c.Request.WithContext(ctx)
 		c.Next()
 	}
@@ -1,9 +1,10 @@
 package middleware
 
 import (
+	auth_domain "github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/authz"
 	"github.com/dukk308/beetool.dev-go-starter/pkgs/base"
 	"github.com/dukk308/beetool.dev-go-starter/pkgs/components/gin_comp"
-	"github.com/dukk308/beetool.dev-go-starter/pkgs/constants"
 	"github.com/dukk308/beetool.dev-go-starter/pkgs/types"
 	"github.com/gin-gonic/gin"
 )
@@ -14,23 +15,52 @@ func RequireRoles(allowedRoles ...string) gin.HandlerFunc {
 		allowed[r] = struct{}{}
 	}
 	return func(c *gin.Context) {
-		v := c.Request.Context().Value(constants.ContextKeyUserInfo)
-		if v == nil {
+		user, ok := types.UserFromContext(c.Request.Context())
+		if !ok {
 			gin_comp.ResponseError(c, base.NewUnauthorizedError("authentication required"))
 			c.Abort()
 			return
 		}
-		user, ok := v.(*types.UserAuthenticated)
+		if _, ok := allowed[user.GetRole()]; !ok {
+			gin_comp.ResponseError(c, base.NewForbiddenError("insufficient permissions"))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// DenyAPIKeys rejects requests authenticated with an API key, for actions that
+// need an interactive sign-in such as managing credentials.
+func DenyAPIKeys() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := types.UserFromContext(c.Request.Context())
 		if !ok {
 			gin_comp.ResponseError(c, base.NewUnauthorizedError("authentication required"))
 			c.Abort()
 			return
 		}
-		if _, ok := allowed[user.GetRole()]; !ok {
-			gin_comp.ResponseError(c, base.NewForbiddenError("insufficient permissions"))
+		if user.IsAPIKey() {
+			gin_comp.ResponseError(c, auth_domain.ErrAPIKeyNotAllowed)
 			c.Abort()
 			return
 		}
 		c.Next()
 	}
 }
+
+// RequirePermission allows the request when the authenticated user's role
+// grants every listed permission in the authz policy.
+func RequirePermission(authorizer authz.IAuthorizer, permissions ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, _ := types.UserFromContext(c.Request.Context())
+		for _, permission := range permissions {
+			if err := authorizer.Authorize(user, permission); err != nil {
+				gin_comp.ResponseError(c, err)
+				c.Abort()
+				return
+			}
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	auth_domain "github.com/dukk308/beetool.dev-go-starter/internal/modules/auth/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// RouteGroups builds the route sets a feature module exposes, so every module
+// guards its public and admin routes the same way.
+type RouteGroups struct {
+	authenticate gin.HandlerFunc
+}
+
+func NewRouteGroups(authenticator auth_domain.IAuthenticator) *RouteGroups {
+	return &RouteGroups{
+		authenticate: Authenticate(authenticator),
+	}
+}
+
+// Public returns a group without authentication.
+func (g *RouteGroups) Public(router *gin.RouterGroup, path string) *gin.RouterGroup {
+	return router.Group(path)
+}
+
+// Authenticated returns a group that requires a signed-in user.
+func (g *RouteGroups) Authenticated(router *gin.RouterGroup, path string) *gin.RouterGroup {
+	return router.Group(path, g.authenticate)
+}
+
+// Admin returns a group that requires a signed-in user with one of roles.
+// Finer checks, such as ownership, belong to the application commands.
+func (g *RouteGroups) Admin(router *gin.RouterGroup, path string, roles ...string) *gin.RouterGroup {
+	return router.Group(path, g.authenticate, RequireRoles(roles...))
+}
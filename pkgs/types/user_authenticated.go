@@ -1,9 +1,20 @@
 package types
 
+import (
+	"context"
+
+	"github.com/dukk308/beetool.dev-go-starter/pkgs/constants"
+)
+
 type UserAuthenticated struct {
-	ID    string
-	Email string
-	Role  string
+	ID        string
+	Email     string
+	Role      string
+	SessionID string
+	// APIKeyID and Scopes are set when the request authenticated with an API
+	// key instead of an access token.
+	APIKeyID string
+	Scopes   []string
 }
 
 func (u *UserAuthenticated) GetID() string {
@@ -17,3 +28,35 @@ func (u *UserAuthenticated) GetEmail() string {
 func (u *UserAuthenticated) GetRole() string {
 	return u.Role
 }
+
+func (u *UserAuthenticated) GetSessionID() string {
+	return u.SessionID
+}
+
+func (u *UserAuthenticated) IsAPIKey() bool {
+	return u.APIKeyID != ""
+}
+
+// HasScope reports whether the credential allows scope. Access tokens and API
+// keys without scopes are not limited.
+func (u *UserAuthenticated) HasScope(scope string) bool {
+	if len(u.Scopes) == 0 {
+		return true
+	}
+	for _, s := range u.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// WithUser returns a context carrying the authenticated user.
+func WithUser(ctx context.Context, user *UserAuthenticated) context.Context {
+	return context.WithValue(ctx, constants.ContextKeyUserInfo, user)
+}
+
+func UserFromContext(ctx context.Context) (*UserAuthenticated, bool) {
+	user, ok := ctx.Value(constants.ContextKeyUserInfo).(*UserAuthenticated)
+	return user, ok && user != nil
+}